@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/maxkulish/aws-tagger/tagger"
+)
+
+// Config holds settings read from a --config file; command-line flags override them
+type Config struct {
+	Profile string            `json:"profile" yaml:"profile"`
+	Region  string            `json:"region" yaml:"region"`
+	Tags    map[string]string `json:"tags" yaml:"tags"`
+}
+
+// LoadConfig reads a YAML or JSON config file, chosen by extension, and
+// validates its tags. Unknown fields are rejected to catch typos.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := decodeByExtension(path, data, &cfg, "config"); err != nil {
+		return nil, err
+	}
+	if err := validateFileTags(cfg.Tags); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// LoadTagsFile reads a YAML or JSON object of tag keys to values, chosen by
+// extension, and validates it as --tag would
+func LoadTagsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tags file: %w", err)
+	}
+
+	var tags map[string]string
+	if err := decodeByExtension(path, data, &tags, "tags"); err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("invalid tags file %s: no tags found", path)
+	}
+	if err := validateFileTags(tags); err != nil {
+		return nil, fmt.Errorf("invalid tags file %s: %w", path, err)
+	}
+	return tags, nil
+}
+
+// decodeByExtension decodes data into v as JSON or YAML depending on the
+// extension of path, rejecting unknown fields; kind names the file in errors
+func decodeByExtension(path string, data []byte, v interface{}, kind string) error {
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(v)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(v)
+	default:
+		return fmt.Errorf("unsupported %s file extension %q; use .json, .yaml or .yml", kind, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s file %s: %w", kind, path, err)
+	}
+	return nil
+}
+
+// validateFileTags rejects empty keys or values and tags AWS would refuse
+func validateFileTags(tags map[string]string) error {
+	for key, value := range tags {
+		if key == "" || value == "" {
+			return fmt.Errorf("empty tag key or value for %q", key)
+		}
+	}
+	return tagger.ValidateTags(tags, false)
+}
+
+// applyConfig fills profile and region from cfg unless they were set on the command line
+func applyConfig(flags *CLIFlags, cfg *Config) {
+	if cfg.Profile != "" && !flags.isSet("profile", "p") {
+		flags.profile = cfg.Profile
+	}
+	if cfg.Region != "" && !flags.isSet("region", "r") {
+		flags.region = cfg.Region
+	}
+}
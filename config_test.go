@@ -0,0 +1,188 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes content to a temporary file with the given name
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name:    "well-formed YAML",
+			file:    "tagger.yaml",
+			content: "profile: prod\nregion: eu-west-1\ntags:\n  map-migrated: mig12345\n  owner: platform\n",
+			want: &Config{Profile: "prod", Region: "eu-west-1", Tags: map[string]string{
+				"map-migrated": "mig12345", "owner": "platform",
+			}},
+		},
+		{
+			name:    "well-formed JSON",
+			file:    "tagger.json",
+			content: `{"region": "us-east-2", "tags": {"map-migrated": "mig12345"}}`,
+			want:    &Config{Region: "us-east-2", Tags: map[string]string{"map-migrated": "mig12345"}},
+		},
+		{
+			name:    "unknown YAML field",
+			file:    "tagger.yml",
+			content: "profile: prod\nregoin: eu-west-1\n",
+			wantErr: "regoin",
+		},
+		{
+			name:    "unknown JSON field",
+			file:    "tagger.json",
+			content: `{"profile": "prod", "tag": {"a": "b"}}`,
+			wantErr: `unknown field "tag"`,
+		},
+		{
+			name:    "reserved tag key",
+			file:    "tagger.yaml",
+			content: "tags:\n  aws:owner: platform\n",
+			wantErr: "cannot start with 'aws:'",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "tagger.toml",
+			content: "profile = \"prod\"\n",
+			wantErr: "unsupported config file extension",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadConfig(writeConfig(t, tt.file, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	cfg := &Config{Profile: "from-file", Region: "eu-west-1"}
+
+	// Defaults are replaced by the file
+	flags := &CLIFlags{profile: defaultProfile, region: defaultRegion, explicit: map[string]bool{}}
+	applyConfig(flags, cfg)
+	if flags.profile != "from-file" || flags.region != "eu-west-1" {
+		t.Errorf("file values not applied: profile=%s region=%s", flags.profile, flags.region)
+	}
+
+	// Flags given on the command line, including shorthands, win
+	flags = &CLIFlags{profile: "from-cli", region: "us-east-2", explicit: map[string]bool{"profile": true, "r": true}}
+	applyConfig(flags, cfg)
+	if flags.profile != "from-cli" || flags.region != "us-east-2" {
+		t.Errorf("command line overridden by file: profile=%s region=%s", flags.profile, flags.region)
+	}
+}
+
+func TestLoadTagsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "well-formed JSON",
+			file:    "tags.json",
+			content: `{"map-migrated": "mig12345", "cost-center": "a,b"}`,
+			want:    map[string]string{"map-migrated": "mig12345", "cost-center": "a,b"},
+		},
+		{
+			name:    "well-formed YAML",
+			file:    "tags.yaml",
+			content: "map-migrated: mig12345\nowner: platform:core\n",
+			want:    map[string]string{"map-migrated": "mig12345", "owner": "platform:core"},
+		},
+		{
+			name:    "malformed JSON",
+			file:    "tags.json",
+			content: `{"map-migrated": "mig12345",`,
+			wantErr: "invalid tags file",
+		},
+		{
+			name:    "non-string value",
+			file:    "tags.yml",
+			content: "owner:\n  team: platform\n",
+			wantErr: "invalid tags file",
+		},
+		{
+			name:    "empty value",
+			file:    "tags.json",
+			content: `{"owner": ""}`,
+			wantErr: `empty tag key or value for "owner"`,
+		},
+		{
+			name:    "no tags",
+			file:    "tags.json",
+			content: `{}`,
+			wantErr: "no tags found",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "tags.txt",
+			content: "owner:platform\n",
+			wantErr: "unsupported tags file extension",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadTagsFile(writeConfig(t, tt.file, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadTagsFile() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTagsFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadTagsFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagsFileOverriddenByCLI(t *testing.T) {
+	fromFile, err := LoadTagsFile(writeConfig(t, "tags.json", `{"owner": "platform", "env": "dev"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := mergeTagSources([]string{tagSourceCLI, tagSourceFile}, map[string]map[string]string{
+		tagSourceCLI:  parseCustomTags("env:prod"),
+		tagSourceFile: fromFile,
+	})
+	want := map[string]string{"owner": "platform", "env": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged tags = %v, want %v", got, want)
+	}
+}
@@ -2,11 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/maxkulish/aws-tagger/tagger"
@@ -20,15 +25,100 @@ const (
 	defaultTagKey   = "map-migrated"
 )
 
+// Tag sources, merged in --tag-priority order
+const (
+	tagSourceCLI  = "cli"
+	tagSourceEnv  = "env"
+	tagSourceFile = "file"
+	tagSourceMap  = "map"
+
+	defaultTagPriority = "cli,env,file,map"
+)
+
+// tagsEnvVar holds tags in --tag format for the env tag source
+const tagsEnvVar = "AWS_TAGGER_TAGS"
+
+// exitNoResources is the exit status of a run that found no resources with --error-on-empty
+const exitNoResources = 2
+
 // MapTags represents the required MAP 2.0 tags
 var mapTags = map[string]string{}
 
 // CLIFlags holds the command-line arguments
 type CLIFlags struct {
-	profile     string
-	region      string
-	mapKeyValue string
-	tags        string
+	profile       string
+	region        string
+	regions       string
+	regionAlias   bool
+	allRegions    bool
+	regionSummary bool
+	typeSummary   bool
+	useFIPS       bool
+	mapKeyValue   string
+	tags          string
+	ensureKeys    string
+	maxAPIErrors  int
+	retries       int
+	strictTags    bool
+	skipDefaults  bool
+	ownedOnly     bool
+	createdAfter  string
+	logPrefix     string
+	logMinBytes   int64
+	serviceDelay  time.Duration
+	svcTimeout    time.Duration
+	reportFile    string
+	output        string
+	diffAgainst   string
+	service       string
+	resourceType  string
+	glueCatalogs  string
+	glueRole      string
+	roleARN       string
+	accountsFile  string
+	externalID    string
+	eksStatus     string
+	eksVersion    string
+	ecsTypes      string
+	glueResources string
+	idempotent    bool
+	arnsFile      string
+	failedARNs    string
+	outputDir     string
+	checkpoint    string
+	resume        bool
+	taggingAPI    bool
+	skipTagged    bool
+	maxTagKeys    int
+	crossAccount  bool
+	athenaSkipWG  string
+	dryRun        bool
+	verify        bool
+	validateOnly  bool
+	cacheListing  bool
+	concurrency   int
+	osWorkers     int
+	autoConc      bool
+	errorOnEmpty  bool
+	sorted        bool
+	tagPriority   string
+	onlySvcs      string
+	excludeSvcs   string
+	configFile    string
+	tagsFile      string
+
+	// explicit records the flags given on the command line
+	explicit map[string]bool
+}
+
+// isSet reports whether any of the named flags was given on the command line
+func (f *CLIFlags) isSet(names ...string) bool {
+	for _, name := range names {
+		if f.explicit[name] {
+			return true
+		}
+	}
+	return false
 }
 
 // validateTags checks if the tags string is properly formatted
@@ -71,14 +161,230 @@ func parseCustomTags(tagsStr string) map[string]string {
 	return tags
 }
 
+// parseRegionFlags splits a comma-separated --region into its first region,
+// which loads the config, and the list to tag, as with --regions
+func parseRegionFlags(region, regions string) (string, []string, error) {
+	list := parseList(regions)
+	if !strings.Contains(region, ",") {
+		return region, list, nil
+	}
+	if len(list) > 0 {
+		return "", nil, fmt.Errorf("use either a region list in --region or --regions, not both")
+	}
+	list = parseList(region)
+	if len(list) == 0 {
+		return "", nil, fmt.Errorf("--region %q lists no regions", region)
+	}
+	return list[0], list, nil
+}
+
+// regionAliases maps the friendly names accepted with --normalize-region-alias to region codes
+var regionAliases = map[string]string{
+	"virginia":   "us-east-1",
+	"ohio":       "us-east-2",
+	"california": "us-west-1",
+	"oregon":     "us-west-2",
+	"canada":     "ca-central-1",
+	"saopaulo":   "sa-east-1",
+	"ireland":    "eu-west-1",
+	"london":     "eu-west-2",
+	"paris":      "eu-west-3",
+	"frankfurt":  "eu-central-1",
+	"zurich":     "eu-central-2",
+	"stockholm":  "eu-north-1",
+	"milan":      "eu-south-1",
+	"spain":      "eu-south-2",
+	"capetown":   "af-south-1",
+	"bahrain":    "me-south-1",
+	"uae":        "me-central-1",
+	"mumbai":     "ap-south-1",
+	"hongkong":   "ap-east-1",
+	"tokyo":      "ap-northeast-1",
+	"seoul":      "ap-northeast-2",
+	"osaka":      "ap-northeast-3",
+	"singapore":  "ap-southeast-1",
+	"sydney":     "ap-southeast-2",
+	"jakarta":    "ap-southeast-3",
+}
+
+// regionCodePattern matches region codes such as us-east-1 and us-gov-west-1
+var regionCodePattern = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-[0-9]+$`)
+
+// resolveRegionAlias returns the region code for a friendly name such as
+// virginia or "Sao Paulo"; region codes pass through unchanged
+func resolveRegionAlias(name string) (string, error) {
+	region := strings.ToLower(strings.TrimSpace(name))
+	if regionCodePattern.MatchString(region) {
+		return region, nil
+	}
+	alias := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(region)
+	if code, ok := regionAliases[alias]; ok {
+		return code, nil
+	}
+	return "", fmt.Errorf("unknown region alias %q", name)
+}
+
+// resolveRegionAliases resolves each of names with resolveRegionAlias
+func resolveRegionAliases(names []string) ([]string, error) {
+	regions := make([]string, 0, len(names))
+	for _, name := range names {
+		region, err := resolveRegionAlias(name)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// parseTagPriority validates a comma-separated list of tag sources, highest priority first
+func parseTagPriority(value string) ([]string, error) {
+	known := map[string]bool{tagSourceCLI: true, tagSourceEnv: true, tagSourceFile: true, tagSourceMap: true}
+	seen := make(map[string]bool)
+	priority := parseList(value)
+	if len(priority) == 0 {
+		return nil, fmt.Errorf("--tag-priority must list at least one of cli, env, file, map")
+	}
+	for _, source := range priority {
+		if !known[source] {
+			return nil, fmt.Errorf("unknown tag source %q in --tag-priority; use cli, env, file or map", source)
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("tag source %q listed more than once in --tag-priority", source)
+		}
+		seen[source] = true
+	}
+	return priority, nil
+}
+
+// mergeTagSources applies sources in reverse priority so higher-priority
+// sources win. Sources missing from priority are ignored.
+func mergeTagSources(priority []string, sources map[string]map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for i := len(priority) - 1; i >= 0; i-- {
+		for k, v := range sources[priority[i]] {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// envTags reads the env tag source, returning nil when the variable is unset
+func envTags() (map[string]string, error) {
+	value := os.Getenv(tagsEnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	if err := validateTags(value); err != nil {
+		return nil, fmt.Errorf("%s: %w", tagsEnvVar, err)
+	}
+	return parseCustomTags(value), nil
+}
+
+// parseList splits a comma-separated flag value, dropping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validateOnly checks the merged tag set and then the credentials, returning
+// the process exit status for --validate-only
+func validateOnly(tags map[string]string, strict bool, checkCredentials func() (string, error)) int {
+	if len(tags) == 0 {
+		log.Println("Validation failed: no tags to apply")
+		return 1
+	}
+	if err := tagger.ValidateTags(tags, strict); err != nil {
+		log.Printf("Validation failed: invalid tags: %v", err)
+		return 1
+	}
+	accountID, err := checkCredentials()
+	if err != nil {
+		log.Printf("Validation failed: %v", err)
+		return 1
+	}
+	log.Printf("Validation succeeded: %d tags are valid and credentials resolve to account %s", len(tags), accountID)
+	return 0
+}
+
+// exitCode maps a tagging error to the process exit status
+func exitCode(err error) int {
+	if errors.Is(err, tagger.ErrNoResourcesFound) {
+		return exitNoResources
+	}
+	return 1
+}
+
 // parseFlags parses the command-line arguments and returns a CLIFlags
 func parseFlags() *CLIFlags {
 	flags := CLIFlags{}
 
 	flag.StringVar(&flags.profile, "profile", defaultProfile, "AWS profile to use")
-	flag.StringVar(&flags.region, "region", defaultRegion, "AWS region to use")
+	flag.StringVar(&flags.region, "region", defaultRegion, "AWS region to use; a comma-separated list tags each region like --regions")
+	flag.BoolVar(&flags.useFIPS, "use-fips", false, "Use FIPS endpoints for every AWS API; per-service endpoint overrides such as AWS_ENDPOINT_URL_<SERVICE> still take precedence")
+	flag.BoolVar(&flags.typeSummary, "resource-type-summary", false, "Break the summary down per resource type within each service, e.g. EC2: instance 10/10, volume 40/40")
+	flag.BoolVar(&flags.regionSummary, "output-summary-per-region", false, "In multi-region runs, break the report and summary down per region as well as in aggregate")
+	flag.BoolVar(&flags.allRegions, "all-regions", false, "Tag every region enabled for the account (global services such as S3 run once)")
+	flag.BoolVar(&flags.regionAlias, "normalize-region-alias", false, "Accept friendly region names such as virginia or ireland in --region and --regions")
+	flag.StringVar(&flags.regions, "regions", "", "Comma-separated regions to tag, e.g. us-east-1,eu-west-1 (global services such as S3 run once)")
+	flag.StringVar(&flags.tagsFile, "tags-file", "", "YAML or JSON file with an object of tag keys to values, merged with the file tag source; --tag overrides it")
+	flag.StringVar(&flags.configFile, "config", "", "YAML or JSON file with profile, region and tags; command-line flags override it")
 	flag.StringVar(&flags.mapKeyValue, "map-migrated", defaultTagValue, "MAP 2.0 value to use")
 	flag.StringVar(&flags.tags, "tag", "", "Custom tags in key:value format (can be comma-separated for multiple tags)")
+	flag.StringVar(&flags.ensureKeys, "ensure-key", "", "Tags in key:default format added only where the key is missing; existing values are never overwritten (EC2, RDS, S3)")
+	flag.StringVar(&flags.tagPriority, "tag-priority", defaultTagPriority, "Tag sources from highest to lowest priority; sources left out are ignored (env reads "+tagsEnvVar+")")
+	flag.BoolVar(&flags.strictTags, "strict-validation", false, "Reject tag keys and values containing characters AWS does not allow")
+	flag.BoolVar(&flags.skipDefaults, "skip-defaults", true, "Skip default resources such as the Athena primary workgroup and default security groups")
+	flag.StringVar(&flags.logPrefix, "loggroup-prefix", "", "Tag only CloudWatch log groups whose name starts with this prefix, e.g. /aws/lambda/")
+	flag.Int64Var(&flags.logMinBytes, "loggroup-min-bytes", 0, "Tag only CloudWatch log groups storing at least this many bytes")
+	flag.StringVar(&flags.createdAfter, "created-after", "", "Tag only EC2 instances, RDS instances and S3 buckets created after this RFC3339 time, e.g. 2024-05-10T00:00:00Z")
+	flag.BoolVar(&flags.ownedOnly, "owned-only", false, "Skip shared resources owned by another account, e.g. RAM-shared transit gateways")
+	flag.DurationVar(&flags.serviceDelay, "service-delay", time.Second, "Pause after each service finishes tagging to avoid API throttling (0 disables it)")
+	flag.DurationVar(&flags.svcTimeout, "service-timeout", 0, "Cancel the API calls of a service still tagging after this long, e.g. 15m, so the others can finish (0 disables it)")
+	flag.StringVar(&flags.output, "output", tagger.OutputText, "Report format: text, json or csv; json and csv print the report to stdout when --report-file is not set, and csv also writes --report-file as CSV")
+	flag.StringVar(&flags.reportFile, "report-file", "", "Write a JSON report of tagging results to this file")
+	flag.StringVar(&flags.outputDir, "output-dir", "", "Write report.json, failures.txt, summary.json and metrics.prom into a timestamped folder under this directory")
+	flag.StringVar(&flags.checkpoint, "checkpoint-file", "", "Append the ARN of each tagged resource to this file as the run progresses")
+	flag.BoolVar(&flags.resume, "resume", false, "Skip resources already listed in --checkpoint-file, continuing an interrupted run")
+	flag.StringVar(&flags.diffAgainst, "diff-against", "", "Compare this run with a previous --report-file and report added, removed and changed resources; combine with --dry-run to detect drift without tagging")
+	flag.StringVar(&flags.arnsFile, "arns-file", "", "Tag only the ARNs listed in this file, one per line")
+	flag.BoolVar(&flags.crossAccount, "allow-cross-account-arns", false, "Tag --arns-file entries that belong to other accounts instead of skipping them")
+	flag.StringVar(&flags.failedARNs, "failed-arns-file", "", "Write the ARNs of resources that failed to tag to this file, for use with --arns-file")
+	flag.StringVar(&flags.service, "service", "", "Tag only resources of this service via the Resource Groups Tagging API (use with --resource-type)")
+	flag.StringVar(&flags.resourceType, "resource-type", "", "Resource type to tag with --service, e.g. document-classifier")
+	flag.StringVar(&flags.onlySvcs, "only-services", "", "Comma-separated services to tag, e.g. ec2,rds,s3 (case-insensitive)")
+	flag.StringVar(&flags.onlySvcs, "services", "", "Alias of --only-services")
+	flag.StringVar(&flags.excludeSvcs, "exclude-services", "", "Comma-separated services to skip, e.g. athena,glue (case-insensitive)")
+	flag.StringVar(&flags.glueCatalogs, "glue-catalog-ids", "", "Comma-separated Glue catalog IDs to tag databases and connections in (default: the account's catalog)")
+	flag.StringVar(&flags.roleARN, "role-arn", "", "Role to assume for all calls, e.g. a cross-account role in a member account")
+	flag.StringVar(&flags.externalID, "external-id", "", "External ID to pass when assuming --role-arn or the --accounts-file roles")
+	flag.StringVar(&flags.accountsFile, "accounts-file", "", "File of IAM role ARNs, one per line, to assume and tag each account in turn; --failed-arns-file, --checkpoint-file and --output-dir get the account ID added")
+	flag.StringVar(&flags.glueRole, "assume-role-arn", "", "Role to assume for Glue calls only, e.g. an Organizations delegated-admin role for --glue-catalog-ids; not allowed with --role-arn or --accounts-file")
+	flag.StringVar(&flags.athenaSkipWG, "athena-skip-workgroups", "", "Comma-separated Athena workgroups to leave untagged, in addition to primary")
+	flag.StringVar(&flags.glueResources, "glue-resources", "", "Comma-separated Glue resources to tag: databases, connections, crawlers, jobs, triggers, workflows, usage-profiles (default: all)")
+	flag.StringVar(&flags.ecsTypes, "ecs-types", "", "Comma-separated ECS resources to tag: cluster, service, task-set, task, container-instance (default: all)")
+	flag.StringVar(&flags.eksStatus, "eks-status", "", "Only tag EKS clusters in this status, e.g. ACTIVE")
+	flag.StringVar(&flags.eksVersion, "eks-version", "", "Only tag EKS clusters running this Kubernetes version, e.g. 1.30")
+	flag.BoolVar(&flags.skipTagged, "skip-tagged", false, "Read existing tags and skip resources that already carry every tag (Glue, RDS, CodeBuild, S3 buckets)")
+	flag.BoolVar(&flags.taggingAPI, "use-tagging-api", false, "Skip resources the Resource Groups Tagging API reports as already carrying all tags (Glue)")
+	flag.BoolVar(&flags.validateOnly, "validate-only", false, "Validate flags, config, tags and credentials, then exit without tagging")
+	flag.BoolVar(&flags.verify, "verify", false, "Read tags back after writing them and report resources missing any (Glue)")
+	flag.BoolVar(&flags.dryRun, "dry-run", false, "Discover resources and log the tags that would be written without calling any tag API")
+	flag.BoolVar(&flags.cacheListing, "discovery-cache", false, "Reuse resource listings across phases of a run, e.g. both passes of --assert-idempotent")
+	flag.BoolVar(&flags.sorted, "deterministic", false, "Sort tag keys and report entries so repeated runs produce identical output")
+	flag.BoolVar(&flags.errorOnEmpty, "error-on-empty", false, "Exit with status 2 when no resources are found in any service, e.g. because of a wrong account or region")
+	flag.BoolVar(&flags.idempotent, "assert-idempotent", false, "Run tagging twice and fail if the second run issues any tag writes")
+	flag.IntVar(&flags.maxTagKeys, "max-tag-keys", 0, "Skip resources that would end up with more tag keys than this (0 uses each service's limit)")
+	flag.IntVar(&flags.concurrency, "concurrency", 0, "Maximum API calls in flight across all services (0 means no limit)")
+	flag.IntVar(&flags.osWorkers, "opensearch-workers", 0, "Number of OpenSearch domains tagged at once (0 uses the default of 4)")
+	flag.BoolVar(&flags.autoConc, "concurrency-auto", false, "Start with one API call in flight and adapt to throttling, up to --concurrency (default 16)")
+	flag.IntVar(&flags.retries, "retry-attempts", 0, "Tries per tag write when AWS throttles it, with jittered exponential backoff between tries (0 uses the default of 5)")
+	flag.IntVar(&flags.maxAPIErrors, "max-api-errors", 0, "Abort the run after this many non-throttling API errors (0 disables the limit)")
 
 	// Add aliases for flags
 	flag.StringVar(&flags.profile, "p", defaultProfile, "AWS profile to use (shorthand)")
@@ -86,14 +392,88 @@ func parseFlags() *CLIFlags {
 	flag.StringVar(&flags.tags, "t", "", "Custom tags (shorthand)")
 
 	flag.Parse()
+	flags.explicit = make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		flags.explicit[f.Name] = true
+	})
 
 	return &flags
 }
 
 func main() {
 	flags := parseFlags()
-	// Validate tags before proceeding
-	if err := validateTags(flags.tags); err != nil {
+	priority, err := parseTagPriority(flags.tagPriority)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if flags.explicit["services"] && flags.explicit["only-services"] {
+		log.Fatalf("Error: --services is an alias of --only-services; use only one of them")
+	}
+	onlyServices, excludeServices := parseList(flags.onlySvcs), parseList(flags.excludeSvcs)
+	if err := tagger.ValidateServiceNames(append(onlyServices, excludeServices...)); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	var fromFile map[string]string
+	if flags.configFile != "" {
+		cfg, err := LoadConfig(flags.configFile)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		applyConfig(flags, cfg)
+		fromFile = cfg.Tags
+	}
+	if flags.tagsFile != "" {
+		fileTags, err := LoadTagsFile(flags.tagsFile)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		// --tags-file entries override tags from --config
+		if fromFile == nil {
+			fromFile = make(map[string]string, len(fileTags))
+		}
+		for k, v := range fileTags {
+			fromFile[k] = v
+		}
+	}
+	if flags.accountsFile != "" && flags.roleARN != "" {
+		log.Fatalf("Error: --accounts-file and --role-arn cannot be used together")
+	}
+	if flags.glueRole != "" && (flags.roleARN != "" || flags.accountsFile != "") {
+		log.Fatalf("Error: --assume-role-arn cannot be combined with --role-arn or --accounts-file; those roles already apply to Glue")
+	}
+	if flags.resume && flags.checkpoint == "" {
+		log.Fatalf("Error: --resume requires --checkpoint-file")
+	}
+	if err := tagger.ValidateOutputFormat(flags.output); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	var createdAfter time.Time
+	if flags.createdAfter != "" {
+		if createdAfter, err = time.Parse(time.RFC3339, flags.createdAfter); err != nil {
+			log.Fatalf("Error: --created-after must be an RFC3339 time: %v", err)
+		}
+	}
+	ecsTypes := parseList(flags.ecsTypes)
+	if err := tagger.ValidateECSTypes(ecsTypes); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	glueResources := parseList(flags.glueResources)
+	if err := tagger.ValidateGlueResources(glueResources); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	var ensureKeys map[string]string
+	if flags.ensureKeys != "" {
+		if err := validateTags(flags.ensureKeys); err != nil {
+			log.Fatalf("Error: --ensure-key: %v", err)
+		}
+		ensureKeys = parseCustomTags(flags.ensureKeys)
+	}
+	fromEnv, err := envTags()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	// Validate tags before proceeding; --tag may be omitted when another source supplies tags
+	if err := validateTags(flags.tags); err != nil && (flags.tags != "" || len(fromEnv)+len(fromFile) == 0) {
 		_, err := fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		if err != nil {
 			return
@@ -101,31 +481,184 @@ func main() {
 		flag.Usage()
 		os.Exit(1)
 	}
+	var regions []string
+	if flags.region, regions, err = parseRegionFlags(flags.region, flags.regions); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if flags.allRegions && len(regions) > 0 {
+		log.Fatalf("Error: --all-regions cannot be combined with a region list")
+	}
+	if flags.regionAlias {
+		if flags.region, err = resolveRegionAlias(flags.region); err != nil {
+			log.Fatalf("Error: --region: %v", err)
+		}
+		if regions, err = resolveRegionAliases(regions); err != nil {
+			log.Fatalf("Error: --regions: %v", err)
+		}
+	}
 	// Log the configuration being used
 	log.Printf("Using AWS Profile: %s", flags.profile)
-	log.Printf("Using AWS Region: %s", flags.region)
-	// Parse custom tags and merge with mapTags
-	customTags := parseCustomTags(flags.tags)
-	allTags := make(map[string]string)
-	// Copy mapTags to allTags
-	for k, v := range mapTags {
-		allTags[k] = v
-	}
-	// Merge custom tags (will override mapTags if there are duplicates)
-	for k, v := range customTags {
-		allTags[k] = v
+	if flags.allRegions {
+		log.Printf("Using all enabled AWS Regions, starting from %s", flags.region)
+	} else if len(regions) > 0 {
+		log.Printf("Using AWS Regions: %s", strings.Join(regions, ","))
+	} else {
+		log.Printf("Using AWS Region: %s", flags.region)
+	}
+	// Merge the tag sources; higher-priority sources override lower ones
+	sources := map[string]map[string]string{
+		tagSourceMap:  mapTags,
+		tagSourceEnv:  fromEnv,
+		tagSourceFile: fromFile,
+	}
+	if flags.tags != "" {
+		sources[tagSourceCLI] = parseCustomTags(flags.tags)
 	}
+	allTags := mergeTagSources(priority, sources)
 	// Log the tags being applied
 	log.Printf("Tags to be applied: %v", allTags)
-	ctx := context.Background()
+	// Cancel the run on Ctrl-C or SIGTERM so partial results are still flushed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if flags.validateOnly {
+		os.Exit(validateOnly(allTags, flags.strictTags, func() (string, error) {
+			return tagger.ResolveAccountID(ctx, flags.profile, flags.region,
+				tagger.WithFIPSEndpoints(flags.useFIPS), tagger.WithAssumeRole(flags.roleARN, flags.externalID))
+		}))
+	}
 
 	start := time.Now()
-	awsResourceTagger, err := tagger.NewAWSResourceTagger(ctx, flags.profile, flags.region, allTags)
+	opts := []tagger.Option{
+		tagger.WithRegions(regions),
+		tagger.WithAllRegions(flags.allRegions),
+		tagger.WithRegionSummary(flags.regionSummary),
+		tagger.WithResourceTypeSummary(flags.typeSummary),
+		tagger.WithFIPSEndpoints(flags.useFIPS),
+		tagger.WithServiceFilter(onlyServices, excludeServices),
+		tagger.WithMaxAPIErrors(flags.maxAPIErrors),
+		tagger.WithRetryAttempts(flags.retries),
+		tagger.WithConcurrency(flags.concurrency),
+		tagger.WithAdaptiveConcurrency(flags.autoConc),
+		tagger.WithOpenSearchWorkers(flags.osWorkers),
+		tagger.WithDryRun(flags.dryRun),
+		tagger.WithVerify(flags.verify),
+		tagger.WithErrorOnEmpty(flags.errorOnEmpty),
+		tagger.WithDeterministic(flags.sorted),
+		tagger.WithDiscoveryCache(flags.cacheListing),
+		tagger.WithStrictValidation(flags.strictTags),
+		tagger.WithSkipDefaults(flags.skipDefaults),
+		tagger.WithOwnedOnly(flags.ownedOnly),
+		tagger.WithCreatedAfter(createdAfter),
+		tagger.WithLogGroupFilter(flags.logPrefix, flags.logMinBytes),
+		tagger.WithServiceDelay(flags.serviceDelay),
+		tagger.WithServiceTimeout(flags.svcTimeout),
+		tagger.WithReportFile(flags.reportFile),
+		tagger.WithOutputFormat(flags.output),
+		tagger.WithDiffAgainst(flags.diffAgainst),
+		tagger.WithFailedARNsFile(flags.failedARNs),
+		tagger.WithOutputDir(flags.outputDir),
+		tagger.WithCheckpointFile(flags.checkpoint, flags.resume),
+		tagger.WithAssumeRole(flags.roleARN, flags.externalID),
+		tagger.WithGlueCatalogIDs(parseList(flags.glueCatalogs)),
+		tagger.WithGlueAssumeRole(flags.glueRole),
+		tagger.WithEnsureKeys(ensureKeys),
+		tagger.WithAthenaSkipWorkgroups(parseList(flags.athenaSkipWG)),
+		tagger.WithECSTypes(ecsTypes),
+		tagger.WithGlueResources(glueResources),
+		tagger.WithEKSFilter(tagger.EKSFilter{Status: flags.eksStatus, Version: flags.eksVersion}),
+		tagger.WithTaggingAPIDiscovery(flags.taggingAPI),
+		tagger.WithSkipTagged(flags.skipTagged),
+		tagger.WithMaxTagKeys(flags.maxTagKeys),
+		tagger.WithAllowCrossAccountARNs(flags.crossAccount),
+	}
+	if flags.accountsFile != "" {
+		err = runAccounts(ctx, flags, allTags, opts)
+	} else {
+		var awsResourceTagger *tagger.AWSResourceTagger
+		awsResourceTagger, err = tagger.NewAWSResourceTagger(ctx, flags.profile, flags.region, allTags, opts...)
+		if err != nil {
+			log.Fatalf("Failed to create awsResourceTagger: %v", err)
+		}
+		err = runTagger(awsResourceTagger, flags)
+	}
 	if err != nil {
-		log.Fatalf("Failed to create awsResourceTagger: %v", err)
+		log.Printf("Tagging failed: %v", err)
+		os.Exit(exitCode(err))
 	}
-	awsResourceTagger.TagAllResources()
 	elapsed := time.Since(start)
 
 	fmt.Printf("[=>] Tagging took %vm %vs\n", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
 }
+
+// runTagger runs the tagging mode selected by flags
+func runTagger(awsResourceTagger *tagger.AWSResourceTagger, flags *CLIFlags) error {
+	if flags.arnsFile != "" {
+		arns, err := tagger.ReadARNsFile(flags.arnsFile)
+		if err != nil {
+			log.Fatalf("Failed to read ARNs file: %v", err)
+		}
+		return awsResourceTagger.TagARNs(arns)
+	}
+	if flags.service != "" || flags.resourceType != "" {
+		return awsResourceTagger.TagResourceType(flags.service, flags.resourceType)
+	}
+	if flags.idempotent {
+		return awsResourceTagger.TagAllResourcesIdempotent()
+	}
+	return awsResourceTagger.TagAllResources()
+}
+
+// runAccounts runs the tagger once per role in --accounts-file, carrying on
+// past failed accounts, and writes their reports together to --report-file.
+// --failed-arns-file, --checkpoint-file and --output-dir are kept per account.
+func runAccounts(ctx context.Context, flags *CLIFlags, tags map[string]string, opts []tagger.Option) error {
+	roles, err := tagger.ReadAccountsFile(flags.accountsFile)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if len(roles) == 0 {
+		return fmt.Errorf("no role ARNs in %s", flags.accountsFile)
+	}
+
+	var reports []tagger.Report
+	var failed []error
+	for i, role := range roles {
+		log.Printf("Tagging account %d of %d as %s", i+1, len(roles), role)
+		// The combined report replaces the per-account report file, and every
+		// other file the run writes gets the account ID so accounts do not
+		// overwrite each other
+		accountID := tagger.RoleAccountID(role)
+		accountOpts := append(opts[:len(opts):len(opts)],
+			tagger.WithAssumeRole(role, flags.externalID),
+			tagger.WithReportFile(""),
+			tagger.WithFailedARNsFile(tagger.AccountPath(flags.failedARNs, accountID)),
+			tagger.WithCheckpointFile(tagger.AccountPath(flags.checkpoint, accountID), flags.resume))
+		if flags.outputDir != "" {
+			accountOpts = append(accountOpts, tagger.WithOutputDir(filepath.Join(flags.outputDir, accountID)))
+		}
+		awsResourceTagger, err := tagger.NewAWSResourceTagger(ctx, flags.profile, flags.region, tags, accountOpts...)
+		if err != nil {
+			log.Printf("Skipping %s: %v", role, err)
+			failed = append(failed, fmt.Errorf("%s: %w", role, err))
+			continue
+		}
+		if err := runTagger(awsResourceTagger, flags); err != nil {
+			log.Printf("Tagging failed for %s: %v", role, err)
+			failed = append(failed, fmt.Errorf("%s: %w", role, err))
+		}
+		reports = append(reports, awsResourceTagger.Report())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if flags.reportFile != "" {
+		if err := tagger.WriteAccountsReport(flags.reportFile, flags.output, reports); err != nil {
+			log.Printf("Error writing report file %s: %v", flags.reportFile, err)
+		} else {
+			log.Printf("Wrote report for %d accounts to %s", len(reports), flags.reportFile)
+		}
+	}
+	return errors.Join(failed...)
+}
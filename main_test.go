@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxkulish/aws-tagger/tagger"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"no resources found", fmt.Errorf("%w in us-east-1", tagger.ErrNoResourcesFound), exitNoResources},
+		{"other failure", errors.New("SSO session validation failed"), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"default order", defaultTagPriority, []string{"cli", "env", "file", "map"}, false},
+		{"subset with spaces", "env, cli", []string{"env", "cli"}, false},
+		{"unknown source", "cli,vault", nil, true},
+		{"duplicate source", "cli,env,cli", nil, true},
+		{"empty list", " , ", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTagPriority(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTagPriority() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTagPriority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRegionFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		region      string
+		regions     string
+		wantRegion  string
+		wantRegions []string
+		wantErr     bool
+	}{
+		{"single region", "us-east-1", "", "us-east-1", nil, false},
+		{"regions flag", "us-east-1", "eu-west-1, ap-southeast-2", "us-east-1", []string{"eu-west-1", "ap-southeast-2"}, false},
+		{"region list", "us-east-1,us-west-2, eu-west-1", "", "us-east-1", []string{"us-east-1", "us-west-2", "eu-west-1"}, false},
+		{"region list and regions flag", "us-east-1,us-west-2", "eu-west-1", "", nil, true},
+		{"empty region list", " , ", "", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, regions, err := parseRegionFlags(tt.region, tt.regions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRegionFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if region != tt.wantRegion || !reflect.DeepEqual(regions, tt.wantRegions) {
+				t.Errorf("parseRegionFlags() = %q, %v, want %q, %v", region, regions, tt.wantRegion, tt.wantRegions)
+			}
+		})
+	}
+}
+
+func TestResolveRegionAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"virginia", "virginia", "us-east-1", false},
+		{"ireland mixed case", "Ireland", "eu-west-1", false},
+		{"alias with space", "Sao Paulo", "sa-east-1", false},
+		{"region code passes through", "ap-southeast-2", "ap-southeast-2", false},
+		{"gov region code", "us-gov-west-1", "us-gov-west-1", false},
+		{"unknown alias", "atlantis", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRegionAlias(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRegionAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRegionAlias() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRegionAliases(t *testing.T) {
+	got, err := resolveRegionAliases([]string{"virginia", "eu-west-1", "tokyo"})
+	if err != nil {
+		t.Fatalf("resolveRegionAliases() error = %v", err)
+	}
+	if want := []string{"us-east-1", "eu-west-1", "ap-northeast-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveRegionAliases() = %v, want %v", got, want)
+	}
+
+	if _, err := resolveRegionAliases([]string{"oregon", "narnia"}); err == nil || !strings.Contains(err.Error(), "narnia") {
+		t.Errorf("resolveRegionAliases() error = %v, want unknown alias narnia", err)
+	}
+}
+
+func TestMergeTagSources(t *testing.T) {
+	sources := map[string]map[string]string{
+		tagSourceCLI: {"owner": "cli", "team": "data"},
+		tagSourceEnv: {"owner": "env", "stage": "prod"},
+		tagSourceMap: {"owner": "map", "map-migrated": "mig12345"},
+	}
+	tests := []struct {
+		name     string
+		priority []string
+		want     map[string]string
+	}{
+		{
+			name:     "cli wins by default",
+			priority: []string{"cli", "env", "file", "map"},
+			want:     map[string]string{"owner": "cli", "team": "data", "stage": "prod", "map-migrated": "mig12345"},
+		},
+		{
+			name:     "env over cli",
+			priority: []string{"env", "cli", "map"},
+			want:     map[string]string{"owner": "env", "team": "data", "stage": "prod", "map-migrated": "mig12345"},
+		},
+		{
+			name:     "omitted sources are ignored",
+			priority: []string{"map", "cli"},
+			want:     map[string]string{"owner": "map", "team": "data", "map-migrated": "mig12345"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeTagSources(tt.priority, sources); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeTagSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvTags(t *testing.T) {
+	t.Setenv(tagsEnvVar, "")
+	if got, err := envTags(); err != nil || got != nil {
+		t.Fatalf("envTags() with unset variable = %v, %v", got, err)
+	}
+
+	t.Setenv(tagsEnvVar, "owner:platform,stage:prod")
+	got, err := envTags()
+	if err != nil {
+		t.Fatalf("envTags() error = %v", err)
+	}
+	if want := map[string]string{"owner": "platform", "stage": "prod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("envTags() = %v, want %v", got, want)
+	}
+
+	t.Setenv(tagsEnvVar, "owner")
+	if _, err := envTags(); err == nil {
+		t.Error("envTags() accepted a malformed value")
+	}
+}
+
+func TestValidateOnly(t *testing.T) {
+	validCredentials := func() (string, error) { return "123456789012", nil }
+	tests := []struct {
+		name        string
+		tags        map[string]string
+		strict      bool
+		credentials func() (string, error)
+		want        int
+		wantCheck   bool
+	}{
+		{"valid config", map[string]string{"map-migrated": "mig12345"}, false, validCredentials, 0, true},
+		{"invalid tag set", map[string]string{"aws:reserved": "x"}, false, validCredentials, 1, false},
+		{"strict characters", map[string]string{"map-migrated": "mig#1"}, true, validCredentials, 1, false},
+		{"no tags", map[string]string{}, false, validCredentials, 1, false},
+		{"missing credentials", map[string]string{"map-migrated": "mig12345"}, false, func() (string, error) {
+			return "", errors.New("unable to get caller identity: no credentials")
+		}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked := false
+			credentials := func() (string, error) {
+				checked = true
+				return tt.credentials()
+			}
+			if got := validateOnly(tt.tags, tt.strict, credentials); got != tt.want {
+				t.Errorf("validateOnly() = %d, want %d", got, tt.want)
+			}
+			if checked != tt.wantCheck {
+				t.Errorf("credentials checked = %v, want %v", checked, tt.wantCheck)
+			}
+		})
+	}
+}
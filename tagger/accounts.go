@@ -0,0 +1,148 @@
+package tagger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// AccountsReport aggregates the reports of a run across several accounts
+type AccountsReport struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Summary     ReportSummary `json:"summary"`
+	Accounts    []Report      `json:"accounts"`
+}
+
+// ReadAccountsFile reads one IAM role ARN per line, ignoring blank lines and
+// # comments. Lines that are not role ARNs are skipped with a warning.
+func ReadAccountsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open accounts file: %w", err)
+	}
+	defer file.Close()
+
+	var roles []string
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !isRoleARN(line) {
+			log.Printf("Warning: skipping line %d of %s, not an IAM role ARN: %s", lineNo, path, line)
+			continue
+		}
+		roles = append(roles, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read accounts file: %w", err)
+	}
+	return roles, nil
+}
+
+// isRoleARN reports whether s is the ARN of an IAM role
+func isRoleARN(s string) bool {
+	parsed, err := arn.Parse(s)
+	return err == nil && parsed.Service == "iam" && parsed.AccountID != "" &&
+		strings.HasPrefix(parsed.Resource, "role/")
+}
+
+// Report returns the report of the results recorded so far
+func (t *AWSResourceTagger) Report() Report {
+	return t.buildReport()
+}
+
+// RoleAccountID returns the account ID in an IAM role ARN
+func RoleAccountID(role string) string {
+	parsed, err := arn.Parse(role)
+	if err != nil {
+		return ""
+	}
+	return parsed.AccountID
+}
+
+// AccountPath adds accountID to the file name of path, before its extension,
+// so that runs over several accounts write one file per account
+func AccountPath(path, accountID string) string {
+	if path == "" || accountID == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + accountID + ext
+}
+
+// WriteAccountsReport writes the reports of every account, with their
+// summaries added up, to path as JSON, or as CSV with an account column when
+// format is OutputCSV
+func WriteAccountsReport(path, format string, reports []Report) error {
+	if format == OutputCSV {
+		return writeAccountsCSVFile(path, reports)
+	}
+
+	combined := AccountsReport{
+		GeneratedAt: time.Now().UTC(),
+		Accounts:    reports,
+	}
+	if combined.Accounts == nil {
+		combined.Accounts = []Report{}
+	}
+	for _, report := range reports {
+		combined.Summary.Tagged += report.Summary.Tagged
+		combined.Summary.Failed += report.Summary.Failed
+		combined.Summary.Skipped += report.Summary.Skipped
+		combined.Summary.WouldTag += report.Summary.WouldTag
+	}
+	return writeJSONFile(path, combined)
+}
+
+// readAccountReport loads the report of accountID from a combined report
+// written by WriteAccountsReport. ok is false when path holds a single-account
+// report; an account missing from the combined report gets an empty report.
+func readAccountReport(path, accountID string) (report Report, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, false, err
+	}
+	var combined AccountsReport
+	if err := json.Unmarshal(data, &combined); err != nil || combined.Accounts == nil {
+		return report, false, nil
+	}
+	for _, r := range combined.Accounts {
+		if r.AccountID == accountID {
+			return r, true, nil
+		}
+	}
+	return Report{AccountID: accountID}, true, nil
+}
+
+// writeAccountsCSVFile atomically replaces path with one CSV row per result
+// of every account, led by the account ID
+func writeAccountsCSVFile(path string, reports []Report) error {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.Write(append([]string{"account_id"}, csvReportHeader...)); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		for _, r := range report.Results {
+			if err := cw.Write([]string{report.AccountID, r.Service, r.ResourceID, r.ARN, r.Status, r.Error}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return writeFileAtomically(path, buf.Bytes())
+}
@@ -0,0 +1,102 @@
+package tagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAccountsFile(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	path := filepath.Join(t.TempDir(), "accounts.txt")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"# member accounts\n"+
+			"arn:aws:iam::111111111111:role/MAPTagger\n"+
+			"\n"+
+			"  arn:aws:iam::222222222222:role/ops/MAPTagger  \n"+
+			"arn:aws:iam::333333333333:user/alice\n"+
+			"444444444444\n"), 0o644))
+
+	roles, err := ReadAccountsFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"arn:aws:iam::111111111111:role/MAPTagger",
+		"arn:aws:iam::222222222222:role/ops/MAPTagger",
+	}, roles)
+	assert.Contains(t, logBuffer.String(), "skipping line 5")
+	assert.Contains(t, logBuffer.String(), "skipping line 6")
+
+	_, err = ReadAccountsFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestWriteAccountsReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	reports := []Report{
+		{AccountID: "111111111111", Summary: ReportSummary{Tagged: 3, Failed: 1}},
+		{AccountID: "222222222222", Summary: ReportSummary{Tagged: 2, Skipped: 4}},
+	}
+
+	require.NoError(t, WriteAccountsReport(path, OutputJSON, reports))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var combined AccountsReport
+	require.NoError(t, json.Unmarshal(data, &combined))
+	assert.Equal(t, ReportSummary{Tagged: 5, Failed: 1, Skipped: 4}, combined.Summary)
+	require.Len(t, combined.Accounts, 2)
+	assert.Equal(t, "111111111111", combined.Accounts[0].AccountID)
+	assert.Equal(t, "222222222222", combined.Accounts[1].AccountID)
+}
+
+func TestWriteAccountsReportAsCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+	reports := []Report{
+		{AccountID: "111111111111", Results: []TagResult{{Service: "EC2", ResourceID: "i-1", Status: StatusTagged}}},
+		{AccountID: "222222222222", Results: []TagResult{{Service: "S3", ResourceID: "logs", Status: StatusFailed, Error: "denied"}}},
+	}
+
+	require.NoError(t, WriteAccountsReport(path, OutputCSV, reports))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "account_id,service,resource_id,arn,status,error\n"+
+		"111111111111,EC2,i-1,,tagged,\n"+
+		"222222222222,S3,logs,,failed,denied\n", string(data))
+}
+
+func TestAccountPath(t *testing.T) {
+	assert.Equal(t, "out/failed-111111111111.txt", AccountPath("out/failed.txt", "111111111111"))
+	assert.Equal(t, "checkpoint-111111111111", AccountPath("checkpoint", "111111111111"))
+	assert.Equal(t, "", AccountPath("", "111111111111"))
+	assert.Equal(t, "111111111111", RoleAccountID("arn:aws:iam::111111111111:role/MAPTagger"))
+}
+
+func TestDiffAgainstCombinedAccountsReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "previous.json")
+	require.NoError(t, WriteAccountsReport(path, OutputJSON, []Report{
+		{AccountID: "111111111111", Results: []TagResult{{Service: "EC2", ResourceID: "i-1", Status: StatusTagged}}},
+		{AccountID: "222222222222", Results: []TagResult{{Service: "EC2", ResourceID: "i-9", Status: StatusTagged}}},
+	}))
+
+	tagger := &AWSResourceTagger{diffAgainst: path}
+	diff := tagger.diffReport(Report{
+		AccountID: "222222222222",
+		Results:   []TagResult{{Service: "EC2", ResourceID: "i-9", Status: StatusTagged}},
+	})
+
+	require.NotNil(t, diff)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
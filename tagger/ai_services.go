@@ -0,0 +1,97 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+)
+
+// ComprehendAPI interface for Comprehend client operations
+type ComprehendAPI interface {
+	ListEndpoints(ctx context.Context, params *comprehend.ListEndpointsInput, optFns ...func(*comprehend.Options)) (*comprehend.ListEndpointsOutput, error)
+	TagResource(ctx context.Context, params *comprehend.TagResourceInput, optFns ...func(*comprehend.Options)) (*comprehend.TagResourceOutput, error)
+}
+
+// AIServiceClients holds a client per AI/ML service; a nil client skips that service
+type AIServiceClients struct {
+	Comprehend ComprehendAPI
+}
+
+func init() {
+	registerService("AIServices", (*AWSResourceTagger).tagAIServicesResources)
+}
+
+// tagAIServicesResources is the main entry point that creates and uses the clients
+func (t *AWSResourceTagger) tagAIServicesResources() error {
+	t.tagAIServicesResourcesWithClients(AIServiceClients{
+		Comprehend: comprehend.NewFromConfig(t.cfg),
+	})
+	return nil
+}
+
+// tagAIServicesResourcesWithClients tags the resources of every AI/ML service
+// with a client. New services get a client field and an entry here.
+func (t *AWSResourceTagger) tagAIServicesResourcesWithClients(clients AIServiceClients) {
+	fmt.Println("=====================================")
+	log.Println("Tagging AI service resources...")
+	defer log.Println("Completed tagging AI service resources")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping AI service tagging")
+		return
+	}
+
+	if clients.Comprehend != nil {
+		t.tagComprehendEndpoints(clients.Comprehend)
+	}
+}
+
+// tagComprehendEndpoints tags every Comprehend custom model endpoint
+func (t *AWSResourceTagger) tagComprehendEndpoints(client ComprehendAPI) {
+	input := &comprehend.ListEndpointsInput{}
+	for {
+		output, err := client.ListEndpoints(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Comprehend Endpoints")
+			return
+		}
+
+		for _, endpoint := range output.EndpointPropertiesList {
+			arn := aws.ToString(endpoint.EndpointArn)
+			t.applyAndRecord(TagResult{
+				Service:      "Comprehend",
+				ResourceType: "endpoint",
+				ResourceID:   arn[strings.LastIndex(arn, "/")+1:],
+				ARN:          arn,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &comprehend.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.comprehendTags(),
+				})
+				return err
+			})
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// comprehendTags converts the configured tags to Comprehend tags
+func (t *AWSResourceTagger) comprehendTags() []comprehendtypes.Tag {
+	tags := make([]comprehendtypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		tags = append(tags, comprehendtypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,89 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockComprehendClient is a mock implementation of ComprehendAPI
+type MockComprehendClient struct {
+	mock.Mock
+}
+
+func (m *MockComprehendClient) ListEndpoints(ctx context.Context, params *comprehend.ListEndpointsInput, optFns ...func(*comprehend.Options)) (*comprehend.ListEndpointsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*comprehend.ListEndpointsOutput), args.Error(1)
+}
+
+func (m *MockComprehendClient) TagResource(ctx context.Context, params *comprehend.TagResourceInput, optFns ...func(*comprehend.Options)) (*comprehend.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*comprehend.TagResourceOutput), args.Error(1)
+}
+
+func comprehendEndpointARN(name string) string {
+	return "arn:aws:comprehend:us-west-2:123456789012:document-classifier-endpoint/" + name
+}
+
+// comprehendEndpoint matches a TagResource call for the named endpoint
+func comprehendEndpoint(name string) interface{} {
+	return mock.MatchedBy(func(input *comprehend.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == comprehendEndpointARN(name)
+	})
+}
+
+func TestTagComprehendEndpoints(t *testing.T) {
+	mockClient := new(MockComprehendClient)
+	mockClient.On("ListEndpoints", mock.Anything, &comprehend.ListEndpointsInput{}).
+		Return(&comprehend.ListEndpointsOutput{
+			EndpointPropertiesList: []comprehendtypes.EndpointProperties{
+				{EndpointArn: aws.String(comprehendEndpointARN("support-tickets"))},
+			},
+			NextToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("ListEndpoints", mock.Anything, &comprehend.ListEndpointsInput{NextToken: aws.String("page-2")}).
+		Return(&comprehend.ListEndpointsOutput{
+			EndpointPropertiesList: []comprehendtypes.EndpointProperties{
+				{EndpointArn: aws.String(comprehendEndpointARN("locked"))},
+			},
+		}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, comprehendEndpoint("support-tickets")).
+		Return(&comprehend.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, comprehendEndpoint("locked")).
+		Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	tagger.tagAIServicesResourcesWithClients(AIServiceClients{Comprehend: mockClient})
+
+	mockClient.AssertExpectations(t)
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, "support-tickets", results[0].ResourceID)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.Equal(t, int64(1), tagger.metrics.APIErrors())
+}
+
+func TestTagAIServicesSkipsWithoutTags(t *testing.T) {
+	mockClient := new(MockComprehendClient)
+
+	tagger := createTestTagger()
+	tagger.tags = map[string]string{}
+	tagger.tagAIServicesResourcesWithClients(AIServiceClients{Comprehend: mockClient})
+
+	mockClient.AssertNotCalled(t, "ListEndpoints", mock.Anything, mock.Anything)
+}
@@ -54,6 +54,36 @@ var (
 		Type:       "workflow",
 		ArnPattern: "arn:aws:glue:%s:%s:workflow/%s",
 	}
+	GlueUsageProfile = ResourceType{
+		Service:    "glue",
+		Type:       "usageProfile",
+		ArnPattern: "arn:aws:glue:%s:%s:usageProfile/%s",
+	}
+	DynamoDBTable = ResourceType{
+		Service:    "dynamodb",
+		Type:       "table",
+		ArnPattern: "arn:aws:dynamodb:%s:%s:table/%s",
+	}
+	CodePipelinePipeline = ResourceType{
+		Service:    "codepipeline",
+		Type:       "pipeline",
+		ArnPattern: "arn:aws:codepipeline:%s:%s:%s",
+	}
+	SQSQueue = ResourceType{
+		Service:    "sqs",
+		Type:       "queue",
+		ArnPattern: "arn:aws:sqs:%s:%s:%s",
+	}
+	KinesisStream = ResourceType{
+		Service:    "kinesis",
+		Type:       "stream",
+		ArnPattern: "arn:aws:kinesis:%s:%s:stream/%s",
+	}
+	S3DirectoryBucket = ResourceType{
+		Service:    "s3express",
+		Type:       "bucket",
+		ArnPattern: "arn:aws:s3express:%s:%s:bucket/%s",
+	}
 )
 
 // cleanResourceName removes leading/trailing slashes and collapses multiple slashes into one
@@ -77,6 +107,20 @@ func (t *AWSResourceTagger) buildARN(resourceType ResourceType, resourceName str
 	)
 }
 
+// buildCatalogARN constructs the ARN of a Glue catalog resource. Resources in
+// another account's catalog use that catalog ID in place of the account ID.
+func (t *AWSResourceTagger) buildCatalogARN(resourceType ResourceType, catalogID, resourceName string) string {
+	if catalogID == "" {
+		return t.buildCompoundARN(resourceType, resourceName)
+	}
+	return fmt.Sprintf(
+		resourceType.ArnPattern,
+		t.region,
+		catalogID,
+		cleanResourceName(resourceName),
+	)
+}
+
 // buildCompoundARN constructs an ARN for resources that need multiple identifiers
 func (t *AWSResourceTagger) buildCompoundARN(resourceType ResourceType, parts ...string) string {
 	// Clean each part individually
@@ -47,6 +47,12 @@ func TestBuildARN(t *testing.T) {
 			resourceName: "/mydb",
 			expected:     "arn:aws:glue:us-west-2:123456789012:database/mydb",
 		},
+		{
+			name:         "DynamoDB Table",
+			resourceType: DynamoDBTable,
+			resourceName: "orders",
+			expected:     "arn:aws:dynamodb:us-west-2:123456789012:table/orders",
+		},
 		{
 			name:         "Glue Job",
 			resourceType: GlueJob,
@@ -59,6 +65,12 @@ func TestBuildARN(t *testing.T) {
 			resourceName: "my-crawler",
 			expected:     "arn:aws:glue:us-west-2:123456789012:crawler/my-crawler",
 		},
+		{
+			name:         "Glue Usage Profile",
+			resourceType: GlueUsageProfile,
+			resourceName: "analysts",
+			expected:     "arn:aws:glue:us-west-2:123456789012:usageProfile/analysts",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,172 @@
+package tagger
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+)
+
+// WithFailedARNsFile writes the ARN of every failed resource to path when the run ends
+func WithFailedARNsFile(path string) Option {
+	return func(t *AWSResourceTagger) {
+		t.failedARNsFile = path
+	}
+}
+
+// WithAllowCrossAccountARNs tags ARNs belonging to accounts other than the caller's
+func WithAllowCrossAccountARNs(allow bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.allowCrossAccountARNs = allow
+	}
+}
+
+// TagARNs tags an explicit list of ARNs, e.g. read from --arns-file, through
+// the Resource Groups Tagging API
+func (t *AWSResourceTagger) TagARNs(arns []string) error {
+	if err := t.validateSSOSession(); err != nil {
+		return fmt.Errorf("SSO session validation failed: %w", err)
+	}
+	defer t.flush()
+
+	arns = t.preflightARNs(arns)
+	log.Printf("Tagging %d ARNs via the Resource Groups Tagging API...", len(arns))
+	client := resourcegroupstaggingapi.NewFromConfig(t.cfg)
+	t.tagARNsWithClient(client, arns)
+	log.Println("Completed tagging ARNs")
+	return nil
+}
+
+// ReadARNsFile reads one ARN per line, ignoring blank lines and # comments
+func ReadARNsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ARNs file: %w", err)
+	}
+	defer file.Close()
+
+	var arns []string
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !arn.IsARN(line) {
+			return nil, fmt.Errorf("invalid ARN on line %d of %s: %s", lineNo, path, line)
+		}
+		arns = append(arns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ARNs file: %w", err)
+	}
+	return arns, nil
+}
+
+// preflightARNs checks each ARN against the resolved partition, region and
+// account, warning on mismatches. ARNs of other accounts are skipped unless
+// cross-account ARNs are allowed.
+func (t *AWSResourceTagger) preflightARNs(arns []string) []string {
+	partition := partitionForRegion(t.region)
+	valid := make([]string, 0, len(arns))
+	for _, resourceARN := range arns {
+		parsed, err := arn.Parse(resourceARN)
+		if err != nil {
+			t.skipARN(resourceARN, "not a valid ARN")
+			continue
+		}
+		if parsed.Partition != partition {
+			log.Printf("Warning: %s is in partition %s, but region %s is in %s", resourceARN, parsed.Partition, t.region, partition)
+		}
+		if parsed.Region != "" && parsed.Region != t.region {
+			log.Printf("Warning: %s is in region %s, not %s", resourceARN, parsed.Region, t.region)
+		}
+		if parsed.AccountID != "" && parsed.AccountID != t.accountID {
+			if !t.allowCrossAccountARNs {
+				t.skipARN(resourceARN, fmt.Sprintf("account %s does not match %s (use --allow-cross-account-arns to tag it)", parsed.AccountID, t.accountID))
+				continue
+			}
+			log.Printf("Warning: %s belongs to account %s, not %s", resourceARN, parsed.AccountID, t.accountID)
+		}
+		valid = append(valid, resourceARN)
+	}
+	return valid
+}
+
+// skipARN records a skipped result for an ARN rejected before tagging
+func (t *AWSResourceTagger) skipARN(resourceARN, reason string) {
+	service, resourceType := arnServiceAndType(resourceARN)
+	log.Printf("Skipping %s: %s", resourceARN, reason)
+	t.recordResult(TagResult{
+		Service:      service,
+		ResourceType: resourceType,
+		ResourceID:   resourceARN,
+		ARN:          resourceARN,
+		Status:       StatusSkipped,
+		Error:        reason,
+	})
+}
+
+// partitionForRegion returns the ARN partition a region belongs to
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// failedARNs returns the ARNs of failed results, without duplicates, in the order they failed
+func (t *AWSResourceTagger) failedARNs() []string {
+	var arns []string
+	seen := make(map[string]bool)
+	for _, r := range t.Results() {
+		if r.Status != StatusFailed || r.ARN == "" || seen[r.ARN] {
+			continue
+		}
+		seen[r.ARN] = true
+		arns = append(arns, r.ARN)
+	}
+	if t.deterministic {
+		sort.Strings(arns)
+	}
+	return arns
+}
+
+// writeFailedARNsFile writes the failed ARNs to path in the format ReadARNsFile accepts
+func (t *AWSResourceTagger) writeFailedARNsFile(path string) error {
+	arns := t.failedARNs()
+	content := strings.Join(arns, "\n")
+	if len(arns) > 0 {
+		content += "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write failed ARNs file: %w", err)
+	}
+	log.Printf("Wrote %d failed ARNs to %s", len(arns), path)
+	return nil
+}
+
+// arnServiceAndType extracts the service and resource type from an ARN such as
+// arn:aws:rds:us-east-1:123456789012:db:mydb or arn:aws:glue:...:database/sales
+func arnServiceAndType(resourceARN string) (string, string) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return "", ""
+	}
+	resourceType := parsed.Resource
+	if i := strings.IndexAny(resourceType, "/:"); i >= 0 {
+		resourceType = resourceType[:i]
+	} else {
+		resourceType = ""
+	}
+	return parsed.Service, resourceType
+}
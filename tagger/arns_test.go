@@ -0,0 +1,155 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailedARNsFileIsReingestible(t *testing.T) {
+	failedFile := filepath.Join(t.TempDir(), "failed.txt")
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		results: NewResultCollector(),
+	}
+	WithFailedARNsFile(failedFile)(tagger)
+
+	tagger.recordResult(TagResult{Service: "RDS", ARN: "arn:aws:rds:us-west-2:123456789012:db:ok", Status: StatusTagged})
+	tagger.recordResult(TagResult{Service: "RDS", ARN: "arn:aws:rds:us-west-2:123456789012:db:broken", Status: StatusFailed})
+	tagger.recordResult(TagResult{Service: "S3", ARN: "arn:aws:s3:::logs", Status: StatusFailed})
+	tagger.recordResult(TagResult{Service: "RDS", ARN: "arn:aws:rds:us-west-2:123456789012:db:broken", Status: StatusFailed})
+	tagger.recordResult(TagResult{Service: "Glue", ResourceID: "no-arn", Status: StatusFailed})
+	tagger.recordResult(TagResult{Service: "Athena", ARN: "arn:aws:athena:us-west-2:123456789012:workgroup/primary", Status: StatusSkipped})
+
+	tagger.flush()
+
+	arns, err := ReadARNsFile(failedFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"arn:aws:rds:us-west-2:123456789012:db:broken",
+		"arn:aws:s3:::logs",
+	}, arns)
+
+	// The retry run tags exactly the ARNs that failed
+	mockClient := new(MockResourceGroupsTaggingClient)
+	mockClient.On("TagResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.TagResourcesInput) bool {
+		return assert.ObjectsAreEqual(arns, input.ResourceARNList)
+	})).Return(&resourcegroupstaggingapi.TagResourcesOutput{}, nil).Once()
+
+	retry := &AWSResourceTagger{ctx: context.Background(), results: NewResultCollector()}
+	retry.tagARNsWithClient(mockClient, arns)
+
+	mockClient.AssertExpectations(t)
+	for _, r := range retry.Results() {
+		assert.Equal(t, StatusTagged, r.Status)
+	}
+}
+
+func TestReadARNsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.txt")
+	require.NoError(t, os.WriteFile(valid, []byte("# retry list\n\narn:aws:s3:::logs\n  arn:aws:sns:us-west-2:123456789012:alerts  \n"), 0o644))
+	arns, err := ReadARNsFile(valid)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arn:aws:s3:::logs", "arn:aws:sns:us-west-2:123456789012:alerts"}, arns)
+
+	invalid := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalid, []byte("arn:aws:s3:::logs\nnot-an-arn\n"), 0o644))
+	_, err = ReadARNsFile(invalid)
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = ReadARNsFile(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestARNServiceAndType(t *testing.T) {
+	tests := []struct {
+		arn          string
+		service      string
+		resourceType string
+	}{
+		{"arn:aws:rds:us-east-1:123456789012:db:mydb", "rds", "db"},
+		{"arn:aws:glue:us-east-1:123456789012:database/sales", "glue", "database"},
+		{"arn:aws:comprehend:us-west-2:123456789012:document-classifier/c1", "comprehend", "document-classifier"},
+		{"arn:aws:s3:::logs", "s3", ""},
+		{"not-an-arn", "", ""},
+	}
+	for _, tt := range tests {
+		service, resourceType := arnServiceAndType(tt.arn)
+		assert.Equal(t, tt.service, service, tt.arn)
+		assert.Equal(t, tt.resourceType, resourceType, tt.arn)
+	}
+}
+
+func TestPreflightARNs(t *testing.T) {
+	arns := []string{
+		"arn:aws:sns:us-west-2:123456789012:alerts",
+		"arn:aws:sns:us-west-2:210987654321:other-account",
+		"arn:aws:s3:::logs",
+		"arn:aws:sns:eu-west-1:123456789012:other-region",
+	}
+
+	tests := []struct {
+		name         string
+		allowCross   bool
+		expectTagged []string
+		expectSkip   []string
+	}{
+		{
+			name:         "Cross-account ARNs are skipped by default",
+			expectTagged: []string{arns[0], arns[2], arns[3]},
+			expectSkip:   []string{arns[1]},
+		},
+		{
+			name:         "Cross-account ARNs are kept when allowed",
+			allowCross:   true,
+			expectTagged: arns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			tagger := &AWSResourceTagger{
+				accountID: "123456789012",
+				region:    "us-west-2",
+				results:   NewResultCollector(),
+			}
+			WithAllowCrossAccountARNs(tt.allowCross)(tagger)
+
+			assert.Equal(t, tt.expectTagged, tagger.preflightARNs(arns))
+
+			var skipped []string
+			for _, r := range tagger.Results() {
+				assert.Equal(t, StatusSkipped, r.Status)
+				assert.Contains(t, r.Error, "account 210987654321 does not match 123456789012")
+				skipped = append(skipped, r.ARN)
+			}
+			assert.Equal(t, tt.expectSkip, skipped)
+
+			// Region mismatches only warn
+			assert.Contains(t, logBuffer.String(), "is in region eu-west-1, not us-west-2")
+			if tt.allowCross {
+				assert.Contains(t, logBuffer.String(), "belongs to account 210987654321")
+			}
+		})
+	}
+}
+
+func TestPartitionForRegion(t *testing.T) {
+	assert.Equal(t, "aws", partitionForRegion("us-west-2"))
+	assert.Equal(t, "aws-cn", partitionForRegion("cn-north-1"))
+	assert.Equal(t, "aws-us-gov", partitionForRegion("us-gov-west-1"))
+}
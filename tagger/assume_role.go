@@ -0,0 +1,40 @@
+package tagger
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// WithAssumeRole makes every call with credentials of roleARN, such as a
+// cross-account role in a member account. externalID is sent when not empty.
+func WithAssumeRole(roleARN, externalID string) Option {
+	return func(t *AWSResourceTagger) {
+		t.roleARN = roleARN
+		t.externalID = externalID
+	}
+}
+
+// assumeRole switches the base config to the credentials of the role set
+// with WithAssumeRole, so the account ID and all clients follow that role
+func (t *AWSResourceTagger) assumeRole() {
+	if t.roleARN == "" {
+		return
+	}
+	t.cfg = assumeRoleConfig(t.cfg, sts.NewFromConfig(t.cfg), t.roleARN, t.externalID)
+}
+
+// assumeRoleConfig copies cfg with cached credentials of roleARN obtained through client
+func assumeRoleConfig(cfg aws.Config, client stscreds.AssumeRoleAPIClient, roleARN, externalID string) aws.Config {
+	log.Printf("Assuming role %s", roleARN)
+	assumed := cfg.Copy()
+	assumed.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(client, roleARN,
+		func(o *stscreds.AssumeRoleOptions) {
+			if externalID != "" {
+				o.ExternalID = aws.String(externalID)
+			}
+		}))
+	return assumed
+}
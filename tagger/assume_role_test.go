@@ -0,0 +1,137 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSTSHTTPClient hands out credentials for AssumeRole and answers
+// GetCallerIdentity with the account of whichever credentials signed it
+type fakeSTSHTTPClient struct {
+	assumeRoleForm url.Values
+}
+
+func (c *fakeSTSHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var response string
+	switch action := form.Get("Action"); action {
+	case "AssumeRole":
+		c.assumeRoleForm = form
+		response = `<AssumeRoleResponse><AssumeRoleResult><Credentials>` +
+			`<AccessKeyId>ASSUMEDKEY</AccessKeyId><SecretAccessKey>assumed-secret</SecretAccessKey>` +
+			`<SessionToken>assumed-token</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>` +
+			`</Credentials></AssumeRoleResult></AssumeRoleResponse>`
+	case "GetCallerIdentity":
+		account := "123456789012"
+		if strings.Contains(req.Header.Get("Authorization"), "Credential=ASSUMEDKEY/") {
+			account = "210987654321"
+		}
+		response = `<GetCallerIdentityResponse><GetCallerIdentityResult>` +
+			`<Account>` + account + `</Account>` +
+			`</GetCallerIdentityResult></GetCallerIdentityResponse>`
+	default:
+		return nil, fmt.Errorf("unexpected STS action %q", action)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func TestAssumeRoleResolvesAssumedAccount(t *testing.T) {
+	const roleARN = "arn:aws:iam::210987654321:role/MAPTagger"
+
+	httpClient := &fakeSTSHTTPClient{}
+	tagger := &AWSResourceTagger{
+		ctx: context.Background(),
+		cfg: aws.Config{
+			Region:           "us-west-2",
+			Credentials:      credentials.NewStaticCredentialsProvider("BASEKEY", "base-secret", ""),
+			HTTPClient:       httpClient,
+			RetryMaxAttempts: 1,
+		},
+	}
+	WithAssumeRole(roleARN, "ext-123")(tagger)
+
+	tagger.assumeRole()
+	accountID, err := getAccountID(tagger.ctx, tagger.cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "210987654321", accountID)
+	assert.Equal(t, roleARN, httpClient.assumeRoleForm.Get("RoleArn"))
+	assert.Equal(t, "ext-123", httpClient.assumeRoleForm.Get("ExternalId"))
+}
+
+func TestAssumeRoleWithoutRoleKeepsBaseAccount(t *testing.T) {
+	httpClient := &fakeSTSHTTPClient{}
+	tagger := &AWSResourceTagger{
+		ctx: context.Background(),
+		cfg: aws.Config{
+			Region:           "us-west-2",
+			Credentials:      credentials.NewStaticCredentialsProvider("BASEKEY", "base-secret", ""),
+			HTTPClient:       httpClient,
+			RetryMaxAttempts: 1,
+		},
+	}
+
+	tagger.assumeRole()
+	accountID, err := getAccountID(tagger.ctx, tagger.cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", accountID)
+	assert.Nil(t, httpClient.assumeRoleForm, "no role should be assumed")
+}
+
+func TestResolveAccountIDAssumesRole(t *testing.T) {
+	const roleARN = "arn:aws:iam::210987654321:role/MAPTagger"
+
+	fake := &fakeSTSHTTPClient{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := fake.Do(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		io.Copy(w, resp.Body)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte("[default]\n"), 0o600))
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "credentials"),
+		[]byte("[default]\naws_access_key_id = BASEKEY\naws_secret_access_key = base-secret\n"), 0o600))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ENDPOINT_URL_STS", server.URL)
+
+	accountID, err := ResolveAccountID(context.Background(), "default", "us-west-2", WithAssumeRole(roleARN, "ext-123"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "210987654321", accountID)
+	assert.Equal(t, roleARN, fake.assumeRoleForm.Get("RoleArn"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -18,13 +19,22 @@ type AthenaAPI interface {
 	TagResource(ctx context.Context, params *athena.TagResourceInput, optFns ...func(*athena.Options)) (*athena.TagResourceOutput, error)
 }
 
+// allowedTagCharacters matches the character set AWS accepts in tag keys and values
+var allowedTagCharacters = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
 // validateTags checks if tags meet Athena's requirements
 func (t *AWSResourceTagger) validateTags() error {
-	if len(t.tags) > 50 {
+	return ValidateTags(t.tags, t.strictValidation)
+}
+
+// ValidateTags checks tags against the AWS limits shared by all services;
+// strict also restricts them to the character set AWS accepts
+func ValidateTags(tags map[string]string, strict bool) error {
+	if len(tags) > 50 {
 		return fmt.Errorf("number of tags exceeds maximum limit of 50")
 	}
 
-	for key, value := range t.tags {
+	for key, value := range tags {
 		if strings.HasPrefix(key, "aws:") {
 			return fmt.Errorf("tag key cannot start with 'aws:': %s", key)
 		}
@@ -34,12 +44,27 @@ func (t *AWSResourceTagger) validateTags() error {
 		if len(value) > 256 {
 			return fmt.Errorf("tag value length must not exceed 256 characters for key: %s", key)
 		}
+		if strict {
+			if !allowedTagCharacters.MatchString(key) {
+				return fmt.Errorf("tag key contains characters not allowed by AWS: %q", key)
+			}
+			if !allowedTagCharacters.MatchString(value) {
+				return fmt.Errorf("tag value contains characters not allowed by AWS for key %s: %q", key, value)
+			}
+		}
 	}
 	return nil
 }
 
 // tagAthenaWorkgroups tags Athena workgroups
 func (t *AWSResourceTagger) tagAthenaWorkgroups(client AthenaAPI) error {
+	skipped := 0
+	defer func() {
+		if skipped > 0 {
+			log.Printf("Skipped %d Athena workgroups", skipped)
+		}
+	}()
+
 	input := &athena.ListWorkGroupsInput{}
 	for {
 		workgroups, err := client.ListWorkGroups(t.ctx, input)
@@ -49,11 +74,16 @@ func (t *AWSResourceTagger) tagAthenaWorkgroups(client AthenaAPI) error {
 
 		for _, workgroup := range workgroups.WorkGroups {
 			wgName := aws.ToString(workgroup.Name)
-			if wgName == "primary" { // Skip the primary workgroup
+			arn := t.buildCompoundARN(AthenaWorkgroup, wgName)
+			target := TagResult{Service: AthenaWorkgroup.Service, ResourceType: AthenaWorkgroup.Type, ResourceID: wgName, ARN: arn}
+			if t.skipDefaultResource(target, AthenaWorkgroup.Service, AthenaWorkgroup.Type, wgName) {
+				skipped++
+				continue
+			}
+			if t.skipAthenaWorkgroup(wgName, arn) {
+				skipped++
 				continue
 			}
-
-			arn := t.buildCompoundARN(AthenaWorkgroup, wgName)
 			if err := t.tagResource(client, arn, wgName, "workgroup"); err != nil {
 				// Log the error with more details
 				log.Printf("Warning: failed to tag workgroup %s (ARN: %s): %v", wgName, arn, err)
@@ -69,6 +99,26 @@ func (t *AWSResourceTagger) tagAthenaWorkgroups(client AthenaAPI) error {
 	return nil
 }
 
+// skipAthenaWorkgroup records a skip for workgroups listed in --athena-skip-workgroups
+func (t *AWSResourceTagger) skipAthenaWorkgroup(wgName, arn string) bool {
+	for _, name := range t.athenaSkipWorkgroups {
+		if name != wgName {
+			continue
+		}
+		log.Printf("Skipping Athena workgroup %s: listed in --athena-skip-workgroups", wgName)
+		t.recordResult(TagResult{
+			Service:      AthenaWorkgroup.Service,
+			ResourceType: AthenaWorkgroup.Type,
+			ResourceID:   wgName,
+			ARN:          arn,
+			Status:       StatusSkipped,
+			Error:        "listed in --athena-skip-workgroups",
+		})
+		return true
+	}
+	return false
+}
+
 // tagAthenaDataCatalogs tags Athena data catalogs
 func (t *AWSResourceTagger) tagAthenaDataCatalogs(client AthenaAPI) error {
 	log.Println("Starting to list and tag data catalogs...")
@@ -99,23 +149,32 @@ func (t *AWSResourceTagger) tagAthenaDataCatalogs(client AthenaAPI) error {
 	return nil
 }
 
-// tagResource handles the actual tagging operation with error handling
+// tagResource tags a single workgroup or data catalog and records the outcome
 func (t *AWSResourceTagger) tagResource(client AthenaAPI, arn, resourceName, resourceType string) error {
-	_, err := client.TagResource(t.ctx, &athena.TagResourceInput{
-		ResourceARN: aws.String(arn),
-		Tags:        t.convertToAthenaTags(),
+	target := TagResult{
+		Service:      "Athena",
+		ResourceType: resourceType,
+		ResourceID:   resourceName,
+		ARN:          arn,
+	}
+	err := t.applyAndRecord(target, func() error {
+		_, err := client.TagResource(t.ctx, &athena.TagResourceInput{
+			ResourceARN: aws.String(arn),
+			Tags:        t.convertToAthenaTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("failed to tag resource: %w", err)
 	}
-	log.Printf("Successfully tagged Athena %s: %s", resourceType, resourceName)
 	return nil
 }
 
 // convertToAthenaTags converts the common tags map to Athena-specific tags
 func (t *AWSResourceTagger) convertToAthenaTags() []athenatypes.Tag {
 	athenaTags := make([]athenatypes.Tag, 0, len(t.tags))
-	for k, v := range t.tags {
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
 		athenaTags = append(athenaTags, athenatypes.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
@@ -124,10 +183,15 @@ func (t *AWSResourceTagger) convertToAthenaTags() []athenatypes.Tag {
 	return athenaTags
 }
 
+func init() {
+	registerService("Athena", (*AWSResourceTagger).tagAthenaResources)
+}
+
 // tagAthenaResources is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagAthenaResources() {
+func (t *AWSResourceTagger) tagAthenaResources() error {
 	client := athena.NewFromConfig(t.cfg)
 	t.tagAthenaResourcesWithClient(client)
+	return nil
 }
 
 // tagAthenaResourcesWithClient handles the actual tagging logic with a provided client
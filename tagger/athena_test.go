@@ -105,6 +105,77 @@ func TestValidateTags(t *testing.T) {
 	}
 }
 
+func TestValidateTagsStrict(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        map[string]string
+		strict      bool
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "Allowed characters in keys and values",
+			tags: map[string]string{
+				"map-migrated":     "mig12345",
+				"cost_center:team": "data/eng = +@.",
+				"Überprüfung 2024": "Köln",
+			},
+			strict:      true,
+			expectError: false,
+		},
+		{
+			name:        "Emoji in key",
+			tags:        map[string]string{"team🚀": "value"},
+			strict:      true,
+			expectError: true,
+			errorMsg:    "tag key contains characters not allowed by AWS",
+		},
+		{
+			name:        "Control character in key",
+			tags:        map[string]string{"team\tname": "value"},
+			strict:      true,
+			expectError: true,
+			errorMsg:    "tag key contains characters not allowed by AWS",
+		},
+		{
+			name:        "Emoji in value",
+			tags:        map[string]string{"team": "rocket🚀"},
+			strict:      true,
+			expectError: true,
+			errorMsg:    "tag value contains characters not allowed by AWS for key team",
+		},
+		{
+			name:        "Disallowed punctuation in value",
+			tags:        map[string]string{"team": "a,b;c"},
+			strict:      true,
+			expectError: true,
+			errorMsg:    "tag value contains characters not allowed by AWS",
+		},
+		{
+			name:        "Disallowed characters accepted without strict mode",
+			tags:        map[string]string{"team🚀": "a,b;c"},
+			strict:      false,
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &AWSResourceTagger{
+				tags:             tt.tags,
+				strictValidation: tt.strict,
+			}
+			err := tagger.validateTags()
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestTagAthenaWorkgroups(t *testing.T) {
 	ctx := context.Background()
 	tagger := &AWSResourceTagger{
@@ -695,3 +766,48 @@ func TestTagAthenaResourcesWithSkipPrimary(t *testing.T) {
 	assert.Contains(t, logOutput, "Completed tagging Athena resources")
 	assert.NotContains(t, logOutput, "Successfully tagged Athena workgroup: primary")
 }
+
+func TestTagAthenaWorkgroupsWithSkipList(t *testing.T) {
+	tagger := &AWSResourceTagger{
+		ctx:       context.Background(),
+		accountID: "123456789012",
+		region:    "us-west-2",
+		tags:      map[string]string{"Environment": "Test"},
+		results:   NewResultCollector(),
+	}
+	WithAthenaSkipWorkgroups([]string{"finance", "audit"})(tagger)
+
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	mockClient := new(MockAthenaClient)
+	mockClient.On("ListWorkGroups", mock.Anything, &athena.ListWorkGroupsInput{}).
+		Return(&athena.ListWorkGroupsOutput{
+			WorkGroups: []athenatypes.WorkGroupSummary{
+				{Name: aws.String("primary")},
+				{Name: aws.String("finance")},
+				{Name: aws.String("analytics")},
+				{Name: aws.String("audit")},
+			},
+		}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *athena.TagResourceInput) bool {
+		return aws.ToString(input.ResourceARN) == "arn:aws:athena:us-west-2:123456789012:workgroup/analytics"
+	})).Return(&athena.TagResourceOutput{}, nil).Once()
+
+	err := tagger.tagAthenaWorkgroups(mockClient)
+	assert.NoError(t, err)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TagResource", 1)
+
+	statuses := map[string]string{}
+	for _, r := range tagger.Results() {
+		statuses[r.ResourceID] = r.Status
+	}
+	assert.Equal(t, map[string]string{
+		"primary": StatusSkipped, "finance": StatusSkipped, "analytics": StatusTagged, "audit": StatusSkipped,
+	}, statuses)
+	assert.Contains(t, logBuffer.String(), "Skipping athena workgroup arn:aws:athena:us-west-2:123456789012:workgroup/primary: default resource")
+	assert.Contains(t, logBuffer.String(), "Skipped 3 Athena workgroups")
+}
@@ -0,0 +1,135 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk/types"
+)
+
+// BeanstalkAPI interface for Elastic Beanstalk client operations
+type BeanstalkAPI interface {
+	DescribeApplications(ctx context.Context, params *elasticbeanstalk.DescribeApplicationsInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.DescribeApplicationsOutput, error)
+	DescribeEnvironments(ctx context.Context, params *elasticbeanstalk.DescribeEnvironmentsInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.DescribeEnvironmentsOutput, error)
+	UpdateTagsForResource(ctx context.Context, params *elasticbeanstalk.UpdateTagsForResourceInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.UpdateTagsForResourceOutput, error)
+}
+
+// BeanstalkMetrics tracks the success/failure metrics for Elastic Beanstalk tagging
+type BeanstalkMetrics struct {
+	ApplicationsFound    int32
+	ApplicationsTagged   int32
+	ApplicationsFailed   int32
+	ApplicationsWouldTag int32
+	EnvironmentsFound    int32
+	EnvironmentsTagged   int32
+	EnvironmentsFailed   int32
+	EnvironmentsWouldTag int32
+}
+
+func init() {
+	registerService("Beanstalk", (*AWSResourceTagger).tagBeanstalkResources)
+}
+
+// tagBeanstalkResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagBeanstalkResources() error {
+	client := elasticbeanstalk.NewFromConfig(t.cfg)
+	metrics := t.tagBeanstalkResourcesWithClient(client)
+
+	log.Printf("Elastic Beanstalk Tagging Summary - Applications: Found=%d, Tagged=%d, Failed=%d, Would tag=%d; Environments: Found=%d, Tagged=%d, Failed=%d, Would tag=%d",
+		metrics.ApplicationsFound, metrics.ApplicationsTagged, metrics.ApplicationsFailed, metrics.ApplicationsWouldTag,
+		metrics.EnvironmentsFound, metrics.EnvironmentsTagged, metrics.EnvironmentsFailed, metrics.EnvironmentsWouldTag)
+	return nil
+}
+
+// tagBeanstalkResourcesWithClient handles the actual tagging logic with a provided client
+func (t *AWSResourceTagger) tagBeanstalkResourcesWithClient(client BeanstalkAPI) *BeanstalkMetrics {
+	fmt.Println("=====================================")
+	log.Println("Tagging Elastic Beanstalk resources...")
+
+	metrics := &BeanstalkMetrics{}
+	t.tagBeanstalkApplications(client, metrics)
+	t.tagBeanstalkEnvironments(client, metrics)
+
+	log.Println("Completed tagging Elastic Beanstalk resources")
+	return metrics
+}
+
+// tagBeanstalkApplications tags every Elastic Beanstalk application
+func (t *AWSResourceTagger) tagBeanstalkApplications(client BeanstalkAPI, metrics *BeanstalkMetrics) {
+	apps, err := client.DescribeApplications(t.ctx, &elasticbeanstalk.DescribeApplicationsInput{})
+	if err != nil {
+		t.handleError(err, "all", "Elastic Beanstalk Applications")
+		return
+	}
+
+	for _, app := range apps.Applications {
+		metrics.ApplicationsFound++
+		name := aws.ToString(app.ApplicationName)
+		if t.tagBeanstalkResource(client, aws.ToString(app.ApplicationArn), name, "application") != nil {
+			metrics.ApplicationsFailed++
+			continue
+		}
+		t.countTagged(&metrics.ApplicationsTagged, &metrics.ApplicationsWouldTag)
+	}
+}
+
+// tagBeanstalkEnvironments tags every Elastic Beanstalk environment
+func (t *AWSResourceTagger) tagBeanstalkEnvironments(client BeanstalkAPI, metrics *BeanstalkMetrics) {
+	input := &elasticbeanstalk.DescribeEnvironmentsInput{}
+	for {
+		envs, err := client.DescribeEnvironments(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Elastic Beanstalk Environments")
+			return
+		}
+
+		for _, env := range envs.Environments {
+			metrics.EnvironmentsFound++
+			name := aws.ToString(env.EnvironmentName)
+			if t.tagBeanstalkResource(client, aws.ToString(env.EnvironmentArn), name, "environment") != nil {
+				metrics.EnvironmentsFailed++
+				continue
+			}
+			t.countTagged(&metrics.EnvironmentsTagged, &metrics.EnvironmentsWouldTag)
+		}
+
+		if envs.NextToken == nil {
+			break
+		}
+		input.NextToken = envs.NextToken
+	}
+}
+
+// tagBeanstalkResource applies the tags to a single application or environment
+// ARN and returns the tag call's error
+func (t *AWSResourceTagger) tagBeanstalkResource(client BeanstalkAPI, arn, name, resourceType string) error {
+	target := TagResult{
+		Service:      "ElasticBeanstalk",
+		ResourceType: resourceType,
+		ResourceID:   name,
+		ARN:          arn,
+	}
+	return t.applyAndRecord(target, func() error {
+		_, err := client.UpdateTagsForResource(t.ctx, &elasticbeanstalk.UpdateTagsForResourceInput{
+			ResourceArn: aws.String(arn),
+			TagsToAdd:   t.convertToBeanstalkTags(),
+		})
+		return err
+	})
+}
+
+// convertToBeanstalkTags converts the common tags map to Elastic Beanstalk tags
+func (t *AWSResourceTagger) convertToBeanstalkTags() []ebtypes.Tag {
+	tags := make([]ebtypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, ebtypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,211 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBeanstalkClient is a mock implementation of BeanstalkAPI
+type MockBeanstalkClient struct {
+	mock.Mock
+}
+
+func (m *MockBeanstalkClient) DescribeApplications(ctx context.Context, params *elasticbeanstalk.DescribeApplicationsInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.DescribeApplicationsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*elasticbeanstalk.DescribeApplicationsOutput), args.Error(1)
+}
+
+func (m *MockBeanstalkClient) DescribeEnvironments(ctx context.Context, params *elasticbeanstalk.DescribeEnvironmentsInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.DescribeEnvironmentsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*elasticbeanstalk.DescribeEnvironmentsOutput), args.Error(1)
+}
+
+func (m *MockBeanstalkClient) UpdateTagsForResource(ctx context.Context, params *elasticbeanstalk.UpdateTagsForResourceInput, optFns ...func(*elasticbeanstalk.Options)) (*elasticbeanstalk.UpdateTagsForResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*elasticbeanstalk.UpdateTagsForResourceOutput), args.Error(1)
+}
+
+func TestTagBeanstalkResourcesWithClient(t *testing.T) {
+	const (
+		appARN = "arn:aws:elasticbeanstalk:us-west-2:123456789012:application/web-app"
+		envARN = "arn:aws:elasticbeanstalk:us-west-2:123456789012:environment/web-app/web-prod"
+	)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockBeanstalkClient)
+		expectedTagged []string
+		expectedFailed []string
+	}{
+		{
+			name: "Tag both applications and environments",
+			setupMock: func(m *MockBeanstalkClient) {
+				m.On("DescribeApplications", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.DescribeApplicationsOutput{
+						Applications: []ebtypes.ApplicationDescription{
+							{ApplicationName: aws.String("web-app"), ApplicationArn: aws.String(appARN)},
+						},
+					}, nil)
+				m.On("DescribeEnvironments", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.DescribeEnvironmentsOutput{
+						Environments: []ebtypes.EnvironmentDescription{
+							{EnvironmentName: aws.String("web-prod"), EnvironmentArn: aws.String(envARN)},
+						},
+					}, nil)
+				m.On("UpdateTagsForResource", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.UpdateTagsForResourceOutput{}, nil)
+			},
+			expectedTagged: []string{appARN, envARN},
+		},
+		{
+			name: "Environments are tagged when listing applications fails",
+			setupMock: func(m *MockBeanstalkClient) {
+				m.On("DescribeApplications", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
+				m.On("DescribeEnvironments", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.DescribeEnvironmentsOutput{
+						Environments: []ebtypes.EnvironmentDescription{
+							{EnvironmentName: aws.String("web-prod"), EnvironmentArn: aws.String(envARN)},
+						},
+					}, nil)
+				m.On("UpdateTagsForResource", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.UpdateTagsForResourceOutput{}, nil)
+			},
+			expectedTagged: []string{envARN},
+		},
+		{
+			name: "Record failed application tagging",
+			setupMock: func(m *MockBeanstalkClient) {
+				m.On("DescribeApplications", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.DescribeApplicationsOutput{
+						Applications: []ebtypes.ApplicationDescription{
+							{ApplicationName: aws.String("web-app"), ApplicationArn: aws.String(appARN)},
+						},
+					}, nil)
+				m.On("DescribeEnvironments", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.DescribeEnvironmentsOutput{}, nil)
+				m.On("UpdateTagsForResource", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
+			},
+			expectedFailed: []string{appARN},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+
+			mockClient := new(MockBeanstalkClient)
+			tt.setupMock(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:     context.Background(),
+				tags:    map[string]string{"env": "prod"},
+				results: NewResultCollector(),
+			}
+			tagger.tagBeanstalkResourcesWithClient(mockClient)
+
+			var tagged, failed []string
+			for _, r := range tagger.Results() {
+				assert.Equal(t, "ElasticBeanstalk", r.Service)
+				switch r.Status {
+				case StatusTagged:
+					tagged = append(tagged, r.ARN)
+				case StatusFailed:
+					failed = append(failed, r.ARN)
+				}
+			}
+			assert.Equal(t, tt.expectedTagged, tagged)
+			assert.Equal(t, tt.expectedFailed, failed)
+
+			for _, arn := range append(tt.expectedTagged, tt.expectedFailed...) {
+				mockClient.AssertCalled(t, "UpdateTagsForResource", mock.Anything, mock.MatchedBy(func(input *elasticbeanstalk.UpdateTagsForResourceInput) bool {
+					return aws.ToString(input.ResourceArn) == arn && len(input.TagsToAdd) == 1
+				}))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagBeanstalkApplicationsMetrics(t *testing.T) {
+	appARN := func(name string) string {
+		return "arn:aws:elasticbeanstalk:us-west-2:123456789012:application/" + name
+	}
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		failing  string
+		expected BeanstalkMetrics
+	}{
+		{
+			name:     "All applications tagged",
+			expected: BeanstalkMetrics{ApplicationsFound: 2, ApplicationsTagged: 2},
+		},
+		{
+			name:     "One application fails",
+			failing:  appARN("billing"),
+			expected: BeanstalkMetrics{ApplicationsFound: 2, ApplicationsTagged: 1, ApplicationsFailed: 1},
+		},
+		{
+			name:     "Dry run counts would-tag",
+			dryRun:   true,
+			expected: BeanstalkMetrics{ApplicationsFound: 2, ApplicationsWouldTag: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockBeanstalkClient)
+			mockClient.On("DescribeApplications", mock.Anything, mock.Anything).
+				Return(&elasticbeanstalk.DescribeApplicationsOutput{
+					Applications: []ebtypes.ApplicationDescription{
+						{ApplicationName: aws.String("web-app"), ApplicationArn: aws.String(appARN("web-app"))},
+						{ApplicationName: aws.String("billing"), ApplicationArn: aws.String(appARN("billing"))},
+					},
+				}, nil)
+			mockClient.On("DescribeEnvironments", mock.Anything, mock.Anything).
+				Return(&elasticbeanstalk.DescribeEnvironmentsOutput{}, nil)
+			if !tt.dryRun {
+				mockClient.On("UpdateTagsForResource", mock.Anything, mock.MatchedBy(func(input *elasticbeanstalk.UpdateTagsForResourceInput) bool {
+					return aws.ToString(input.ResourceArn) == tt.failing
+				})).Return(nil, errors.New("AccessDenied")).Maybe()
+				mockClient.On("UpdateTagsForResource", mock.Anything, mock.Anything).
+					Return(&elasticbeanstalk.UpdateTagsForResourceOutput{}, nil)
+			}
+
+			tagger := createTestTagger()
+			tagger.results = NewResultCollector()
+			WithDryRun(tt.dryRun)(tagger)
+			metrics := tagger.tagBeanstalkResourcesWithClient(mockClient)
+
+			assert.Equal(t, tt.expected, *metrics)
+			mockClient.AssertExpectations(t)
+			if tt.dryRun {
+				mockClient.AssertNotCalled(t, "UpdateTagsForResource", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
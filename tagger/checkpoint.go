@@ -0,0 +1,137 @@
+package tagger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// checkpointFlushEvery is how many tagged resources are buffered before the
+// checkpoint file is flushed
+const checkpointFlushEvery = 25
+
+// checkpoint records tagged resources, one ARN (or ID when there is no ARN)
+// per line, so an interrupted run can resume where it stopped
+type checkpoint struct {
+	mu        sync.Mutex
+	done      map[string]bool
+	file      *os.File
+	writer    *bufio.Writer
+	unflushed int
+}
+
+// WithCheckpointFile appends every tagged resource to path. With resume set,
+// resources already listed in path are skipped instead of tagged again.
+func WithCheckpointFile(path string, resume bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.checkpointFile = path
+		t.resume = resume
+	}
+}
+
+// openCheckpoint opens path for appending, loading its entries when resuming.
+// Without resume an existing file is truncated.
+func openCheckpoint(path string, resume bool) (*checkpoint, error) {
+	cp := &checkpoint{done: make(map[string]bool)}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		if err := cp.load(path); err != nil {
+			return nil, err
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		log.Printf("Resuming from checkpoint %s: %d resources already tagged", path, len(cp.done))
+	}
+
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint file: %w", err)
+	}
+	cp.file = file
+	cp.writer = bufio.NewWriter(file)
+	return cp, nil
+}
+
+// load reads the entries of an existing checkpoint file, ignoring blank and #
+// comment lines; a missing file is empty
+func (c *checkpoint) load(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			c.done[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// contains reports whether resource was tagged by this or a checkpointed run.
+// It is safe on a nil checkpoint.
+func (c *checkpoint) contains(resource string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[resource]
+}
+
+// add records resource as tagged, flushing every checkpointFlushEvery entries.
+// It is a no-op on a nil checkpoint.
+func (c *checkpoint) add(resource string) {
+	if c == nil || resource == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done[resource] {
+		return
+	}
+	c.done[resource] = true
+	fmt.Fprintln(c.writer, resource)
+	if c.unflushed++; c.unflushed >= checkpointFlushEvery {
+		c.flushLocked()
+	}
+}
+
+// close flushes pending entries and closes the file
+func (c *checkpoint) close() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushLocked()
+	return c.file.Close()
+}
+
+// flushLocked writes buffered entries; c.mu must be held
+func (c *checkpoint) flushLocked() {
+	if err := c.writer.Flush(); err != nil {
+		log.Printf("Error writing checkpoint file: %v", err)
+	}
+	c.unflushed = 0
+}
+
+// checkpointed reports whether target is in the checkpoint and records it as skipped
+func (t *AWSResourceTagger) checkpointed(target TagResult) bool {
+	if !t.checkpoint.contains(resultIdentifier(target)) {
+		return false
+	}
+	t.skipResource(target, "already tagged according to checkpoint")
+	return true
+}
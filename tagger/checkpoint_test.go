@@ -0,0 +1,142 @@
+package tagger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeSkipsCheckpointedResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# previous run\n"+snsTopicARN("alerts")+"\n\n"), 0o644))
+
+	cp, err := openCheckpoint(path, true)
+	require.NoError(t, err)
+
+	mockClient := new(MockSNSClient)
+	mockClient.On("ListTopics", mock.Anything, &sns.ListTopicsInput{}).
+		Return(&sns.ListTopicsOutput{
+			Topics: []snstypes.Topic{
+				{TopicArn: aws.String(snsTopicARN("alerts"))},
+				{TopicArn: aws.String(snsTopicARN("orders"))},
+			},
+		}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, snsTopic("orders")).Return(&sns.TagResourceOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:        context.Background(),
+		tags:       map[string]string{"map-migrated": "mig12345"},
+		results:    NewResultCollector(),
+		checkpoint: cp,
+	}
+	tagger.tagSNSResourcesWithClient(mockClient)
+	require.NoError(t, cp.close())
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, snsTopic("alerts"))
+
+	results := tagger.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, StatusTagged, results[1].Status)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# previous run\n"+snsTopicARN("alerts")+"\n\n"+snsTopicARN("orders")+"\n", string(data))
+}
+
+func TestCheckpointedGlueJobIsSkippedNotWouldTag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+	require.NoError(t, os.WriteFile(path, []byte("arn:aws:glue:us-west-2:123456789012:job/done\n"), 0o644))
+	cp, err := openCheckpoint(path, true)
+	require.NoError(t, err)
+	defer cp.close()
+
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetJobs", mock.Anything, mock.Anything).Return(&glue.GetJobsOutput{
+		Jobs: []gluetypes.Job{{Name: aws.String("done")}, {Name: aws.String("new")}},
+	}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.checkpoint = cp
+	WithDryRun(true)(tagger)
+
+	metrics := &GlueMetrics{}
+	tagger.tagGlueJobs(mockClient, metrics)
+
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	assert.Equal(t, int32(1), metrics.JobsSkipped)
+	assert.Equal(t, int32(1), metrics.JobsWouldTag)
+	statuses := map[string]string{}
+	for _, result := range tagger.Results() {
+		statuses[result.ResourceID] = result.Status
+	}
+	assert.Equal(t, map[string]string{"done": StatusSkipped, "new": StatusWouldTag}, statuses)
+}
+
+func TestCheckpointedARNsAreLeftOutOfTagResourcesBatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+	require.NoError(t, os.WriteFile(path, []byte(snsTopicARN("alerts")+"\n"), 0o644))
+	cp, err := openCheckpoint(path, true)
+	require.NoError(t, err)
+	defer cp.close()
+
+	mockClient := new(MockResourceGroupsTaggingClient)
+	mockClient.On("TagResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.TagResourcesInput) bool {
+		return assert.ObjectsAreEqual([]string{snsTopicARN("orders")}, input.ResourceARNList)
+	})).Return(&resourcegroupstaggingapi.TagResourcesOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:        context.Background(),
+		tags:       map[string]string{"map-migrated": "mig12345"},
+		results:    NewResultCollector(),
+		checkpoint: cp,
+	}
+	tagger.tagARNsWithClient(mockClient, []string{snsTopicARN("alerts"), snsTopicARN("orders")})
+
+	mockClient.AssertExpectations(t)
+	results := tagger.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, "already tagged according to checkpoint", results[0].Error)
+	assert.Equal(t, StatusTagged, results[1].Status)
+}
+
+func TestCheckpointWithoutResumeStartsFresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+	require.NoError(t, os.WriteFile(path, []byte(snsTopicARN("alerts")+"\n"), 0o644))
+
+	cp, err := openCheckpoint(path, false)
+	require.NoError(t, err)
+
+	assert.False(t, cp.contains(snsTopicARN("alerts")))
+	cp.add(snsTopicARN("orders"))
+	cp.add(snsTopicARN("orders"))
+	require.NoError(t, cp.close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, snsTopicARN("orders")+"\n", string(data))
+}
+
+func TestResumeWithMissingCheckpointFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+
+	cp, err := openCheckpoint(path, true)
+	require.NoError(t, err)
+	defer cp.close()
+
+	assert.False(t, cp.contains(snsTopicARN("alerts")))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/aws"
 )
 
@@ -16,15 +17,27 @@ type CloudWatchAPI interface {
 	TagResource(ctx context.Context, params *cloudwatch.TagResourceInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.TagResourceOutput, error)
 }
 
-// tagCloudWatchResources creates a CloudWatch client and initiates the tagging process
-func (t *AWSResourceTagger) tagCloudWatchResources() {
+func init() {
+	registerService("CloudWatch", (*AWSResourceTagger).tagCloudWatchResources)
+}
+
+// tagCloudWatchResources creates CloudWatch and CloudWatch Logs clients and initiates the tagging process
+func (t *AWSResourceTagger) tagCloudWatchResources() error {
 	client := cloudwatch.NewFromConfig(t.cfg)
-	t.tagCloudWatchResourcesWithClient(client)
+	logsClient := cloudwatchlogs.NewFromConfig(t.cfg)
+	t.tagCloudWatchResourcesWithClients(client, logsClient)
+	return nil
 }
 
-// tagCloudWatchResourcesWithClient tags CloudWatch alarms and dashboards with the provided client.
-// It logs the process and handles errors. The process includes pagination for fetching alarms and dashboards.
+// tagCloudWatchResourcesWithClient tags CloudWatch alarms and dashboards only
 func (t *AWSResourceTagger) tagCloudWatchResourcesWithClient(client CloudWatchAPI) {
+	t.tagCloudWatchResourcesWithClients(client, nil)
+}
+
+// tagCloudWatchResourcesWithClients tags CloudWatch alarms and dashboards and, when logsClient is
+// not nil, log groups. It logs the process and handles errors. The process includes pagination
+// for fetching every resource type.
+func (t *AWSResourceTagger) tagCloudWatchResourcesWithClients(client CloudWatchAPI, logsClient CloudWatchLogsAPI) {
 	log.Println("Starting CloudWatch resource tagging...")
 	defer log.Println("Completed CloudWatch resource tagging")
 
@@ -37,17 +50,22 @@ func (t *AWSResourceTagger) tagCloudWatchResourcesWithClient(client CloudWatchAP
 		totalAlarms      int
 		taggedAlarms     int
 		failedAlarms     int
+		totalComposite   int
+		taggedComposite  int
+		failedComposite  int
 		totalDashboards  int
 		taggedDashboards int
 		failedDashboards int
 	)
 
-	// Tag CloudWatch Alarms with pagination
+	// Tag CloudWatch metric and composite alarms with pagination; DescribeAlarms
+	// only returns metric alarms unless both types are requested
 	log.Println("Discovering CloudWatch alarms...")
 	var nextTokenAlarms *string
 	for {
 		output, err := client.DescribeAlarms(t.ctx, &cloudwatch.DescribeAlarmsInput{
-			NextToken: nextTokenAlarms,
+			AlarmTypes: []cloudwatchtypes.AlarmType{cloudwatchtypes.AlarmTypeMetricAlarm, cloudwatchtypes.AlarmTypeCompositeAlarm},
+			NextToken:  nextTokenAlarms,
 		})
 		if err != nil {
 			log.Printf("Error describing CloudWatch alarms: %v", err)
@@ -56,25 +74,24 @@ func (t *AWSResourceTagger) tagCloudWatchResourcesWithClient(client CloudWatchAP
 
 		totalAlarms += len(output.MetricAlarms)
 		for _, alarm := range output.MetricAlarms {
-			cwTags := make([]cloudwatchtypes.Tag, 0, len(t.tags))
-			for k, v := range t.tags {
-				cwTags = append(cwTags, cloudwatchtypes.Tag{
-					Key:   aws.String(k),
-					Value: aws.String(v),
-				})
+			err := t.tagCloudWatchResource(client, "alarm", alarm.AlarmName, alarm.AlarmArn)
+			switch {
+			case err != nil:
+				failedAlarms++
+			case !t.dryRun:
+				taggedAlarms++
 			}
+		}
 
-			_, err := client.TagResource(t.ctx, &cloudwatch.TagResourceInput{
-				ResourceARN: alarm.AlarmArn,
-				Tags:        cwTags,
-			})
-			if err != nil {
-				failedAlarms++
-				t.handleError(err, *alarm.AlarmArn, "CloudWatch Alarm")
-				continue
+		totalComposite += len(output.CompositeAlarms)
+		for _, alarm := range output.CompositeAlarms {
+			err := t.tagCloudWatchResource(client, "composite alarm", alarm.AlarmName, alarm.AlarmArn)
+			switch {
+			case err != nil:
+				failedComposite++
+			case !t.dryRun:
+				taggedComposite++
 			}
-			taggedAlarms++
-			log.Printf("Successfully tagged CloudWatch alarm: %s", *alarm.AlarmName)
 		}
 
 		if output.NextToken == nil {
@@ -97,25 +114,13 @@ func (t *AWSResourceTagger) tagCloudWatchResourcesWithClient(client CloudWatchAP
 
 		totalDashboards += len(dashboards.DashboardEntries)
 		for _, dashboard := range dashboards.DashboardEntries {
-			cwTags := make([]cloudwatchtypes.Tag, 0, len(t.tags))
-			for k, v := range t.tags {
-				cwTags = append(cwTags, cloudwatchtypes.Tag{
-					Key:   aws.String(k),
-					Value: aws.String(v),
-				})
-			}
-
-			_, err := client.TagResource(t.ctx, &cloudwatch.TagResourceInput{
-				ResourceARN: dashboard.DashboardArn,
-				Tags:        cwTags,
-			})
-			if err != nil {
+			err := t.tagCloudWatchResource(client, "dashboard", dashboard.DashboardName, dashboard.DashboardArn)
+			switch {
+			case err != nil:
 				failedDashboards++
-				t.handleError(err, *dashboard.DashboardArn, "CloudWatch Dashboard")
-				continue
+			case !t.dryRun:
+				taggedDashboards++
 			}
-			taggedDashboards++
-			log.Printf("Successfully tagged CloudWatch dashboard: %s", *dashboard.DashboardName)
 		}
 
 		if dashboards.NextToken == nil {
@@ -124,8 +129,43 @@ func (t *AWSResourceTagger) tagCloudWatchResourcesWithClient(client CloudWatchAP
 		nextTokenDashboards = dashboards.NextToken
 	}
 
+	// Tag CloudWatch Logs log groups with pagination
+	var logGroups logGroupCounts
+	if logsClient != nil {
+		log.Println("Discovering CloudWatch log groups...")
+		logGroups = t.tagCloudWatchLogGroupsWithClient(logsClient)
+	}
+
 	// Print summary
 	log.Println("CloudWatch Tagging Summary:")
 	log.Printf("Alarms: Total=%d, Tagged=%d, Failed=%d", totalAlarms, taggedAlarms, failedAlarms)
+	log.Printf("Composite Alarms: Total=%d, Tagged=%d, Failed=%d", totalComposite, taggedComposite, failedComposite)
 	log.Printf("Dashboards: Total=%d, Tagged=%d, Failed=%d", totalDashboards, taggedDashboards, failedDashboards)
+	if logsClient != nil {
+		log.Printf("Log Groups: Total=%d, Tagged=%d, Failed=%d", logGroups.total, logGroups.tagged, logGroups.failed)
+	}
+}
+
+// tagCloudWatchResource adds the configured tags to a CloudWatch alarm or
+// dashboard by ARN and records the outcome
+func (t *AWSResourceTagger) tagCloudWatchResource(client CloudWatchAPI, resourceType string, name, arn *string) error {
+	cwTags := make([]cloudwatchtypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		cwTags = append(cwTags, cloudwatchtypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return t.applyAndRecord(TagResult{
+		Service:      "CloudWatch",
+		ResourceType: resourceType,
+		ResourceID:   aws.StringValue(name),
+		ARN:          aws.StringValue(arn),
+	}, func() error {
+		_, err := client.TagResource(t.ctx, &cloudwatch.TagResourceInput{
+			ResourceARN: arn,
+			Tags:        cwTags,
+		})
+		return err
+	})
 }
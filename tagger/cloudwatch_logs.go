@@ -0,0 +1,82 @@
+package tagger
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// CloudWatchLogsAPI interface for CloudWatch Logs client operations
+type CloudWatchLogsAPI interface {
+	DescribeLogGroups(ctx context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	TagResource(ctx context.Context, params *cloudwatchlogs.TagResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error)
+}
+
+// WithLogGroupFilter tags only log groups whose name starts with prefix and
+// that store at least minBytes; empty and zero values disable each filter
+func WithLogGroupFilter(prefix string, minBytes int64) Option {
+	return func(t *AWSResourceTagger) {
+		t.logGroupPrefix = prefix
+		t.logGroupMinBytes = minBytes
+	}
+}
+
+// logGroupCounts summarises a log group tagging pass for the CloudWatch summary
+type logGroupCounts struct {
+	total  int
+	tagged int
+	failed int
+}
+
+// tagCloudWatchLogGroupsWithClient tags every log group matching the log
+// group filter and records its retention setting so the report can be
+// correlated with storage cost
+func (t *AWSResourceTagger) tagCloudWatchLogGroupsWithClient(client CloudWatchLogsAPI) logGroupCounts {
+	var counts logGroupCounts
+	input := &cloudwatchlogs.DescribeLogGroupsInput{}
+	if t.logGroupPrefix != "" {
+		input.LogGroupNamePrefix = aws.String(t.logGroupPrefix)
+	}
+	for {
+		output, err := client.DescribeLogGroups(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "CloudWatch Log Groups")
+			return counts
+		}
+
+		for _, group := range output.LogGroups {
+			if aws.ToInt64(group.StoredBytes) < t.logGroupMinBytes {
+				continue
+			}
+			counts.total++
+			// LogGroupArn omits the trailing :* that Arn carries, as TagResource expects
+			arn := aws.ToString(group.LogGroupArn)
+			err := t.applyAndRecord(TagResult{
+				Service:      "CloudWatchLogs",
+				ResourceType: "log-group",
+				ResourceID:   aws.ToString(group.LogGroupName),
+				ARN:          arn,
+				Retention:    group.RetentionInDays,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &cloudwatchlogs.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.tags,
+				})
+				return err
+			})
+			switch {
+			case err != nil:
+				counts.failed++
+			case !t.dryRun:
+				counts.tagged++
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return counts
+}
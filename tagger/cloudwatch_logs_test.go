@@ -0,0 +1,161 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCloudWatchLogsClient is a mock implementation of CloudWatchLogsAPI
+type MockCloudWatchLogsClient struct {
+	mock.Mock
+}
+
+func (m *MockCloudWatchLogsClient) DescribeLogGroups(ctx context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
+}
+
+func (m *MockCloudWatchLogsClient) TagResource(ctx context.Context, params *cloudwatchlogs.TagResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudwatchlogs.TagResourceOutput), args.Error(1)
+}
+
+func TestTagCloudWatchLogGroups(t *testing.T) {
+	mockClient := new(MockCloudWatchLogsClient)
+	mockClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{
+				{
+					LogGroupName:    aws.String("/aws/lambda/ingest"),
+					Arn:             aws.String("arn:aws:logs:us-west-2:123456789012:log-group:/aws/lambda/ingest:*"),
+					LogGroupArn:     aws.String("arn:aws:logs:us-west-2:123456789012:log-group:/aws/lambda/ingest"),
+					RetentionInDays: aws.Int32(30),
+				},
+			},
+			NextToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{NextToken: aws.String("page-2")}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{
+				{
+					LogGroupName: aws.String("app"),
+					LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:app"),
+				},
+			},
+		}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &cloudwatchlogs.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:/aws/lambda/ingest"),
+		Tags:        map[string]string{"env": "prod"},
+	}).Return(&cloudwatchlogs.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &cloudwatchlogs.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:app"),
+		Tags:        map[string]string{"env": "prod"},
+	}).Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	counts := tagger.tagCloudWatchLogGroupsWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, logGroupCounts{total: 2, tagged: 1, failed: 1}, counts)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	if assert.NotNil(t, results[0].Retention) {
+		assert.Equal(t, int32(30), *results[0].Retention)
+	}
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.Nil(t, results[1].Retention, "groups that never expire have no retention")
+}
+
+func TestTagCloudWatchLogGroupsByPrefix(t *testing.T) {
+	mockClient := new(MockCloudWatchLogsClient)
+	mockClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String("/aws/lambda/"),
+	}).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+		LogGroups: []cwltypes.LogGroup{
+			{
+				LogGroupName: aws.String("/aws/lambda/ingest"),
+				LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:/aws/lambda/ingest"),
+			},
+		},
+	}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &cloudwatchlogs.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:/aws/lambda/ingest"),
+		Tags:        map[string]string{"env": "prod"},
+	}).Return(&cloudwatchlogs.TagResourceOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	WithLogGroupFilter("/aws/lambda/", 0)(tagger)
+	counts := tagger.tagCloudWatchLogGroupsWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, logGroupCounts{total: 1, tagged: 1}, counts)
+}
+
+func TestTagCloudWatchLogGroupsByMinBytes(t *testing.T) {
+	mockClient := new(MockCloudWatchLogsClient)
+	mockClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{
+				{
+					LogGroupName: aws.String("small"),
+					LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:small"),
+					StoredBytes:  aws.Int64(1023),
+				},
+				{
+					LogGroupName: aws.String("exact"),
+					LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:exact"),
+					StoredBytes:  aws.Int64(1024),
+				},
+				{
+					LogGroupName: aws.String("large"),
+					LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:large"),
+					StoredBytes:  aws.Int64(1 << 30),
+				},
+				{
+					LogGroupName: aws.String("empty"),
+					LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:empty"),
+				},
+			},
+		}, nil).Once()
+	var tagged []string
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		tagged = append(tagged, aws.ToString(args.Get(1).(*cloudwatchlogs.TagResourceInput).ResourceArn))
+	}).Return(&cloudwatchlogs.TagResourceOutput{}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	WithLogGroupFilter("", 1024)(tagger)
+	counts := tagger.tagCloudWatchLogGroupsWithClient(mockClient)
+
+	assert.Equal(t, logGroupCounts{total: 2, tagged: 2}, counts)
+	assert.Equal(t, []string{
+		"arn:aws:logs:us-west-2:123456789012:log-group:exact",
+		"arn:aws:logs:us-west-2:123456789012:log-group:large",
+	}, tagged)
+}
@@ -5,14 +5,20 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/smithy-go"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -251,6 +257,52 @@ func TestTagCloudWatchResourcesWithTagError(t *testing.T) {
 	assert.Contains(t, logOutput, "Access denied")
 }
 
+func TestTagCloudWatchResourcesTagsCompositeAlarms(t *testing.T) {
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("DescribeAlarms", mock.Anything, &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []cloudwatchtypes.AlarmType{cloudwatchtypes.AlarmTypeMetricAlarm, cloudwatchtypes.AlarmTypeCompositeAlarm},
+	}).Return(&cloudwatch.DescribeAlarmsOutput{
+		MetricAlarms: []cloudwatchtypes.MetricAlarm{{
+			AlarmName: aws.String("cpu-high"),
+			AlarmArn:  aws.String("arn:aws:cloudwatch:us-west-2:123456789012:alarm:cpu-high"),
+		}},
+		CompositeAlarms: []cloudwatchtypes.CompositeAlarm{{
+			AlarmName: aws.String("service-degraded"),
+			AlarmArn:  aws.String("arn:aws:cloudwatch:us-west-2:123456789012:alarm:service-degraded"),
+		}},
+	}, nil)
+	mockClient.On("ListDashboards", mock.Anything, mock.Anything).Return(&cloudwatch.ListDashboardsOutput{}, nil)
+	for _, arn := range []string{
+		"arn:aws:cloudwatch:us-west-2:123456789012:alarm:cpu-high",
+		"arn:aws:cloudwatch:us-west-2:123456789012:alarm:service-degraded",
+	} {
+		mockClient.On("TagResource", mock.Anything, &cloudwatch.TagResourceInput{
+			ResourceARN: aws.String(arn),
+			Tags:        []cloudwatchtypes.Tag{{Key: aws.String("Environment"), Value: aws.String("Test")}},
+		}).Return(&cloudwatch.TagResourceOutput{}, nil).Once()
+	}
+
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger := createTestTagger()
+	tagger.tags = map[string]string{"Environment": "Test"}
+	tagger.results = NewResultCollector()
+	tagger.tagCloudWatchResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	logOutput := logBuffer.String()
+	assert.Regexp(t, `\d Alarms: Total=1, Tagged=1, Failed=0`, logOutput)
+	assert.Contains(t, logOutput, "Composite Alarms: Total=1, Tagged=1, Failed=0")
+	assert.Equal(t, []TagResult{
+		{Service: "CloudWatch", ResourceType: "alarm", ResourceID: "cpu-high",
+			ARN: "arn:aws:cloudwatch:us-west-2:123456789012:alarm:cpu-high", Status: StatusTagged, Tags: tagger.tags},
+		{Service: "CloudWatch", ResourceType: "composite alarm", ResourceID: "service-degraded",
+			ARN: "arn:aws:cloudwatch:us-west-2:123456789012:alarm:service-degraded", Status: StatusTagged, Tags: tagger.tags},
+	}, tagger.Results())
+}
+
 func TestTagCloudWatchResourcesEmptyTags(t *testing.T) {
 	ctx := context.Background()
 	tagger := &AWSResourceTagger{
@@ -283,6 +335,7 @@ func TestTagCloudWatchResourcesEmptyTags(t *testing.T) {
 
 func TestTagCloudWatchResources(t *testing.T) {
 	ctx := context.Background()
+	alarmTypes := []cloudwatchtypes.AlarmType{cloudwatchtypes.AlarmTypeMetricAlarm, cloudwatchtypes.AlarmTypeCompositeAlarm}
 	validTags := map[string]string{
 		"Environment": "Test",
 		"Project":     "Demo",
@@ -301,7 +354,8 @@ func TestTagCloudWatchResources(t *testing.T) {
 			setupMocks: func(m *MockCloudWatchClient) {
 				// First page of alarms
 				m.On("DescribeAlarms", mock.Anything, &cloudwatch.DescribeAlarmsInput{
-					NextToken: (*string)(nil),
+					AlarmTypes: alarmTypes,
+					NextToken:  (*string)(nil),
 				}).Return(&cloudwatch.DescribeAlarmsOutput{
 					MetricAlarms: []cloudwatchtypes.MetricAlarm{
 						{
@@ -314,7 +368,8 @@ func TestTagCloudWatchResources(t *testing.T) {
 
 				// Second page of alarms
 				m.On("DescribeAlarms", mock.Anything, &cloudwatch.DescribeAlarmsInput{
-					NextToken: aws.String("next-token"),
+					AlarmTypes: alarmTypes,
+					NextToken:  aws.String("next-token"),
 				}).Return(&cloudwatch.DescribeAlarmsOutput{
 					MetricAlarms: []cloudwatchtypes.MetricAlarm{
 						{
@@ -427,8 +482,8 @@ func TestTagCloudWatchResources(t *testing.T) {
 			},
 			expectLogs: []string{
 				"Starting CloudWatch resource tagging...",
-				"Error tagging CloudWatch Alarm",
-				"Error tagging CloudWatch Dashboard",
+				"Error tagging CloudWatch alarm",
+				"Error tagging CloudWatch dashboard",
 				"Completed CloudWatch resource tagging",
 			},
 		},
@@ -470,3 +525,120 @@ func TestTagCloudWatchResources(t *testing.T) {
 		})
 	}
 }
+
+func TestTagCloudWatchResourcesIncludesLogGroups(t *testing.T) {
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("DescribeAlarms", mock.Anything, mock.Anything).Return(&cloudwatch.DescribeAlarmsOutput{}, nil)
+	mockClient.On("ListDashboards", mock.Anything, mock.Anything).Return(&cloudwatch.ListDashboardsOutput{}, nil)
+
+	// Two pages of log groups, the second group failing
+	logsClient := new(MockCloudWatchLogsClient)
+	logsClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{{
+				LogGroupName: aws.String("group1"),
+				LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:group1"),
+			}},
+			NextToken: aws.String("next-token"),
+		}, nil).Once()
+	logsClient.On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{NextToken: aws.String("next-token")}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{{
+				LogGroupName: aws.String("group2"),
+				LogGroupArn:  aws.String("arn:aws:logs:us-west-2:123456789012:log-group:group2"),
+			}},
+		}, nil).Once()
+	logsClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *cloudwatchlogs.TagResourceInput) bool {
+		return strings.HasSuffix(aws.ToString(input.ResourceArn), ":group1")
+	})).Return(&cloudwatchlogs.TagResourceOutput{}, nil).Once()
+	logsClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *cloudwatchlogs.TagResourceInput) bool {
+		return strings.HasSuffix(aws.ToString(input.ResourceArn), ":group2")
+	})).Return(nil, fmt.Errorf("AccessDeniedException")).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"Environment": "Test"},
+		results: NewResultCollector(),
+	}
+
+	// Capture logs
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger.tagCloudWatchResourcesWithClients(mockClient, logsClient)
+
+	mockClient.AssertExpectations(t)
+	logsClient.AssertExpectations(t)
+	logOutput := logBuffer.String()
+	assert.Contains(t, logOutput, "Discovering CloudWatch log groups...")
+	assert.Contains(t, logOutput, "CloudWatch Tagging Summary:")
+	assert.Contains(t, logOutput, "Log Groups: Total=2, Tagged=1, Failed=1")
+}
+
+// fakeCloudWatchHTTPClient serves two alarms and no dashboards or log groups,
+// counting TagResource calls per resource ARN
+type fakeCloudWatchHTTPClient struct {
+	mu     sync.Mutex
+	tagged map[string]int
+}
+
+func (c *fakeCloudWatchHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response string
+	contentType := "text/xml"
+	if req.Header.Get("X-Amz-Target") != "" {
+		// CloudWatch Logs speaks JSON; report no log groups
+		response, contentType = "{}", "application/x-amz-json-1.1"
+	} else {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		switch action := form.Get("Action"); action {
+		case "DescribeAlarms":
+			response = `<DescribeAlarmsResponse><DescribeAlarmsResult><MetricAlarms>` +
+				`<member><AlarmName>cpu</AlarmName><AlarmArn>arn:aws:cloudwatch:us-west-2:123456789012:alarm:cpu</AlarmArn></member>` +
+				`<member><AlarmName>disk</AlarmName><AlarmArn>arn:aws:cloudwatch:us-west-2:123456789012:alarm:disk</AlarmArn></member>` +
+				`</MetricAlarms></DescribeAlarmsResult></DescribeAlarmsResponse>`
+		case "ListDashboards":
+			response = `<ListDashboardsResponse><ListDashboardsResult/></ListDashboardsResponse>`
+		case "TagResource":
+			c.mu.Lock()
+			c.tagged[form.Get("ResourceARN")]++
+			c.mu.Unlock()
+			response = `<TagResourceResponse><TagResourceResult/></TagResourceResponse>`
+		default:
+			return nil, fmt.Errorf("unexpected CloudWatch action %q", action)
+		}
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func TestTagCloudWatchResourcesTagsEachAlarmOnce(t *testing.T) {
+	httpClient := &fakeCloudWatchHTTPClient{tagged: map[string]int{}}
+	tagger := createTestTagger()
+	tagger.cfg = aws.Config{
+		Region:           "us-west-2",
+		Credentials:      aws.AnonymousCredentials{},
+		HTTPClient:       httpClient,
+		RetryMaxAttempts: 1,
+	}
+
+	tagger.tagCloudWatchResources()
+
+	assert.Equal(t, map[string]int{
+		"arn:aws:cloudwatch:us-west-2:123456789012:alarm:cpu":  1,
+		"arn:aws:cloudwatch:us-west-2:123456789012:alarm:disk": 1,
+	}, httpClient.tagged)
+}
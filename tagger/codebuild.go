@@ -0,0 +1,104 @@
+package tagger
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	codebuildtypes "github.com/aws/aws-sdk-go-v2/service/codebuild/types"
+)
+
+// codeBuildBatchSize is the maximum number of projects accepted by BatchGetProjects
+const codeBuildBatchSize = 100
+
+// CodeBuildAPI interface for CodeBuild client operations
+type CodeBuildAPI interface {
+	ListProjects(ctx context.Context, params *codebuild.ListProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.ListProjectsOutput, error)
+	BatchGetProjects(ctx context.Context, params *codebuild.BatchGetProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.BatchGetProjectsOutput, error)
+	UpdateProject(ctx context.Context, params *codebuild.UpdateProjectInput, optFns ...func(*codebuild.Options)) (*codebuild.UpdateProjectOutput, error)
+}
+
+// tagCodeBuildProjects tags every CodeBuild project. CodeBuild has no tag
+// API, and UpdateProject replaces the whole tag set, so the configured tags
+// are merged into each project's existing tags.
+func (t *AWSResourceTagger) tagCodeBuildProjects(client CodeBuildAPI) {
+	var names []string
+	input := &codebuild.ListProjectsInput{}
+	for {
+		output, err := client.ListProjects(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "CodeBuild Projects")
+			return
+		}
+		names = append(names, output.Projects...)
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	for start := 0; start < len(names); start += codeBuildBatchSize {
+		end := start + codeBuildBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		output, err := client.BatchGetProjects(t.ctx, &codebuild.BatchGetProjectsInput{
+			Names: names[start:end],
+		})
+		if err != nil {
+			t.handleError(err, "all", "CodeBuild Projects")
+			continue
+		}
+
+		for _, project := range output.Projects {
+			name := aws.ToString(project.Name)
+			target := TagResult{
+				Service:      "CodeBuild",
+				ResourceType: "project",
+				ResourceID:   name,
+				ARN:          aws.ToString(project.Arn),
+			}
+			// BatchGetProjects already returned the tags, so --skip-tagged costs no extra read
+			if t.skipAlreadyApplied(target, codeBuildTagMap(project.Tags)) {
+				continue
+			}
+			tags := t.mergeCodeBuildTags(project.Tags)
+			t.applyAndRecord(target, func() error {
+				_, err := client.UpdateProject(t.ctx, &codebuild.UpdateProjectInput{
+					Name: aws.String(name),
+					Tags: tags,
+				})
+				return err
+			})
+		}
+	}
+	log.Printf("Processed %d CodeBuild projects", len(names))
+}
+
+// mergeCodeBuildTags overlays the configured tags on a project's existing tags
+func (t *AWSResourceTagger) mergeCodeBuildTags(existing []codebuildtypes.Tag) []codebuildtypes.Tag {
+	tags := make([]codebuildtypes.Tag, 0, len(existing)+len(t.tags))
+	for _, tag := range existing {
+		if _, replaced := t.tags[aws.ToString(tag.Key)]; !replaced {
+			tags = append(tags, tag)
+		}
+	}
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, codebuildtypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
+
+// codeBuildTagMap flattens CodeBuild tags into a map
+func codeBuildTagMap(tags []codebuildtypes.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return m
+}
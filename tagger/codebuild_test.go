@@ -0,0 +1,119 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	codebuildtypes "github.com/aws/aws-sdk-go-v2/service/codebuild/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCodeBuildClient is a mock implementation of CodeBuildAPI
+type MockCodeBuildClient struct {
+	mock.Mock
+}
+
+func (m *MockCodeBuildClient) ListProjects(ctx context.Context, params *codebuild.ListProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.ListProjectsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*codebuild.ListProjectsOutput), args.Error(1)
+}
+
+func (m *MockCodeBuildClient) BatchGetProjects(ctx context.Context, params *codebuild.BatchGetProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.BatchGetProjectsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*codebuild.BatchGetProjectsOutput), args.Error(1)
+}
+
+func (m *MockCodeBuildClient) UpdateProject(ctx context.Context, params *codebuild.UpdateProjectInput, optFns ...func(*codebuild.Options)) (*codebuild.UpdateProjectOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*codebuild.UpdateProjectOutput), args.Error(1)
+}
+
+func TestTagCodeBuildProjects(t *testing.T) {
+	mockClient := new(MockCodeBuildClient)
+	mockClient.On("ListProjects", mock.Anything, &codebuild.ListProjectsInput{}).
+		Return(&codebuild.ListProjectsOutput{Projects: []string{"build"}, NextToken: aws.String("page-2")}, nil).Once()
+	mockClient.On("ListProjects", mock.Anything, &codebuild.ListProjectsInput{NextToken: aws.String("page-2")}).
+		Return(&codebuild.ListProjectsOutput{Projects: []string{"deploy"}}, nil).Once()
+	mockClient.On("BatchGetProjects", mock.Anything, &codebuild.BatchGetProjectsInput{Names: []string{"build", "deploy"}}).
+		Return(&codebuild.BatchGetProjectsOutput{
+			Projects: []codebuildtypes.Project{
+				{
+					Name: aws.String("build"),
+					Arn:  aws.String("arn:aws:codebuild:us-west-2:123456789012:project/build"),
+					Tags: []codebuildtypes.Tag{
+						{Key: aws.String("team"), Value: aws.String("platform")},
+						{Key: aws.String("Environment"), Value: aws.String("Old")},
+					},
+				},
+				{
+					Name: aws.String("deploy"),
+					Arn:  aws.String("arn:aws:codebuild:us-west-2:123456789012:project/deploy"),
+				},
+			},
+		}, nil).Once()
+	mockClient.On("UpdateProject", mock.Anything, mock.MatchedBy(func(input *codebuild.UpdateProjectInput) bool {
+		return aws.ToString(input.Name) == "build" && assert.ObjectsAreEqual(map[string]string{
+			"team":        "platform",
+			"Environment": "Test",
+			"Project":     "UnitTest",
+		}, codeBuildTagMap(input.Tags))
+	})).Return(&codebuild.UpdateProjectOutput{}, nil).Once()
+	mockClient.On("UpdateProject", mock.Anything, mock.MatchedBy(func(input *codebuild.UpdateProjectInput) bool {
+		return aws.ToString(input.Name) == "deploy"
+	})).Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagCodeBuildProjects(mockClient)
+
+	mockClient.AssertExpectations(t)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.Equal(t, "arn:aws:codebuild:us-west-2:123456789012:project/deploy", results[1].ARN)
+}
+
+func TestSkipTaggedCodeBuildProjectIssuesNoUpdate(t *testing.T) {
+	mockClient := new(MockCodeBuildClient)
+	mockClient.On("ListProjects", mock.Anything, &codebuild.ListProjectsInput{}).
+		Return(&codebuild.ListProjectsOutput{Projects: []string{"build"}}, nil).Once()
+	mockClient.On("BatchGetProjects", mock.Anything, mock.Anything).
+		Return(&codebuild.BatchGetProjectsOutput{
+			Projects: []codebuildtypes.Project{{
+				Name: aws.String("build"),
+				Arn:  aws.String("arn:aws:codebuild:us-west-2:123456789012:project/build"),
+				Tags: []codebuildtypes.Tag{
+					{Key: aws.String("Environment"), Value: aws.String("Test")},
+					{Key: aws.String("Project"), Value: aws.String("UnitTest")},
+				},
+			}},
+		}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	WithSkipTagged(true)(tagger)
+	tagger.tagCodeBuildProjects(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "UpdateProject", mock.Anything, mock.Anything)
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, int64(1), tagger.metrics.AlreadyTagged())
+}
@@ -0,0 +1,93 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
+	codepipelinetypes "github.com/aws/aws-sdk-go-v2/service/codepipeline/types"
+)
+
+// CodePipelineAPI interface for CodePipeline client operations
+type CodePipelineAPI interface {
+	ListPipelines(ctx context.Context, params *codepipeline.ListPipelinesInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListPipelinesOutput, error)
+	TagResource(ctx context.Context, params *codepipeline.TagResourceInput, optFns ...func(*codepipeline.Options)) (*codepipeline.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("Code", (*AWSResourceTagger).tagCodeResources)
+}
+
+// tagCodeResources is the main entry point for CodeBuild and CodePipeline
+func (t *AWSResourceTagger) tagCodeResources() error {
+	buildClient := codebuild.NewFromConfig(t.cfg)
+	pipelineClient := codepipeline.NewFromConfig(t.cfg)
+	t.tagCodeResourcesWithClients(buildClient, pipelineClient)
+	return nil
+}
+
+// tagCodeResourcesWithClients tags CodeBuild projects and CodePipeline pipelines
+func (t *AWSResourceTagger) tagCodeResourcesWithClients(buildClient CodeBuildAPI, pipelineClient CodePipelineAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging CodeBuild and CodePipeline resources...")
+	defer log.Println("Completed tagging CodeBuild and CodePipeline resources")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping CodeBuild and CodePipeline tagging")
+		return
+	}
+
+	t.tagCodeBuildProjects(buildClient)
+	t.tagCodePipelines(pipelineClient)
+}
+
+// tagCodePipelines tags every CodePipeline pipeline
+func (t *AWSResourceTagger) tagCodePipelines(client CodePipelineAPI) {
+	input := &codepipeline.ListPipelinesInput{}
+	for {
+		output, err := client.ListPipelines(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "CodePipeline Pipelines")
+			return
+		}
+
+		for _, pipeline := range output.Pipelines {
+			name := aws.ToString(pipeline.Name)
+			// ListPipelines returns names only
+			arn := t.buildARN(CodePipelinePipeline, name)
+			t.applyAndRecord(TagResult{
+				Service:      "CodePipeline",
+				ResourceType: CodePipelinePipeline.Type,
+				ResourceID:   name,
+				ARN:          arn,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &codepipeline.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.codePipelineTags(),
+				})
+				return err
+			})
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// codePipelineTags converts the configured tags to CodePipeline tags
+func (t *AWSResourceTagger) codePipelineTags() []codepipelinetypes.Tag {
+	tags := make([]codepipelinetypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, codepipelinetypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,80 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
+	codepipelinetypes "github.com/aws/aws-sdk-go-v2/service/codepipeline/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCodePipelineClient is a mock implementation of CodePipelineAPI
+type MockCodePipelineClient struct {
+	mock.Mock
+}
+
+func (m *MockCodePipelineClient) ListPipelines(ctx context.Context, params *codepipeline.ListPipelinesInput, optFns ...func(*codepipeline.Options)) (*codepipeline.ListPipelinesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*codepipeline.ListPipelinesOutput), args.Error(1)
+}
+
+func (m *MockCodePipelineClient) TagResource(ctx context.Context, params *codepipeline.TagResourceInput, optFns ...func(*codepipeline.Options)) (*codepipeline.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*codepipeline.TagResourceOutput), args.Error(1)
+}
+
+func TestTagCodePipelines(t *testing.T) {
+	mockClient := new(MockCodePipelineClient)
+	mockClient.On("ListPipelines", mock.Anything, &codepipeline.ListPipelinesInput{}).
+		Return(&codepipeline.ListPipelinesOutput{
+			Pipelines: []codepipelinetypes.PipelineSummary{{Name: aws.String("release")}},
+			NextToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("ListPipelines", mock.Anything, &codepipeline.ListPipelinesInput{NextToken: aws.String("page-2")}).
+		Return(&codepipeline.ListPipelinesOutput{
+			Pipelines: []codepipelinetypes.PipelineSummary{{Name: aws.String("hotfix")}},
+		}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *codepipeline.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:codepipeline:us-west-2:123456789012:release" && len(input.Tags) == 2
+	})).Return(&codepipeline.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *codepipeline.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:codepipeline:us-west-2:123456789012:hotfix"
+	})).Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagCodePipelines(mockClient)
+
+	mockClient.AssertExpectations(t)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, StatusFailed, results[1].Status)
+}
+
+func TestTagCodeResourcesListFailures(t *testing.T) {
+	buildClient := new(MockCodeBuildClient)
+	buildClient.On("ListProjects", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDeniedException")).Once()
+	pipelineClient := new(MockCodePipelineClient)
+	pipelineClient.On("ListPipelines", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := createTestTagger()
+	tagger.metrics = NewMetricsCollector()
+	tagger.tagCodeResourcesWithClients(buildClient, pipelineClient)
+
+	buildClient.AssertExpectations(t)
+	pipelineClient.AssertExpectations(t)
+	buildClient.AssertNotCalled(t, "BatchGetProjects", mock.Anything, mock.Anything)
+	assert.Equal(t, int64(2), tagger.metrics.APIErrors(), "a CodeBuild failure does not stop CodePipeline tagging")
+}
@@ -0,0 +1,139 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// defaultAutoConcurrency is the ceiling for --concurrency-auto when --concurrency is unset
+const defaultAutoConcurrency = 16
+
+// concurrencyLimiter caps the number of in-flight API calls shared by every
+// service client. In adaptive mode it follows AIMD: the limit starts at one,
+// grows by 1/limit per successful call and halves on each throttled call.
+type concurrencyLimiter struct {
+	mu       sync.Mutex
+	limit    float64
+	max      int
+	adaptive bool
+	inFlight int
+	// changed is closed and replaced whenever a slot may have become free
+	changed chan struct{}
+}
+
+// WithConcurrency caps the number of API calls in flight across all services; zero means no cap
+func WithConcurrency(n int) Option {
+	return func(t *AWSResourceTagger) {
+		t.concurrency = n
+	}
+}
+
+// WithAdaptiveConcurrency adjusts the API call concurrency to throttling, up to WithConcurrency
+func WithAdaptiveConcurrency(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.adaptiveConcurrency = enabled
+	}
+}
+
+// newConcurrencyLimiter builds a limiter with max slots, starting at one slot when adaptive
+func newConcurrencyLimiter(max int, adaptive bool) *concurrencyLimiter {
+	if max < 1 {
+		max = 1
+	}
+	l := &concurrencyLimiter{
+		limit:    float64(max),
+		max:      max,
+		adaptive: adaptive,
+		changed:  make(chan struct{}),
+	}
+	if adaptive {
+		l.limit = 1
+	}
+	return l
+}
+
+// Limit returns the number of calls currently allowed in flight
+func (l *concurrencyLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Acquire blocks until a slot is free or ctx is done
+func (l *concurrencyLimiter) Acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.inFlight < int(l.limit) {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release frees a slot and adapts the limit to the call's outcome
+func (l *concurrencyLimiter) Release(throttled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+
+	if l.adaptive {
+		previous := int(l.limit)
+		if throttled {
+			l.limit /= 2
+			if l.limit < 1 {
+				l.limit = 1
+			}
+		} else {
+			l.limit += 1 / l.limit
+			if l.limit > float64(l.max) {
+				l.limit = float64(l.max)
+			}
+		}
+		if current := int(l.limit); current != previous {
+			log.Printf("API concurrency adjusted from %d to %d", previous, current)
+		}
+	}
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// isThrottlingError reports whether err is an API error for a rate-limited call
+func isThrottlingError(err error) bool {
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		return throttlingErrorCodes[ae.ErrorCode()]
+	}
+	code, ok := v1ErrorCode(err)
+	return ok && throttlingErrorCodes[code]
+}
+
+// limitConcurrency returns an SDK API option that holds a limiter slot for
+// each attempt of every call, so retry backoff does not occupy a slot
+func limitConcurrency(l *concurrencyLimiter) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("LimitConcurrency",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				if err := l.Acquire(ctx); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+				out, metadata, err := next.HandleFinalize(ctx, in)
+				l.Release(isThrottlingError(err))
+				return out, metadata, err
+			}), middleware.After)
+	}
+}
@@ -0,0 +1,196 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completeCalls runs n sequential calls through the limiter with the given outcome
+func completeCalls(t *testing.T, l *concurrencyLimiter, n int, throttled bool) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, l.Acquire(context.Background()))
+		l.Release(throttled)
+	}
+}
+
+func TestAdaptiveConcurrencyBacksOffAndRecovers(t *testing.T) {
+	l := newConcurrencyLimiter(8, true)
+	assert.Equal(t, 1, l.Limit(), "adaptive mode starts low")
+
+	completeCalls(t, l, 40, false)
+	assert.Equal(t, 8, l.Limit(), "successes grow the limit up to the ceiling")
+
+	completeCalls(t, l, 1, true)
+	assert.Equal(t, 4, l.Limit(), "a throttle halves the limit")
+	completeCalls(t, l, 5, true)
+	assert.Equal(t, 1, l.Limit(), "the limit never drops below one")
+
+	completeCalls(t, l, 10, false)
+	assert.Greater(t, l.Limit(), 1, "the limit recovers once throttling stops")
+	completeCalls(t, l, 50, false)
+	assert.Equal(t, 8, l.Limit())
+}
+
+func TestFixedConcurrencyIgnoresThrottling(t *testing.T) {
+	l := newConcurrencyLimiter(4, false)
+	completeCalls(t, l, 3, true)
+	assert.Equal(t, 4, l.Limit())
+}
+
+func TestConcurrencyLimiterBlocksAtLimit(t *testing.T) {
+	l := newConcurrencyLimiter(1, false)
+	require.NoError(t, l.Acquire(context.Background()))
+
+	acquired := make(chan struct{})
+	go func() {
+		if l.Acquire(context.Background()) == nil {
+			close(acquired)
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second call acquired a slot while the limit was reached")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.Release(false)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second call was not released")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.Acquire(ctx), context.Canceled)
+}
+
+// countingHTTPClient answers every request after a short delay and records
+// the highest number of requests in flight at once
+type countingHTTPClient struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *countingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader("<TagResourceResponse><TagResourceResult/></TagResourceResponse>")),
+		Request:    req,
+	}, nil
+}
+
+func TestConcurrencyCapsInFlightCalls(t *testing.T) {
+	const limit = 2
+	httpClient := &countingHTTPClient{}
+	client := sns.NewFromConfig(aws.Config{
+		Region:           "us-west-2",
+		Credentials:      aws.AnonymousCredentials{},
+		HTTPClient:       httpClient,
+		RetryMaxAttempts: 1,
+		APIOptions:       []func(*middleware.Stack) error{limitConcurrency(newConcurrencyLimiter(limit, false))},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := client.TagResource(context.Background(), &sns.TagResourceInput{
+				ResourceArn: aws.String(fmt.Sprintf("arn:aws:sns:us-west-2:123456789012:topic-%d", i)),
+				Tags:        []snstypes.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, limit, httpClient.maxInFlight)
+}
+
+// throttlingHTTPClient throttles every request and counts them by query action
+type throttlingHTTPClient struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *throttlingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	action := strings.TrimPrefix(strings.Split(string(body), "&")[0], "Action=")
+	c.mu.Lock()
+	c.calls[action]++
+	c.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body: io.NopCloser(strings.NewReader("<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code>" +
+			"<Message>Rate exceeded</Message></Error><RequestId>r-1</RequestId></ErrorResponse>")),
+		Request: req,
+	}, nil
+}
+
+func TestTagWritesAreRetriedOnlyByTheTagger(t *testing.T) {
+	httpClient := &throttlingHTTPClient{calls: map[string]int{}}
+	client := sns.NewFromConfig(aws.Config{
+		Region:      "us-west-2",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  httpClient,
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = 3
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+			})
+		},
+		APIOptions: []func(*middleware.Stack) error{skipSDKRetries},
+	})
+	tagger := &AWSResourceTagger{
+		ctx:            context.Background(),
+		metrics:        NewMetricsCollector(),
+		retryAttempts:  4,
+		retryBaseDelay: time.Millisecond,
+	}
+
+	err := tagger.retryWithBackoff(func() error {
+		_, err := client.TagResource(context.Background(), &sns.TagResourceInput{
+			ResourceArn: aws.String("arn:aws:sns:us-west-2:123456789012:topic"),
+			Tags:        []snstypes.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		})
+		return err
+	})
+	assert.True(t, isThrottlingError(err))
+	assert.Equal(t, 4, httpClient.calls["TagResource"], "one request per tagger attempt")
+
+	_, err = client.ListTopics(context.Background(), &sns.ListTopicsInput{})
+	assert.Error(t, err)
+	assert.Equal(t, 3, httpClient.calls["ListTopics"], "other calls keep the SDK retryer")
+}
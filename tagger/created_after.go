@@ -0,0 +1,21 @@
+package tagger
+
+import "time"
+
+// WithCreatedAfter tags only resources created after since, for services whose
+// describe output carries a creation time. A zero time disables the filter.
+func WithCreatedAfter(since time.Time) Option {
+	return func(t *AWSResourceTagger) {
+		t.createdAfter = since
+	}
+}
+
+// skipCreatedBefore records a skip when --created-after is set and created is
+// not after it. An unknown creation time is treated as recent.
+func (t *AWSResourceTagger) skipCreatedBefore(target TagResult, created *time.Time) bool {
+	if t.createdAfter.IsZero() || created == nil || created.After(t.createdAfter) {
+		return false
+	}
+	t.skipResource(target, "created "+created.UTC().Format(time.RFC3339)+", before --created-after")
+	return true
+}
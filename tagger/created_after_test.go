@@ -0,0 +1,119 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	createdAfterCutoff = time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	createdBefore      = aws.Time(createdAfterCutoff.AddDate(0, -1, 0))
+	createdSince       = aws.Time(createdAfterCutoff.AddDate(0, 1, 0))
+)
+
+// assertSkippedAsOld checks that the only recorded skip is resourceID
+func assertSkippedAsOld(t *testing.T, tagger *AWSResourceTagger, resourceID string) {
+	t.Helper()
+	var skipped []string
+	for _, result := range tagger.Results() {
+		if result.Status == StatusSkipped {
+			skipped = append(skipped, result.ResourceID)
+			assert.Contains(t, result.Error, "before --created-after")
+		}
+	}
+	assert.Equal(t, []string{resourceID}, skipped)
+}
+
+func TestCreatedAfterSkipsOlderEC2Instances(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+			{InstanceId: aws.String("i-old"), LaunchTime: createdBefore},
+			{InstanceId: aws.String("i-new"), LaunchTime: createdSince},
+			{InstanceId: aws.String("i-unknown")},
+		}}},
+	}, nil)
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).Return(&ec2.DescribeVolumesOutput{}, nil)
+	expectNoSnapshotsOrImages(mockClient)
+	var tagged []string
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		tagged = append(tagged, args.Get(1).(*ec2.CreateTagsInput).Resources...)
+	}).Return(&ec2.CreateTagsOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	WithCreatedAfter(createdAfterCutoff)(tagger)
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	assert.Equal(t, []string{"i-new", "i-unknown"}, tagged)
+	assertSkippedAsOld(t, tagger, "i-old")
+}
+
+func TestCreatedAfterSkipsOlderRDSInstances(t *testing.T) {
+	mockClient := new(MockRDSClient)
+	mockClient.On("DescribeDBInstances", mock.Anything, mock.Anything).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db-old"),
+				DBInstanceArn:        aws.String("arn:aws:rds:us-west-2:123456789012:db:db-old"),
+				InstanceCreateTime:   createdBefore,
+			},
+			{
+				DBInstanceIdentifier: aws.String("db-new"),
+				DBInstanceArn:        aws.String("arn:aws:rds:us-west-2:123456789012:db:db-new"),
+				InstanceCreateTime:   createdSince,
+			},
+		},
+	}, nil)
+	mockClient.On("AddTagsToResource", mock.Anything, mock.MatchedBy(func(input *rds.AddTagsToResourceInput) bool {
+		return aws.ToString(input.ResourceName) == "arn:aws:rds:us-west-2:123456789012:db:db-new"
+	})).Return(&rds.AddTagsToResourceOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	WithCreatedAfter(createdAfterCutoff)(tagger)
+	tagger.tagDBInstancesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assertSkippedAsOld(t, tagger, "db-old")
+}
+
+func TestCreatedAfterSkipsOlderS3Buckets(t *testing.T) {
+	mockClient := new(MockS3Client)
+	mockClient.On("ListBuckets", mock.Anything, mock.Anything).Return(&s3.ListBucketsOutput{
+		Buckets: []s3types.Bucket{
+			{Name: aws.String("old-logs"), CreationDate: createdBefore},
+			{Name: aws.String("new-logs"), CreationDate: createdSince},
+		},
+	}, nil)
+	mockClient.On("GetBucketLocation", mock.Anything, &s3.GetBucketLocationInput{Bucket: aws.String("new-logs")}).
+		Return(&s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintUsWest2}, nil).Once()
+	mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).Return(&s3.GetBucketTaggingOutput{}, nil).Once()
+	mockClient.On("PutBucketTagging", mock.Anything, mock.Anything).Return(&s3.PutBucketTaggingOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		region:  "us-west-2",
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	WithCreatedAfter(createdAfterCutoff)(tagger)
+
+	metrics := tagger.tagS3BucketsWithClient(mockClient)
+
+	assert.Equal(t, 1, metrics.BucketsTagged)
+	assert.Equal(t, map[string]string{"new-logs": "us-west-2"}, mockClient.regions)
+	mockClient.AssertExpectations(t)
+	assertSkippedAsOld(t, tagger, "old-logs")
+}
@@ -0,0 +1,53 @@
+package tagger
+
+// DefaultResource describes a default or primary resource a service creates on
+// the account's behalf. These are skipped unless --skip-defaults=false.
+type DefaultResource struct {
+	Service      string
+	ResourceType string
+	Name         string
+	Description  string
+}
+
+// defaultResources is the registry of resources treated as defaults
+var defaultResources = []DefaultResource{
+	{
+		Service:      AthenaWorkgroup.Service,
+		ResourceType: AthenaWorkgroup.Type,
+		Name:         "primary",
+		Description:  "Athena primary workgroup created in every region",
+	},
+	{
+		Service:      "ec2",
+		ResourceType: "security-group",
+		Name:         "default",
+		Description:  "Default security group created with every VPC",
+	},
+}
+
+// DefaultResources returns a copy of the default resource registry
+func DefaultResources() []DefaultResource {
+	registry := make([]DefaultResource, len(defaultResources))
+	copy(registry, defaultResources)
+	return registry
+}
+
+// isDefaultResource reports whether the named resource is a registered default
+func isDefaultResource(service, resourceType, name string) bool {
+	for _, d := range defaultResources {
+		if d.Service == service && d.ResourceType == resourceType && d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDefaultResource records target as skipped when the named resource is a
+// registered default and --skip-defaults is on
+func (t *AWSResourceTagger) skipDefaultResource(target TagResult, service, resourceType, name string) bool {
+	if t.tagDefaults || !isDefaultResource(service, resourceType, name) {
+		return false
+	}
+	t.skipResource(target, "default resource")
+	return true
+}
@@ -0,0 +1,120 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenatypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIsDefaultResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		service      string
+		resourceType string
+		resourceName string
+		expected     bool
+	}{
+		{"Athena primary workgroup", "athena", "workgroup", "primary", true},
+		{"Athena custom workgroup", "athena", "workgroup", "analytics", false},
+		{"Default security group", "ec2", "security-group", "default", true},
+		{"Custom security group", "ec2", "security-group", "web", false},
+		{"Unregistered resource type", "glue", "database", "default", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isDefaultResource(tt.service, tt.resourceType, tt.resourceName))
+		})
+	}
+}
+
+func TestSkipDefaultResource(t *testing.T) {
+	primary := TagResult{Service: "athena", ResourceType: "workgroup", ResourceID: "primary"}
+	analytics := TagResult{Service: "athena", ResourceType: "workgroup", ResourceID: "analytics"}
+
+	skipping := &AWSResourceTagger{results: NewResultCollector()}
+	assert.True(t, skipping.skipDefaultResource(primary, "athena", "workgroup", "primary"))
+	assert.False(t, skipping.skipDefaultResource(analytics, "athena", "workgroup", "analytics"))
+	results := skipping.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "primary", results[0].ResourceID)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, "default resource", results[0].Error)
+
+	tagging := &AWSResourceTagger{results: NewResultCollector()}
+	WithSkipDefaults(false)(tagging)
+	assert.False(t, tagging.skipDefaultResource(primary, "athena", "workgroup", "primary"))
+	assert.False(t, tagging.skipDefaultResource(TagResult{Service: "VPC", ResourceType: "security-group", ResourceID: "sg-1"},
+		"ec2", "security-group", "default"))
+	assert.Empty(t, tagging.Results())
+}
+
+func TestTagAthenaWorkgroupsSkipDefaults(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectPrimary bool
+	}{
+		{
+			name:          "Primary workgroup skipped by default",
+			expectPrimary: false,
+		},
+		{
+			name:          "Primary workgroup tagged with --skip-defaults=false",
+			opts:          []Option{WithSkipDefaults(false)},
+			expectPrimary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			tagger := &AWSResourceTagger{
+				ctx:       context.Background(),
+				accountID: "123456789012",
+				region:    "us-west-2",
+				tags:      map[string]string{"Environment": "Test"},
+			}
+			for _, opt := range tt.opts {
+				opt(tagger)
+			}
+
+			mockClient := new(MockAthenaClient)
+			mockClient.On("ListWorkGroups", mock.Anything, mock.Anything).
+				Return(&athena.ListWorkGroupsOutput{
+					WorkGroups: []athenatypes.WorkGroupSummary{
+						{Name: aws.String("primary")},
+						{Name: aws.String("analytics")},
+					},
+				}, nil)
+			mockClient.On("TagResource", mock.Anything, mock.Anything).
+				Return(&athena.TagResourceOutput{}, nil)
+
+			err := tagger.tagAthenaWorkgroups(mockClient)
+			assert.NoError(t, err)
+
+			primaryTagged := func(input *athena.TagResourceInput) bool {
+				return aws.ToString(input.ResourceARN) == "arn:aws:athena:us-west-2:123456789012:workgroup/primary"
+			}
+			if tt.expectPrimary {
+				mockClient.AssertCalled(t, "TagResource", mock.Anything, mock.MatchedBy(primaryTagged))
+				mockClient.AssertNumberOfCalls(t, "TagResource", 2)
+			} else {
+				mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.MatchedBy(primaryTagged))
+				mockClient.AssertNumberOfCalls(t, "TagResource", 1)
+				assert.Contains(t, logBuffer.String(), "Skipping athena workgroup arn:aws:athena:us-west-2:123456789012:workgroup/primary: default resource")
+			}
+		})
+	}
+}
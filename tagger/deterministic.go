@@ -0,0 +1,49 @@
+package tagger
+
+import (
+	"sort"
+)
+
+// WithDeterministic processes tag keys and reports results in sorted order so
+// repeated runs produce identical output
+func WithDeterministic(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.deterministic = enabled
+	}
+}
+
+// orderedTagKeys returns the configured tag keys, sorted under --deterministic
+func (t *AWSResourceTagger) orderedTagKeys() []string {
+	keys := make([]string, 0, len(t.tags))
+	for k := range t.tags {
+		keys = append(keys, k)
+	}
+	if t.deterministic {
+		sort.Strings(keys)
+	}
+	return keys
+}
+
+// sortedTagKeys returns the keys of tags in sorted order
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortResults orders results by service, resource type and resource identifier
+func sortResults(results []TagResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		return resultIdentifier(a) < resultIdentifier(b)
+	})
+}
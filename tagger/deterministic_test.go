@@ -0,0 +1,59 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicOrdering(t *testing.T) {
+	tags := map[string]string{
+		"map-migrated": "mig12345",
+		"Environment":  "Prod",
+		"Owner":        "platform",
+		"CostCenter":   "42",
+		"Project":      "Atlas",
+	}
+	results := []TagResult{
+		{Service: "RDS", ResourceType: "db", ResourceID: "orders", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "volume", ResourceID: "vol-2", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "instance", ResourceID: "i-2", ARN: "arn:aws:ec2:us-west-2:123456789012:instance/i-2", Status: StatusFailed},
+		{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", ARN: "arn:aws:ec2:us-west-2:123456789012:instance/i-1", Status: StatusFailed},
+	}
+
+	// run simulates one tagging pass, recording results in the given order
+	run := func(order []int) ([]string, []TagResult, []string) {
+		tagger := &AWSResourceTagger{
+			ctx:     context.Background(),
+			tags:    tags,
+			results: NewResultCollector(),
+		}
+		WithDeterministic(true)(tagger)
+		for _, i := range order {
+			tagger.recordResult(results[i])
+		}
+
+		var keys []string
+		for _, tag := range tagger.convertToEC2Tags() {
+			keys = append(keys, aws.ToString(tag.Key))
+		}
+		return keys, tagger.buildReport().Results, tagger.failedARNs()
+	}
+
+	firstKeys, firstResults, firstFailed := run([]int{0, 1, 2, 3})
+	for _, order := range [][]int{{3, 2, 1, 0}, {1, 3, 0, 2}} {
+		keys, report, failed := run(order)
+		assert.Equal(t, firstKeys, keys)
+		assert.Equal(t, firstResults, report)
+		assert.Equal(t, firstFailed, failed)
+	}
+
+	assert.Equal(t, []string{"CostCenter", "Environment", "Owner", "Project", "map-migrated"}, firstKeys)
+	var ids []string
+	for _, r := range firstResults {
+		ids = append(ids, r.ResourceID)
+	}
+	assert.Equal(t, []string{"i-1", "i-2", "vol-2", "orders"}, ids)
+}
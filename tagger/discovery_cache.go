@@ -0,0 +1,77 @@
+package tagger
+
+import (
+	"log"
+	"sync"
+)
+
+// discoveryCache holds resource listings keyed by service so repeated phases
+// of a run, such as the two passes of --assert-idempotent, describe once.
+// Only listings are cached; tag writes never change them, and failed
+// discoveries are not stored.
+type discoveryCache struct {
+	mu      sync.Mutex
+	entries map[string]interface{}
+}
+
+// WithDiscoveryCache reuses resource listings across phases of a single run
+func WithDiscoveryCache(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		if enabled {
+			t.discovery = &discoveryCache{entries: make(map[string]interface{})}
+		} else {
+			t.discovery = nil
+		}
+	}
+}
+
+// InvalidateDiscoveryCache drops the cached listings for keys, or all listings when none are given
+func (t *AWSResourceTagger) InvalidateDiscoveryCache(keys ...string) {
+	c := t.discovery
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(keys) == 0 {
+		c.entries = make(map[string]interface{})
+		return
+	}
+	for _, key := range keys {
+		delete(c.entries, t.discoveryKey(key))
+	}
+}
+
+// cachedDiscovery returns the listing cached under key, running discover on a
+// miss. Without a cache discover always runs.
+func cachedDiscovery[T any](t *AWSResourceTagger, key string, discover func() (T, error)) (T, error) {
+	c := t.discovery
+	if c == nil {
+		return discover()
+	}
+	key = t.discoveryKey(key)
+
+	c.mu.Lock()
+	if cached, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		log.Printf("Using cached discovery for %s", key)
+		return cached.(T), nil
+	}
+	c.mu.Unlock()
+
+	result, err := discover()
+	if err != nil {
+		return result, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = result
+	c.mu.Unlock()
+	return result, nil
+}
+
+// discoveryKey scopes a cache key to the current region so multi-region runs
+// never reuse another region's listing
+func (t *AWSResourceTagger) discoveryKey(key string) string {
+	return t.region + "/" + key
+}
@@ -0,0 +1,87 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiscoveryCacheAcrossPhases(t *testing.T) {
+	tests := []struct {
+		name          string
+		cache         bool
+		expectListing int
+	}{
+		{name: "Cached listing is reused by the tag phase", cache: true, expectListing: 1},
+		{name: "Without a cache every phase lists", cache: false, expectListing: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockS3Client)
+			mockClient.On("ListBuckets", mock.Anything, mock.Anything).Return(&s3.ListBucketsOutput{
+				Buckets: []s3types.Bucket{{Name: aws.String("logs")}},
+			}, nil)
+			mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).Return(&s3.GetBucketLocationOutput{}, nil)
+			mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).Return(&s3.GetBucketTaggingOutput{}, nil)
+			mockClient.On("PutBucketTagging", mock.Anything, mock.Anything).Return(&s3.PutBucketTaggingOutput{}, nil)
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"env": "prod"},
+			}
+			WithDiscoveryCache(tt.cache)(tagger)
+
+			// Count phase as a dry run, then the tag phase
+			tagger.dryRun = true
+			counted := tagger.tagS3BucketsWithClient(mockClient)
+			tagger.dryRun = false
+			tagged := tagger.tagS3BucketsWithClient(mockClient)
+
+			assert.Equal(t, 1, counted.BucketsWouldTag)
+			assert.Equal(t, 1, tagged.BucketsTagged)
+			mockClient.AssertNumberOfCalls(t, "ListBuckets", tt.expectListing)
+			mockClient.AssertNumberOfCalls(t, "PutBucketTagging", 1)
+		})
+	}
+}
+
+func TestDiscoveryCacheInvalidation(t *testing.T) {
+	tagger := &AWSResourceTagger{}
+	WithDiscoveryCache(true)(tagger)
+
+	calls := 0
+	discover := func() ([]string, error) {
+		calls++
+		return []string{"a"}, nil
+	}
+
+	_, _ = cachedDiscovery(tagger, "svc", discover)
+	_, _ = cachedDiscovery(tagger, "svc", discover)
+	assert.Equal(t, 1, calls)
+
+	tagger.InvalidateDiscoveryCache("svc")
+	_, _ = cachedDiscovery(tagger, "svc", discover)
+	assert.Equal(t, 2, calls)
+
+	tagger.InvalidateDiscoveryCache()
+	_, _ = cachedDiscovery(tagger, "svc", discover)
+	assert.Equal(t, 3, calls)
+
+	// Failed discoveries are retried rather than cached
+	failures := 0
+	failing := func() ([]string, error) {
+		failures++
+		return nil, errors.New("throttled")
+	}
+	_, err := cachedDiscovery(tagger, "broken", failing)
+	assert.Error(t, err)
+	_, _ = cachedDiscovery(tagger, "broken", failing)
+	assert.Equal(t, 2, failures)
+}
@@ -0,0 +1,73 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// WithDryRun discovers resources and logs the tags it would write without calling any tag API
+func WithDryRun(dryRun bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.dryRun = dryRun
+	}
+}
+
+// dryRunSkip logs the tag write a dry run would make and reports whether the
+// caller must skip the actual tag call
+func (t *AWSResourceTagger) dryRunSkip(resource string) bool {
+	if !t.dryRun {
+		return false
+	}
+	log.Printf("[DRY-RUN] would tag %s with %s", resource, formatTagMap(t.tags))
+	atomic.AddInt64(&t.dryRunCount, 1)
+	return true
+}
+
+// dryRunAccountID stands in for the account ID when a dry run cannot reach STS
+const dryRunAccountID = "unknown"
+
+// checkCredentials reports whether credentials can be retrieved from the
+// config without calling STS, warning when none are configured. Dry runs use
+// it in place of the GetCallerIdentity session check so they work offline.
+func (t *AWSResourceTagger) checkCredentials() bool {
+	if t.cfg.Credentials == nil {
+		log.Println("Warning: no AWS credentials configured; a real run would fail")
+		return false
+	}
+	creds, err := t.cfg.Credentials.Retrieve(t.ctx)
+	if err != nil || !creds.HasKeys() {
+		log.Printf("Warning: unable to retrieve AWS credentials; a real run would fail: %v", err)
+		return false
+	}
+	return true
+}
+
+// countTagged increments wouldTag on a dry run and tagged otherwise
+func (t *AWSResourceTagger) countTagged(tagged, wouldTag *int32) {
+	if t.dryRun {
+		atomic.AddInt32(wouldTag, 1)
+		return
+	}
+	atomic.AddInt32(tagged, 1)
+}
+
+// taggedStatus is the result status of a successful tag call, or would_tag on a dry run
+func (t *AWSResourceTagger) taggedStatus() string {
+	if t.dryRun {
+		return StatusWouldTag
+	}
+	return StatusTagged
+}
+
+// formatTagMap renders a tag map like formatTags, with keys sorted
+func formatTagMap(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ", "))
+}
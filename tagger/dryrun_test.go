@@ -0,0 +1,141 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDryRunS3Buckets(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	mockClient := new(MockS3Client)
+	mockClient.On("ListBuckets", mock.Anything, mock.Anything).Return(&s3.ListBucketsOutput{
+		Buckets: []s3types.Bucket{{Name: aws.String("logs")}, {Name: aws.String("data")}},
+	}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"map-migrated": "mig123", "env": "prod"},
+		results: NewResultCollector(),
+	}
+	WithDryRun(true)(tagger)
+
+	metrics := tagger.tagS3BucketsWithClient(mockClient)
+
+	mockClient.AssertNotCalled(t, "PutBucketTagging", mock.Anything, mock.Anything)
+	assert.Equal(t, 2, metrics.BucketsFound)
+	assert.Equal(t, 2, metrics.BucketsWouldTag)
+	assert.Equal(t, 0, metrics.BucketsTagged)
+	assert.Contains(t, logBuffer.String(), "[DRY-RUN] would tag arn:aws:s3:::logs with {env: prod, map-migrated: mig123}")
+
+	report := tagger.buildReport()
+	assert.Equal(t, 2, report.Summary.WouldTag)
+	assert.Equal(t, 0, report.Summary.Tagged)
+	for _, r := range report.Results {
+		assert.Equal(t, StatusWouldTag, r.Status)
+	}
+}
+
+func TestDryRunGlueResources(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("sales")}},
+	}, nil)
+	mockClient.On("GetConnections", mock.Anything, mock.Anything).Return(&glue.GetConnectionsOutput{
+		ConnectionList: []gluetypes.Connection{{Name: aws.String("jdbc")}},
+	}, nil)
+
+	tagger := createTestTagger()
+	WithDryRun(true)(tagger)
+	metrics := &GlueMetrics{}
+
+	tagger.tagGlueDatabases(mockClient, metrics)
+	tagger.tagGlueConnections(mockClient, metrics)
+
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	assert.Equal(t, int32(1), metrics.DatabasesFound)
+	assert.Equal(t, int32(1), metrics.DatabasesWouldTag)
+	assert.Equal(t, int32(0), metrics.DatabasesTagged)
+	assert.Equal(t, int32(1), metrics.ConnectionsWouldTag)
+	assert.Equal(t, int32(0), metrics.ConnectionsTagged)
+}
+
+func TestDryRunResourceBatch(t *testing.T) {
+	mockClient := new(MockResourceGroupsTaggingClient)
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+		dryRun:  true,
+	}
+
+	tagger.tagARNsWithClient(mockClient, []string{"arn:aws:sns:us-west-2:123456789012:alerts"})
+
+	mockClient.AssertNotCalled(t, "TagResources", mock.Anything, mock.Anything)
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusWouldTag, results[0].Status)
+}
+
+func TestDryRunValidatesCredentialPresence(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials aws.CredentialsProvider
+		expectWarn  bool
+	}{
+		{
+			name: "Stub credentials pass",
+			credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+			}),
+		},
+		{
+			name:       "No credentials provider warns",
+			expectWarn: true,
+		},
+		{
+			name: "Failing credentials provider warns",
+			credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{}, errors.New("no EC2 IMDS role found")
+			}),
+			expectWarn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			tagger := &AWSResourceTagger{
+				ctx: context.Background(),
+				cfg: aws.Config{Credentials: tt.credentials},
+			}
+			WithDryRun(true)(tagger)
+
+			// A dry run never calls STS, so validation succeeds offline either way
+			assert.NoError(t, tagger.validateSSOSession())
+			assert.Equal(t, !tt.expectWarn, tagger.checkCredentials())
+			if tt.expectWarn {
+				assert.Contains(t, logBuffer.String(), "Warning:")
+			} else {
+				assert.NotContains(t, logBuffer.String(), "Warning:")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBAPI interface for DynamoDB client operations
+type DynamoDBAPI interface {
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	TagResource(ctx context.Context, params *dynamodb.TagResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("DynamoDB", (*AWSResourceTagger).tagDynamoDBResources)
+}
+
+// tagDynamoDBResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagDynamoDBResources() error {
+	client := dynamodb.NewFromConfig(t.cfg)
+	t.tagDynamoDBResourcesWithClient(client)
+	return nil
+}
+
+// tagDynamoDBResourcesWithClient tags every DynamoDB table in the region
+func (t *AWSResourceTagger) tagDynamoDBResourcesWithClient(client DynamoDBAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging DynamoDB tables...")
+	defer log.Println("Completed tagging DynamoDB tables")
+
+	input := &dynamodb.ListTablesInput{}
+	for {
+		output, err := client.ListTables(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "DynamoDB Tables")
+			return
+		}
+
+		for _, tableName := range output.TableNames {
+			// TagResource only accepts the table ARN, which ListTables does not return
+			arn := t.buildARN(DynamoDBTable, tableName)
+			t.applyAndRecord(TagResult{
+				Service:      "DynamoDB",
+				ResourceType: DynamoDBTable.Type,
+				ResourceID:   tableName,
+				ARN:          arn,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &dynamodb.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.dynamoDBTags(),
+				})
+				return err
+			})
+		}
+
+		if output.LastEvaluatedTableName == nil {
+			break
+		}
+		input.ExclusiveStartTableName = output.LastEvaluatedTableName
+	}
+}
+
+// dynamoDBTags converts the configured tags to DynamoDB tags
+func (t *AWSResourceTagger) dynamoDBTags() []dynamodbtypes.Tag {
+	tags := make([]dynamodbtypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, dynamodbtypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
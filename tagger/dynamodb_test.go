@@ -0,0 +1,89 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDynamoDBClient is a mock implementation of DynamoDBAPI
+type MockDynamoDBClient struct {
+	mock.Mock
+}
+
+func (m *MockDynamoDBClient) ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.ListTablesOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) TagResource(ctx context.Context, params *dynamodb.TagResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.TagResourceOutput), args.Error(1)
+}
+
+// dynamoDBTableARN matches a TagResource call for the named table
+func dynamoDBTableARN(name string) interface{} {
+	return mock.MatchedBy(func(input *dynamodb.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:dynamodb:us-west-2:123456789012:table/"+name
+	})
+}
+
+func TestTagDynamoDBTables(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	mockClient.On("ListTables", mock.Anything, &dynamodb.ListTablesInput{}).
+		Return(&dynamodb.ListTablesOutput{
+			TableNames:             []string{"orders", "customers"},
+			LastEvaluatedTableName: aws.String("customers"),
+		}, nil).Once()
+	mockClient.On("ListTables", mock.Anything, &dynamodb.ListTablesInput{ExclusiveStartTableName: aws.String("customers")}).
+		Return(&dynamodb.ListTablesOutput{
+			TableNames: []string{"sessions"},
+		}, nil).Once()
+	for _, name := range []string{"orders", "customers", "sessions"} {
+		mockClient.On("TagResource", mock.Anything, dynamoDBTableARN(name)).
+			Return(&dynamodb.TagResourceOutput{}, nil).Once()
+	}
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagDynamoDBResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TagResource", 3)
+	for _, result := range tagger.Results() {
+		assert.Equal(t, StatusTagged, result.Status)
+	}
+}
+
+func TestTagDynamoDBTablesAccessDenied(t *testing.T) {
+	mockClient := new(MockDynamoDBClient)
+	mockClient.On("ListTables", mock.Anything, mock.Anything).
+		Return(&dynamodb.ListTablesOutput{TableNames: []string{"locked", "open"}}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, dynamoDBTableARN("locked")).
+		Return(nil, &mockAPIError{code: "AccessDenied", message: "not authorized"}).Once()
+	mockClient.On("TagResource", mock.Anything, dynamoDBTableARN("open")).
+		Return(&dynamodb.TagResourceOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	tagger.tagDynamoDBResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, int64(1), tagger.metrics.APIErrors(), "the failure is counted by handleError")
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Equal(t, StatusTagged, results[1].Status)
+}
@@ -2,29 +2,39 @@ package tagger
 
 import (
 	"context"
+	"fmt"
 	"log"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 // EC2API interface for EC2 client operations
 type EC2API interface {
 	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
 	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
 }
 
+func init() {
+	registerService("EC2", (*AWSResourceTagger).tagEC2Resources)
+}
+
 // tagEC2Resources tags EC2 instances and related resources
-func (t *AWSResourceTagger) tagEC2Resources() {
+func (t *AWSResourceTagger) tagEC2Resources() error {
 	client := ec2.NewFromConfig(t.cfg)
 	t.tagEC2ResourcesWithClient(client)
+	return nil
 }
 
 // tagEC2ResourcesWithClient tags EC2 instances and related resources using the provided client
 func (t *AWSResourceTagger) tagEC2ResourcesWithClient(client EC2API) {
-	var instanceIds []string
+	var instances []types.Instance
 
-	// Describe EC2 instances and collect instance IDs
+	// Describe EC2 instances and collect them
 	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{})
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(t.ctx)
@@ -35,25 +45,32 @@ func (t *AWSResourceTagger) tagEC2ResourcesWithClient(client EC2API) {
 
 		for _, reservation := range page.Reservations {
 			for _, instance := range reservation.Instances {
-				instanceIds = append(instanceIds, *instance.InstanceId)
+				instances = append(instances, instance)
 			}
 		}
 	}
 
 	// Tag the collected EC2 instances
-	for _, instanceID := range instanceIds {
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{instanceID},
-			Tags:      t.awsTags,
-		})
-		if err != nil {
-			t.handleError(err, instanceID, "EC2")
-			return // Stop if any instance tagging fails.
+	for _, instance := range instances {
+		instanceID := *instance.InstanceId
+		target := TagResult{Service: "EC2", ResourceType: "instance", ResourceID: instanceID}
+		if t.skipCreatedBefore(target, instance.LaunchTime) {
+			continue
+		}
+		if t.skipOverTagLimit(target, ec2TagKeys(instance.Tags), ec2TagKeys(t.ec2TagsFor(instance.Tags))) {
+			continue
 		}
-		log.Printf("Tagged EC2 instance: %s", instanceID)
+		target.Tags = t.tagsFor(ec2TagKeys(instance.Tags))
+		t.applyAndRecord(target, func() error {
+			_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
+				Resources: []string{instanceID},
+				Tags:      t.ec2TagsFor(instance.Tags),
+			})
+			return err
+		})
 	}
 	
-	// Only proceed to volume tagging if instance processing was successful
+	// Tag EBS volumes
 	volPaginator := ec2.NewDescribeVolumesPaginator(client, &ec2.DescribeVolumesInput{})
 	for volPaginator.HasMorePages() {
 		page, err := volPaginator.NextPage(t.ctx)
@@ -63,15 +80,117 @@ func (t *AWSResourceTagger) tagEC2ResourcesWithClient(client EC2API) {
 		}
 
 		for _, volume := range page.Volumes {
-			_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-				Resources: []string{*volume.VolumeId},
-				Tags:      t.awsTags,
+			target := TagResult{Service: "EC2", ResourceType: "volume", ResourceID: *volume.VolumeId}
+			if t.skipOverTagLimit(target, ec2TagKeys(volume.Tags), ec2TagKeys(t.ec2TagsFor(volume.Tags))) {
+				continue
+			}
+			target.Tags = t.tagsFor(ec2TagKeys(volume.Tags))
+			t.applyAndRecord(target, func() error {
+				_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
+					Resources: []string{*volume.VolumeId},
+					Tags:      t.ec2TagsFor(volume.Tags),
+				})
+				return err
 			})
-			if err != nil {
-				t.handleError(err, *volume.VolumeId, "EBS")
-				continue // Safe to continue to the next volume if tagging fails.
+		}
+	}
+
+	t.tagEC2SnapshotsWithClient(client)
+	t.tagEC2ImagesWithClient(client)
+}
+
+// tagEC2SnapshotsWithClient tags EBS snapshots owned by the account
+func (t *AWSResourceTagger) tagEC2SnapshotsWithClient(client EC2API) {
+	// Only owned snapshots: public and shared ones cannot be tagged by this
+	// account, so --owned-only has nothing left to filter here
+	paginator := ec2.NewDescribeSnapshotsPaginator(client, &ec2.DescribeSnapshotsInput{
+		OwnerIds: []string{"self"},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(t.ctx)
+		if err != nil {
+			log.Printf("Error describing EBS snapshots: %v", err)
+			return
+		}
+
+		for _, snapshot := range page.Snapshots {
+			snapshotID := *snapshot.SnapshotId
+			target := TagResult{Service: "EC2", ResourceType: "snapshot", ResourceID: snapshotID}
+			if t.skipOverTagLimit(target, ec2TagKeys(snapshot.Tags), ec2TagKeys(t.ec2TagsFor(snapshot.Tags))) {
+				continue
 			}
-			log.Printf("Tagged EBS volume: %s", *volume.VolumeId)
+			target.Tags = t.tagsFor(ec2TagKeys(snapshot.Tags))
+			t.applyAndRecord(target, func() error {
+				_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
+					Resources: []string{snapshotID},
+					Tags:      t.ec2TagsFor(snapshot.Tags),
+				})
+				return err
+			})
+		}
+	}
+}
+
+// untaggableImageStates are AMI states that reject or make tagging pointless
+var untaggableImageStates = map[types.ImageState]bool{
+	types.ImageStatePending:      true,
+	types.ImageStateFailed:       true,
+	types.ImageStateInvalid:      true,
+	types.ImageStateError:        true,
+	types.ImageStateDeregistered: true,
+}
+
+// skipUntaggableImage records a skip for AMIs that are not in a taggable state
+// or that another account owns, which Owners: self can still return for shared AMIs
+func (t *AWSResourceTagger) skipUntaggableImage(target TagResult, image types.Image) bool {
+	if untaggableImageStates[image.State] {
+		t.skipResource(target, fmt.Sprintf("image is %s", image.State))
+		return true
+	}
+	if owner := aws.ToString(image.OwnerId); owner != "" && t.accountID != "" && owner != t.accountID {
+		t.skipResource(target, "owned by account "+owner)
+		return true
+	}
+	return false
+}
+
+// tagEC2ImagesWithClient tags AMIs owned by the account
+func (t *AWSResourceTagger) tagEC2ImagesWithClient(client EC2API) {
+	skipped := 0
+	defer func() {
+		if skipped > 0 {
+			log.Printf("Skipped %d AMIs that are not available or not owned", skipped)
+		}
+	}()
+
+	paginator := ec2.NewDescribeImagesPaginator(client, &ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(t.ctx)
+		if err != nil {
+			log.Printf("Error describing AMIs: %v", err)
+			return
+		}
+
+		for _, image := range page.Images {
+			imageID := *image.ImageId
+			target := TagResult{Service: "EC2", ResourceType: "image", ResourceID: imageID}
+			if t.skipUntaggableImage(target, image) {
+				skipped++
+				continue
+			}
+			if t.skipOverTagLimit(target, ec2TagKeys(image.Tags), ec2TagKeys(t.ec2TagsFor(image.Tags))) {
+				continue
+			}
+			target.Tags = t.tagsFor(ec2TagKeys(image.Tags))
+			t.applyAndRecord(target, func() error {
+				_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
+					Resources: []string{imageID},
+					Tags:      t.ec2TagsFor(image.Tags),
+				})
+				return err
+			})
 		}
 	}
 }
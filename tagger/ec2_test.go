@@ -3,11 +3,13 @@ package tagger
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -32,6 +34,28 @@ func (m *MockEC2Client) DescribeVolumes(ctx context.Context, params *ec2.Describ
 	return args.Get(0).(*ec2.DescribeVolumesOutput), args.Error(1)
 }
 
+func (m *MockEC2Client) DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeSnapshotsOutput), args.Error(1)
+}
+
+func (m *MockEC2Client) DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeImagesOutput), args.Error(1)
+}
+
+// expectNoSnapshotsOrImages mocks empty snapshot and AMI listings
+func expectNoSnapshotsOrImages(m *MockEC2Client) {
+	m.On("DescribeSnapshots", mock.Anything, mock.Anything).Return(&ec2.DescribeSnapshotsOutput{}, nil).Once()
+	m.On("DescribeImages", mock.Anything, mock.Anything).Return(&ec2.DescribeImagesOutput{}, nil).Once()
+}
+
 func (m *MockEC2Client) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -78,6 +102,8 @@ func TestTagEC2Resources(t *testing.T) {
 				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
 					return input.Resources[0] == "vol-1234567890abcdef0"
 				})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+
+				expectNoSnapshotsOrImages(m)
 			},
 			expectedErr: false,
 		},
@@ -114,3 +140,204 @@ func TestTagEC2Resources(t *testing.T) {
 		})
 	}
 }
+
+func TestTagEC2ResourcesTagLimit(t *testing.T) {
+	var fullTags []ec2types.Tag
+	for i := 0; i < 3; i++ {
+		fullTags = append(fullTags, ec2types.Tag{Key: aws.String(fmt.Sprintf("existing-%d", i)), Value: aws.String("v")})
+	}
+
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{InstanceId: aws.String("i-full"), Tags: fullTags},
+						{InstanceId: aws.String("i-ok")},
+					},
+				},
+			},
+		}, nil).Once()
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeVolumesOutput{
+			Volumes: []ec2types.Volume{
+				{VolumeId: aws.String("vol-full"), Tags: fullTags},
+			},
+		}, nil).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "i-ok"
+	})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+	expectNoSnapshotsOrImages(mockClient)
+
+	tagger := &AWSResourceTagger{
+		ctx:        context.Background(),
+		awsTags:    []ec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("Test")}},
+		results:    NewResultCollector(),
+		maxTagKeys: 3,
+	}
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "CreateTags", 1)
+
+	var skipped []string
+	for _, r := range tagger.Results() {
+		if r.ResourceID == "i-ok" {
+			assert.Equal(t, StatusTagged, r.Status)
+			continue
+		}
+		assert.Equal(t, StatusSkipped, r.Status)
+		assert.Equal(t, "merged tags would have 4 keys, over the 3-key limit", r.Error)
+		skipped = append(skipped, r.ResourceID)
+	}
+	assert.Equal(t, []string{"i-full", "vol-full"}, skipped)
+}
+
+func TestTagEC2ResourcesContinuesAfterInstanceFailure(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{InstanceId: aws.String("i-denied")},
+						{InstanceId: aws.String("i-ok")},
+					},
+				},
+			},
+		}, nil).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "i-denied"
+	})).Return(nil, errors.New("UnauthorizedOperation")).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "i-ok"
+	})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeVolumesOutput{
+			Volumes: []ec2types.Volume{{VolumeId: aws.String("vol-1")}},
+		}, nil).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "vol-1"
+	})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+	expectNoSnapshotsOrImages(mockClient)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		awsTags: []ec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("Test")}},
+	}
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "CreateTags", 3)
+}
+
+func TestTagEC2SnapshotsAndImages(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeSnapshots", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeSnapshotsInput) bool {
+		return len(input.OwnerIds) == 1 && input.OwnerIds[0] == "self"
+	})).Return(&ec2.DescribeSnapshotsOutput{
+		Snapshots: []ec2types.Snapshot{
+			{SnapshotId: aws.String("snap-denied")},
+			{SnapshotId: aws.String("snap-ok")},
+		},
+	}, nil).Once()
+	mockClient.On("DescribeImages", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeImagesInput) bool {
+		return len(input.Owners) == 1 && input.Owners[0] == "self"
+	})).Return(&ec2.DescribeImagesOutput{
+		Images: []ec2types.Image{
+			{ImageId: aws.String("ami-denied")},
+			{ImageId: aws.String("ami-ok")},
+		},
+	}, nil).Once()
+	for _, id := range []string{"snap-denied", "ami-denied"} {
+		id := id
+		mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+			return input.Resources[0] == id
+		})).Return(nil, errors.New("UnauthorizedOperation")).Once()
+	}
+	for _, id := range []string{"snap-ok", "ami-ok"} {
+		id := id
+		mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+			return input.Resources[0] == id
+		})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+	}
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		awsTags: []ec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("Test")}},
+		metrics: NewMetricsCollector(),
+	}
+	tagger.tagEC2SnapshotsWithClient(mockClient)
+	tagger.tagEC2ImagesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "CreateTags", 4)
+	assert.Equal(t, int64(2), tagger.metrics.APIErrors())
+}
+
+func TestTagEC2ImagesSkipsUntaggableImages(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeImages", mock.Anything, mock.Anything).Return(&ec2.DescribeImagesOutput{
+		Images: []ec2types.Image{
+			{ImageId: aws.String("ami-ready"), State: ec2types.ImageStateAvailable, OwnerId: aws.String("123456789012")},
+			{ImageId: aws.String("ami-pending"), State: ec2types.ImageStatePending, OwnerId: aws.String("123456789012")},
+			{ImageId: aws.String("ami-failed"), State: ec2types.ImageStateFailed, OwnerId: aws.String("123456789012")},
+			{ImageId: aws.String("ami-shared"), State: ec2types.ImageStateAvailable, OwnerId: aws.String("210987654321")},
+		},
+	}, nil).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "ami-ready"
+	})).Return(&ec2.CreateTagsOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagEC2ImagesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "CreateTags", 1)
+
+	skipped := map[string]string{}
+	for _, result := range tagger.Results() {
+		if result.ResourceID == "ami-ready" {
+			assert.Equal(t, StatusTagged, result.Status)
+			continue
+		}
+		assert.Equal(t, StatusSkipped, result.Status)
+		skipped[result.ResourceID] = result.Error
+	}
+	assert.Equal(t, map[string]string{
+		"ami-pending": "image is pending",
+		"ami-failed":  "image is failed",
+		"ami-shared":  "owned by account 210987654321",
+	}, skipped)
+}
+
+func TestEC2ResourcesAppearInResourceTypeSummary(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+			{InstanceId: aws.String("i-1")},
+			{InstanceId: aws.String("i-2")},
+		}}},
+	}, nil).Once()
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).Return(&ec2.DescribeVolumesOutput{
+		Volumes: []ec2types.Volume{{VolumeId: aws.String("vol-1")}},
+	}, nil).Once()
+	expectNoSnapshotsOrImages(mockClient)
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "i-2"
+	})).Return(nil, errors.New("denied")).Once()
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	WithResourceTypeSummary(true)(tagger)
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	assert.Equal(t, []ResourceTypeSummary{
+		{Service: "EC2", ResourceType: "instance", ReportSummary: ReportSummary{Tagged: 1, Failed: 1}},
+		{Service: "EC2", ResourceType: "volume", ReportSummary: ReportSummary{Tagged: 1}},
+	}, tagger.buildRunSummary(tagger.buildReport()).ResourceTypes)
+}
@@ -0,0 +1,234 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// ECS resource kinds selectable with WithECSTypes
+const (
+	ECSCluster           = "cluster"
+	ECSService           = "service"
+	ECSTask              = "task"
+	ECSTaskSet           = "task-set"
+	ECSContainerInstance = "container-instance"
+)
+
+// ecsResourceTypes lists every ECS resource kind in tagging order
+var ecsResourceTypes = []string{ECSCluster, ECSService, ECSTaskSet, ECSTask, ECSContainerInstance}
+
+// ecsDescribeServicesBatch is the most services DescribeServices accepts per call
+const ecsDescribeServicesBatch = 10
+
+// ECSAPI interface for ECS client operations
+type ECSAPI interface {
+	ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error)
+	ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error)
+	DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error)
+	ListTasks(ctx context.Context, params *ecs.ListTasksInput, optFns ...func(*ecs.Options)) (*ecs.ListTasksOutput, error)
+	ListContainerInstances(ctx context.Context, params *ecs.ListContainerInstancesInput, optFns ...func(*ecs.Options)) (*ecs.ListContainerInstancesOutput, error)
+	TagResource(ctx context.Context, params *ecs.TagResourceInput, optFns ...func(*ecs.Options)) (*ecs.TagResourceOutput, error)
+}
+
+// WithECSTypes limits ECS tagging to the listed resource kinds; empty tags every kind
+func WithECSTypes(kinds []string) Option {
+	return func(t *AWSResourceTagger) {
+		t.ecsTypes = lowerSet(kinds)
+	}
+}
+
+// ValidateECSTypes reports the first kind that is not an ECS resource kind
+func ValidateECSTypes(kinds []string) error {
+	known := lowerSet(ecsResourceTypes)
+	for _, kind := range kinds {
+		if !known[strings.ToLower(kind)] {
+			return fmt.Errorf("unknown ECS resource type %q; valid types are %s", kind, strings.Join(ecsResourceTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// ecsTypeEnabled reports whether kind passes the --ecs-types filter
+func (t *AWSResourceTagger) ecsTypeEnabled(kind string) bool {
+	return len(t.ecsTypes) == 0 || t.ecsTypes[kind]
+}
+
+func init() {
+	registerService("ECS", (*AWSResourceTagger).tagECSResources)
+}
+
+// tagECSResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagECSResources() error {
+	client := ecs.NewFromConfig(t.cfg)
+	t.tagECSResourcesWithClient(client)
+	return nil
+}
+
+// tagECSResourcesWithClient tags every ECS cluster and the selected resources inside it
+func (t *AWSResourceTagger) tagECSResourcesWithClient(client ECSAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging ECS resources...")
+	defer log.Println("Completed tagging ECS resources")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping ECS tagging")
+		return
+	}
+
+	input := &ecs.ListClustersInput{}
+	for {
+		output, err := client.ListClusters(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "ECS Clusters")
+			return
+		}
+
+		for _, clusterARN := range output.ClusterArns {
+			t.tagECSCluster(client, clusterARN)
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagECSCluster tags a cluster and the selected resource kinds it contains
+func (t *AWSResourceTagger) tagECSCluster(client ECSAPI, clusterARN string) {
+	if t.ecsTypeEnabled(ECSCluster) {
+		t.tagECSResource(client, ECSCluster, clusterARN)
+	}
+	if t.ecsTypeEnabled(ECSService) || t.ecsTypeEnabled(ECSTaskSet) {
+		t.tagECSServices(client, clusterARN)
+	}
+	if t.ecsTypeEnabled(ECSTask) {
+		t.tagECSTasks(client, clusterARN)
+	}
+	if t.ecsTypeEnabled(ECSContainerInstance) {
+		t.tagECSContainerInstances(client, clusterARN)
+	}
+}
+
+// tagECSServices tags the services of a cluster and, through DescribeServices, their task sets
+func (t *AWSResourceTagger) tagECSServices(client ECSAPI, clusterARN string) {
+	input := &ecs.ListServicesInput{Cluster: aws.String(clusterARN)}
+	for {
+		output, err := client.ListServices(t.ctx, input)
+		if err != nil {
+			t.handleError(err, clusterARN, "ECS Services")
+			return
+		}
+
+		if t.ecsTypeEnabled(ECSService) {
+			for _, serviceARN := range output.ServiceArns {
+				t.tagECSResource(client, ECSService, serviceARN)
+			}
+		}
+		if t.ecsTypeEnabled(ECSTaskSet) {
+			t.tagECSTaskSets(client, clusterARN, output.ServiceArns)
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagECSTaskSets tags the task sets of services using an external deployment controller
+func (t *AWSResourceTagger) tagECSTaskSets(client ECSAPI, clusterARN string, serviceARNs []string) {
+	for start := 0; start < len(serviceARNs); start += ecsDescribeServicesBatch {
+		end := min(start+ecsDescribeServicesBatch, len(serviceARNs))
+		output, err := client.DescribeServices(t.ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(clusterARN),
+			Services: serviceARNs[start:end],
+		})
+		if err != nil {
+			t.handleError(err, clusterARN, "ECS Task Sets")
+			continue
+		}
+
+		for _, service := range output.Services {
+			for _, taskSet := range service.TaskSets {
+				t.tagECSResource(client, ECSTaskSet, aws.ToString(taskSet.TaskSetArn))
+			}
+		}
+	}
+}
+
+// tagECSTasks tags the running tasks of a cluster
+func (t *AWSResourceTagger) tagECSTasks(client ECSAPI, clusterARN string) {
+	input := &ecs.ListTasksInput{Cluster: aws.String(clusterARN)}
+	for {
+		output, err := client.ListTasks(t.ctx, input)
+		if err != nil {
+			t.handleError(err, clusterARN, "ECS Tasks")
+			return
+		}
+
+		for _, taskARN := range output.TaskArns {
+			t.tagECSResource(client, ECSTask, taskARN)
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagECSContainerInstances tags the container instances registered to a cluster
+func (t *AWSResourceTagger) tagECSContainerInstances(client ECSAPI, clusterARN string) {
+	input := &ecs.ListContainerInstancesInput{Cluster: aws.String(clusterARN)}
+	for {
+		output, err := client.ListContainerInstances(t.ctx, input)
+		if err != nil {
+			t.handleError(err, clusterARN, "ECS Container Instances")
+			return
+		}
+
+		for _, instanceARN := range output.ContainerInstanceArns {
+			t.tagECSResource(client, ECSContainerInstance, instanceARN)
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagECSResource tags a single ECS resource by ARN
+func (t *AWSResourceTagger) tagECSResource(client ECSAPI, kind, arn string) {
+	t.applyAndRecord(TagResult{
+		Service:      "ECS",
+		ResourceType: kind,
+		ResourceID:   arn[strings.LastIndex(arn, "/")+1:],
+		ARN:          arn,
+	}, func() error {
+		_, err := client.TagResource(t.ctx, &ecs.TagResourceInput{
+			ResourceArn: aws.String(arn),
+			Tags:        t.ecsTags(),
+		})
+		return err
+	})
+}
+
+// ecsTags converts the configured tags to ECS tags
+func (t *AWSResourceTagger) ecsTags() []ecstypes.Tag {
+	tags := make([]ecstypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		tags = append(tags, ecstypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,169 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockECSClient is a mock implementation of ECSAPI
+type MockECSClient struct {
+	mock.Mock
+}
+
+func (m *MockECSClient) ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.ListClustersOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.ListServicesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.DescribeServicesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListTasks(ctx context.Context, params *ecs.ListTasksInput, optFns ...func(*ecs.Options)) (*ecs.ListTasksOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.ListTasksOutput), args.Error(1)
+}
+
+func (m *MockECSClient) ListContainerInstances(ctx context.Context, params *ecs.ListContainerInstancesInput, optFns ...func(*ecs.Options)) (*ecs.ListContainerInstancesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.ListContainerInstancesOutput), args.Error(1)
+}
+
+func (m *MockECSClient) TagResource(ctx context.Context, params *ecs.TagResourceInput, optFns ...func(*ecs.Options)) (*ecs.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ecs.TagResourceOutput), args.Error(1)
+}
+
+const (
+	ecsTestCluster  = "arn:aws:ecs:us-west-2:123456789012:cluster/web"
+	ecsTestService  = "arn:aws:ecs:us-west-2:123456789012:service/web/api"
+	ecsTestTaskSet  = "arn:aws:ecs:us-west-2:123456789012:task-set/web/api/ecs-svc-123"
+	ecsTestTask     = "arn:aws:ecs:us-west-2:123456789012:task/web/0123abcd"
+	ecsTestTask2    = "arn:aws:ecs:us-west-2:123456789012:task/web/4567ef01"
+	ecsTestInstance = "arn:aws:ecs:us-west-2:123456789012:container-instance/web/89ab"
+)
+
+// setupECSCluster mocks one cluster with a service, a task set, two pages of
+// tasks and a container instance
+func setupECSCluster(m *MockECSClient) {
+	m.On("ListClusters", mock.Anything, mock.Anything).
+		Return(&ecs.ListClustersOutput{ClusterArns: []string{ecsTestCluster}}, nil)
+	m.On("ListServices", mock.Anything, mock.Anything).
+		Return(&ecs.ListServicesOutput{ServiceArns: []string{ecsTestService}}, nil)
+	m.On("DescribeServices", mock.Anything, &ecs.DescribeServicesInput{
+		Cluster:  aws.String(ecsTestCluster),
+		Services: []string{ecsTestService},
+	}).Return(&ecs.DescribeServicesOutput{
+		Services: []ecstypes.Service{{TaskSets: []ecstypes.TaskSet{{TaskSetArn: aws.String(ecsTestTaskSet)}}}},
+	}, nil)
+	m.On("ListTasks", mock.Anything, &ecs.ListTasksInput{Cluster: aws.String(ecsTestCluster)}).
+		Return(&ecs.ListTasksOutput{TaskArns: []string{ecsTestTask}, NextToken: aws.String("page-2")}, nil)
+	m.On("ListTasks", mock.Anything, &ecs.ListTasksInput{Cluster: aws.String(ecsTestCluster), NextToken: aws.String("page-2")}).
+		Return(&ecs.ListTasksOutput{TaskArns: []string{ecsTestTask2}}, nil)
+	m.On("ListContainerInstances", mock.Anything, &ecs.ListContainerInstancesInput{Cluster: aws.String(ecsTestCluster)}).
+		Return(&ecs.ListContainerInstancesOutput{ContainerInstanceArns: []string{ecsTestInstance}}, nil)
+}
+
+func TestTagECSResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		types    []string
+		expected []string
+	}{
+		{
+			name:     "All resource kinds by default",
+			expected: []string{ecsTestCluster, ecsTestInstance, ecsTestService, ecsTestTaskSet, ecsTestTask, ecsTestTask2},
+		},
+		{
+			name:     "Only task sets and container instances",
+			types:    []string{"task-set", "Container-Instance"},
+			expected: []string{ecsTestInstance, ecsTestTaskSet},
+		},
+		{
+			name:     "Only tasks",
+			types:    []string{"task"},
+			expected: []string{ecsTestTask, ecsTestTask2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockECSClient)
+			setupECSCluster(mockClient)
+			var tagged []string
+			mockClient.On("TagResource", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				tagged = append(tagged, aws.ToString(args.Get(1).(*ecs.TagResourceInput).ResourceArn))
+			}).Return(&ecs.TagResourceOutput{}, nil)
+
+			tagger := createTestTagger()
+			tagger.results = NewResultCollector()
+			WithECSTypes(tt.types)(tagger)
+			tagger.tagECSResourcesWithClient(mockClient)
+
+			sort.Strings(tagged)
+			assert.Equal(t, tt.expected, tagged)
+		})
+	}
+}
+
+func TestTagECSResourcesContinuesAfterFailure(t *testing.T) {
+	mockClient := new(MockECSClient)
+	setupECSCluster(mockClient)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *ecs.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == ecsTestTask
+	})).Return(nil, errors.New("InvalidParameterException: long ARN format required"))
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&ecs.TagResourceOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	WithECSTypes([]string{"task", "container-instance"})(tagger)
+	tagger.tagECSResourcesWithClient(mockClient)
+
+	statuses := map[string]string{}
+	for _, result := range tagger.Results() {
+		statuses[result.ResourceType+"/"+result.ResourceID] = result.Status
+	}
+	assert.Equal(t, map[string]string{
+		"task/0123abcd":           StatusFailed,
+		"task/4567ef01":           StatusTagged,
+		"container-instance/89ab": StatusTagged,
+	}, statuses)
+	mockClient.AssertNotCalled(t, "ListServices", mock.Anything, mock.Anything)
+}
+
+func TestValidateECSTypes(t *testing.T) {
+	assert.NoError(t, ValidateECSTypes([]string{"cluster", "TASK-SET"}))
+	assert.ErrorContains(t, ValidateECSTypes([]string{"task-definition"}), `unknown ECS resource type "task-definition"`)
+}
@@ -0,0 +1,102 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/efs"
+)
+
+// efsReplicationDestination is the overwrite protection state of a file
+// system that is the read-only destination of a replication configuration
+const efsReplicationDestination = efs.ReplicationOverwriteProtectionReplicating
+
+// EFSAPI interface for EFS client operations. EFS uses the v1 SDK client
+// built by newV1Session because the v2 module is not available.
+type EFSAPI interface {
+	DescribeFileSystemsWithContext(ctx aws.Context, input *efs.DescribeFileSystemsInput, opts ...request.Option) (*efs.DescribeFileSystemsOutput, error)
+	TagResourceWithContext(ctx aws.Context, input *efs.TagResourceInput, opts ...request.Option) (*efs.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("EFS", (*AWSResourceTagger).tagEFSResources)
+}
+
+// tagEFSResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagEFSResources() error {
+	sess, err := t.newV1Session(t.region)
+	if err != nil {
+		return fmt.Errorf("unable to create EFS session: %w", err)
+	}
+	t.tagEFSResourcesWithClient(efs.New(sess))
+	return nil
+}
+
+// tagEFSResourcesWithClient tags EFS file systems, skipping replication
+// destinations because they are read-only while replication is active
+func (t *AWSResourceTagger) tagEFSResourcesWithClient(client EFSAPI) {
+	log.Println("Tagging EFS file systems...")
+	defer log.Println("Completed tagging EFS file systems")
+
+	skipped := 0
+	input := &efs.DescribeFileSystemsInput{}
+	for {
+		output, err := client.DescribeFileSystemsWithContext(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "EFS File Systems")
+			break
+		}
+
+		for _, fs := range output.FileSystems {
+			target := TagResult{
+				Service:      "EFS",
+				ResourceType: "file-system",
+				ResourceID:   aws.StringValue(fs.FileSystemId),
+				ARN:          aws.StringValue(fs.FileSystemArn),
+			}
+			if isEFSReplicationDestination(fs) {
+				skipped++
+				t.skipResource(target, "replication destination is read-only")
+				continue
+			}
+
+			t.applyAndRecord(target, func() error {
+				_, err := client.TagResourceWithContext(t.ctx, &efs.TagResourceInput{
+					ResourceId: fs.FileSystemId,
+					Tags:       t.efsTags(),
+				})
+				return err
+			})
+		}
+
+		if output.NextMarker == nil {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	if skipped > 0 {
+		log.Printf("Skipped %d EFS file systems that are replication destinations", skipped)
+	}
+}
+
+// isEFSReplicationDestination reports whether fs receives replicated data
+func isEFSReplicationDestination(fs *efs.FileSystemDescription) bool {
+	return fs.FileSystemProtection != nil &&
+		aws.StringValue(fs.FileSystemProtection.ReplicationOverwriteProtection) == efsReplicationDestination
+}
+
+// efsTags converts the configured tags to EFS tags
+func (t *AWSResourceTagger) efsTags() []*efs.Tag {
+	tags := make([]*efs.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, &efs.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
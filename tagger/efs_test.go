@@ -0,0 +1,84 @@
+package tagger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEFSClient is a mock implementation of EFSAPI
+type MockEFSClient struct {
+	mock.Mock
+}
+
+func (m *MockEFSClient) DescribeFileSystemsWithContext(ctx aws.Context, input *efs.DescribeFileSystemsInput, opts ...request.Option) (*efs.DescribeFileSystemsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*efs.DescribeFileSystemsOutput), args.Error(1)
+}
+
+func (m *MockEFSClient) TagResourceWithContext(ctx aws.Context, input *efs.TagResourceInput, opts ...request.Option) (*efs.TagResourceOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*efs.TagResourceOutput), args.Error(1)
+}
+
+func TestTagEFSSkipsReplicationDestinations(t *testing.T) {
+	mockClient := new(MockEFSClient)
+	mockClient.On("DescribeFileSystemsWithContext", mock.Anything, &efs.DescribeFileSystemsInput{}).
+		Return(&efs.DescribeFileSystemsOutput{
+			FileSystems: []*efs.FileSystemDescription{
+				{
+					FileSystemId:  aws.String("fs-source"),
+					FileSystemArn: aws.String("arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/fs-source"),
+					FileSystemProtection: &efs.FileSystemProtectionDescription{
+						ReplicationOverwriteProtection: aws.String(efs.ReplicationOverwriteProtectionEnabled),
+					},
+				},
+			},
+			NextMarker: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("DescribeFileSystemsWithContext", mock.Anything, &efs.DescribeFileSystemsInput{Marker: aws.String("page-2")}).
+		Return(&efs.DescribeFileSystemsOutput{
+			FileSystems: []*efs.FileSystemDescription{
+				{
+					FileSystemId:  aws.String("fs-replica"),
+					FileSystemArn: aws.String("arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/fs-replica"),
+					FileSystemProtection: &efs.FileSystemProtectionDescription{
+						ReplicationOverwriteProtection: aws.String(efs.ReplicationOverwriteProtectionReplicating),
+					},
+				},
+				{FileSystemId: aws.String("fs-denied")},
+			},
+		}, nil).Once()
+	mockClient.On("TagResourceWithContext", mock.Anything, mock.MatchedBy(func(input *efs.TagResourceInput) bool {
+		return aws.StringValue(input.ResourceId) == "fs-source"
+	})).Return(&efs.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResourceWithContext", mock.Anything, mock.MatchedBy(func(input *efs.TagResourceInput) bool {
+		return aws.StringValue(input.ResourceId) == "fs-denied"
+	})).Return(nil, errors.New("AccessDenied")).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagEFSResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TagResourceWithContext", 2)
+
+	results := tagger.Results()
+	assert.Len(t, results, 3)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, "fs-replica", results[1].ResourceID)
+	assert.Equal(t, StatusSkipped, results[1].Status)
+	assert.Equal(t, "replication destination is read-only", results[1].Error)
+	assert.Equal(t, StatusFailed, results[2].Status)
+}
@@ -0,0 +1,158 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// EKSAPI interface for EKS client operations
+type EKSAPI interface {
+	ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error)
+	DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error)
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error)
+	TagResource(ctx context.Context, params *eks.TagResourceInput, optFns ...func(*eks.Options)) (*eks.TagResourceOutput, error)
+}
+
+// EKSFilter limits tagging to clusters matching a status and/or Kubernetes version.
+// Empty fields match every cluster.
+type EKSFilter struct {
+	Status  string
+	Version string
+}
+
+// WithEKSFilter tags only EKS clusters (and their node groups) matching the filter
+func WithEKSFilter(filter EKSFilter) Option {
+	return func(t *AWSResourceTagger) {
+		t.eksFilter = filter
+	}
+}
+
+// matches reports whether a cluster passes the filter
+func (f EKSFilter) matches(cluster *ekstypes.Cluster) bool {
+	if f.Status != "" && !strings.EqualFold(f.Status, string(cluster.Status)) {
+		return false
+	}
+	if f.Version != "" && f.Version != aws.ToString(cluster.Version) {
+		return false
+	}
+	return true
+}
+
+func init() {
+	registerService("EKS", (*AWSResourceTagger).tagEKSResources)
+}
+
+// tagEKSResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagEKSResources() error {
+	client := eks.NewFromConfig(t.cfg)
+	t.tagEKSResourcesWithClient(client)
+	return nil
+}
+
+// tagEKSResourcesWithClient handles the actual tagging logic with a provided client
+func (t *AWSResourceTagger) tagEKSResourcesWithClient(client EKSAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging EKS resources...")
+
+	input := &eks.ListClustersInput{}
+	for {
+		clusters, err := client.ListClusters(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "EKS Clusters")
+			return
+		}
+
+		for _, clusterName := range clusters.Clusters {
+			t.tagEKSCluster(client, clusterName)
+		}
+
+		if clusters.NextToken == nil {
+			break
+		}
+		input.NextToken = clusters.NextToken
+	}
+
+	log.Println("Completed tagging EKS resources")
+}
+
+// tagEKSCluster tags a cluster and its node groups when the cluster matches the filter
+func (t *AWSResourceTagger) tagEKSCluster(client EKSAPI, clusterName string) {
+	described, err := client.DescribeCluster(t.ctx, &eks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		t.handleError(err, clusterName, "EKS Cluster")
+		return
+	}
+
+	cluster := described.Cluster
+	if !t.eksFilter.matches(cluster) {
+		log.Printf("Skipping EKS cluster %s (status %s, version %s) not matching filter",
+			clusterName, cluster.Status, aws.ToString(cluster.Version))
+		t.recordResult(TagResult{
+			Service:      "EKS",
+			ResourceType: "cluster",
+			ResourceID:   clusterName,
+			ARN:          aws.ToString(cluster.Arn),
+			Status:       StatusSkipped,
+			Error:        "does not match --eks-status/--eks-version filter",
+		})
+		return
+	}
+
+	t.tagEKSResource(client, aws.ToString(cluster.Arn), clusterName, "cluster")
+	t.tagEKSNodegroups(client, clusterName)
+}
+
+// tagEKSNodegroups tags every node group of a cluster
+func (t *AWSResourceTagger) tagEKSNodegroups(client EKSAPI, clusterName string) {
+	input := &eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)}
+	for {
+		nodegroups, err := client.ListNodegroups(t.ctx, input)
+		if err != nil {
+			t.handleError(err, clusterName, "EKS Node Groups")
+			return
+		}
+
+		for _, nodegroupName := range nodegroups.Nodegroups {
+			described, err := client.DescribeNodegroup(t.ctx, &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String(nodegroupName),
+			})
+			if err != nil {
+				t.handleError(err, nodegroupName, "EKS Node Group")
+				continue
+			}
+			t.tagEKSResource(client, aws.ToString(described.Nodegroup.NodegroupArn), nodegroupName, "nodegroup")
+		}
+
+		if nodegroups.NextToken == nil {
+			break
+		}
+		input.NextToken = nodegroups.NextToken
+	}
+}
+
+// tagEKSResource applies the tags to a single cluster or node group ARN
+func (t *AWSResourceTagger) tagEKSResource(client EKSAPI, arn, name, resourceType string) {
+	target := TagResult{
+		Service:      "EKS",
+		ResourceType: resourceType,
+		ResourceID:   name,
+		ARN:          arn,
+	}
+	t.applyAndRecord(target, func() error {
+		_, err := client.TagResource(t.ctx, &eks.TagResourceInput{
+			ResourceArn: aws.String(arn),
+			Tags:        t.tags,
+		})
+		return err
+	})
+}
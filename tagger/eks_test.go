@@ -0,0 +1,174 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEKSClient is a mock implementation of EKSAPI
+type MockEKSClient struct {
+	mock.Mock
+}
+
+func (m *MockEKSClient) ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eks.ListClustersOutput), args.Error(1)
+}
+
+func (m *MockEKSClient) DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eks.DescribeClusterOutput), args.Error(1)
+}
+
+func (m *MockEKSClient) ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eks.ListNodegroupsOutput), args.Error(1)
+}
+
+func (m *MockEKSClient) DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eks.DescribeNodegroupOutput), args.Error(1)
+}
+
+func (m *MockEKSClient) TagResource(ctx context.Context, params *eks.TagResourceInput, optFns ...func(*eks.Options)) (*eks.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eks.TagResourceOutput), args.Error(1)
+}
+
+// setupEKSClusters mocks two clusters, each with a single node group
+func setupEKSClusters(m *MockEKSClient) {
+	m.On("ListClusters", mock.Anything, mock.Anything).
+		Return(&eks.ListClustersOutput{Clusters: []string{"prod", "legacy"}}, nil)
+	m.On("DescribeCluster", mock.Anything, &eks.DescribeClusterInput{Name: aws.String("prod")}).
+		Return(&eks.DescribeClusterOutput{Cluster: &ekstypes.Cluster{
+			Name:    aws.String("prod"),
+			Arn:     aws.String("arn:aws:eks:us-west-2:123456789012:cluster/prod"),
+			Status:  ekstypes.ClusterStatusActive,
+			Version: aws.String("1.30"),
+		}}, nil)
+	m.On("DescribeCluster", mock.Anything, &eks.DescribeClusterInput{Name: aws.String("legacy")}).
+		Return(&eks.DescribeClusterOutput{Cluster: &ekstypes.Cluster{
+			Name:    aws.String("legacy"),
+			Arn:     aws.String("arn:aws:eks:us-west-2:123456789012:cluster/legacy"),
+			Status:  ekstypes.ClusterStatusUpdating,
+			Version: aws.String("1.27"),
+		}}, nil)
+	for _, cluster := range []string{"prod", "legacy"} {
+		m.On("ListNodegroups", mock.Anything, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)}).
+			Return(&eks.ListNodegroupsOutput{Nodegroups: []string{cluster + "-ng"}}, nil).Maybe()
+		m.On("DescribeNodegroup", mock.Anything, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(cluster),
+			NodegroupName: aws.String(cluster + "-ng"),
+		}).Return(&eks.DescribeNodegroupOutput{Nodegroup: &ekstypes.Nodegroup{
+			NodegroupArn: aws.String("arn:aws:eks:us-west-2:123456789012:nodegroup/" + cluster + "/" + cluster + "-ng/abc"),
+		}}, nil).Maybe()
+	}
+	m.On("TagResource", mock.Anything, mock.Anything).Return(&eks.TagResourceOutput{}, nil)
+}
+
+func TestTagEKSResourcesWithClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          EKSFilter
+		expectedTagged  []string
+		expectedSkipped []string
+	}{
+		{
+			name:           "No filter tags every cluster and node group",
+			expectedTagged: []string{"prod", "prod-ng", "legacy", "legacy-ng"},
+		},
+		{
+			name:            "Status filter skips non-matching clusters and their node groups",
+			filter:          EKSFilter{Status: "active"},
+			expectedTagged:  []string{"prod", "prod-ng"},
+			expectedSkipped: []string{"legacy"},
+		},
+		{
+			name:            "Version filter skips non-matching clusters",
+			filter:          EKSFilter{Version: "1.27"},
+			expectedTagged:  []string{"legacy", "legacy-ng"},
+			expectedSkipped: []string{"prod"},
+		},
+		{
+			name:            "Status and version must both match",
+			filter:          EKSFilter{Status: "ACTIVE", Version: "1.27"},
+			expectedSkipped: []string{"prod", "legacy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			mockClient := new(MockEKSClient)
+			setupEKSClusters(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:     context.Background(),
+				tags:    map[string]string{"env": "prod"},
+				results: NewResultCollector(),
+			}
+			WithEKSFilter(tt.filter)(tagger)
+			tagger.tagEKSResourcesWithClient(mockClient)
+
+			var tagged, skipped []string
+			for _, r := range tagger.Results() {
+				switch r.Status {
+				case StatusTagged:
+					tagged = append(tagged, r.ResourceID)
+				case StatusSkipped:
+					skipped = append(skipped, r.ResourceID)
+				}
+			}
+			assert.Equal(t, tt.expectedTagged, tagged)
+			assert.Equal(t, tt.expectedSkipped, skipped)
+			mockClient.AssertNumberOfCalls(t, "TagResource", len(tt.expectedTagged))
+			for _, cluster := range tt.expectedSkipped {
+				mockClient.AssertNotCalled(t, "ListNodegroups", mock.Anything, &eks.ListNodegroupsInput{ClusterName: aws.String(cluster)})
+			}
+		})
+	}
+}
+
+func TestTagEKSResourcesWithClient_ListError(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	mockClient := new(MockEKSClient)
+	mockClient.On("ListClusters", mock.Anything, mock.Anything).
+		Return(nil, errors.New("API error"))
+
+	tagger := &AWSResourceTagger{ctx: context.Background(), results: NewResultCollector()}
+	tagger.tagEKSResourcesWithClient(mockClient)
+
+	assert.Contains(t, logBuffer.String(), "Error tagging EKS Clusters resource all")
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+}
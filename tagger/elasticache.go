@@ -17,10 +17,15 @@ type ElastiCacheAPI interface {
 	AddTagsToResource(ctx context.Context, params *elasticache.AddTagsToResourceInput, optFns ...func(*elasticache.Options)) (*elasticache.AddTagsToResourceOutput, error)
 }
 
+func init() {
+	registerService("ElastiCache", (*AWSResourceTagger).tagElastiCacheResources)
+}
+
 // tagElastiCacheResources is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagElastiCacheResources() {
+func (t *AWSResourceTagger) tagElastiCacheResources() error {
 	client := elasticache.NewFromConfig(t.cfg)
 	t.tagElastiCacheResourcesWithClient(client)
+	return nil
 }
 
 // tagElastiCacheResourcesWithClient handles the actual tagging logic with a provided client
@@ -28,69 +33,81 @@ func (t *AWSResourceTagger) tagElastiCacheResourcesWithClient(client ElastiCache
 	fmt.Println("=====================================")
 	log.Println("Tagging ElastiCache resources...")
 
+	tags := t.convertToElastiCacheTags()
+
 	// List all ElastiCache clusters
-	clusters, err := client.DescribeCacheClusters(t.ctx, &elasticache.DescribeCacheClustersInput{})
-	if err != nil {
-		t.handleError(err, "all", "ElastiCache")
-		return
-	}
+	clusterInput := &elasticache.DescribeCacheClustersInput{}
+	for {
+		clusters, err := client.DescribeCacheClusters(t.ctx, clusterInput)
+		if err != nil {
+			t.handleError(err, "all", "ElastiCache")
+			return
+		}
 
-	// Tag individual clusters
-	for _, cluster := range clusters.CacheClusters {
-		arn := aws.ToString(cluster.ARN)
-		input := &elasticache.AddTagsToResourceInput{
-			ResourceName: cluster.ARN,
-			Tags: func() []elctypes.Tag {
-				tags := make([]elctypes.Tag, 0, len(t.tags))
-				for k, v := range t.tags {
-					tags = append(tags, elctypes.Tag{
-						Key:   aws.String(k),
-						Value: aws.String(v),
-					})
-				}
-				return tags
-			}(),
+		// Tag individual clusters
+		for _, cluster := range clusters.CacheClusters {
+			t.applyAndRecord(TagResult{
+				Service:      "ElastiCache",
+				ResourceType: "cluster",
+				ResourceID:   aws.ToString(cluster.CacheClusterId),
+				ARN:          aws.ToString(cluster.ARN),
+			}, func() error {
+				_, err := client.AddTagsToResource(t.ctx, &elasticache.AddTagsToResourceInput{
+					ResourceName: cluster.ARN,
+					Tags:         tags,
+				})
+				return err
+			})
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
-		if err != nil {
-			t.handleError(err, arn, "ElastiCache")
-			continue
+		if aws.ToString(clusters.Marker) == "" {
+			break
 		}
-		log.Printf("Successfully tagged ElastiCache cluster: %s", aws.ToString(cluster.CacheClusterId))
+		clusterInput.Marker = clusters.Marker
 	}
 
 	// List all Replication Groups
-	repGroups, err := client.DescribeReplicationGroups(t.ctx, &elasticache.DescribeReplicationGroupsInput{})
-	if err != nil {
-		t.handleError(err, "all", "ElastiCache Replication Groups")
-		return
-	}
+	groupInput := &elasticache.DescribeReplicationGroupsInput{}
+	for {
+		repGroups, err := client.DescribeReplicationGroups(t.ctx, groupInput)
+		if err != nil {
+			t.handleError(err, "all", "ElastiCache Replication Groups")
+			return
+		}
 
-	// Tag replication groups
-	for _, group := range repGroups.ReplicationGroups {
-		arn := aws.ToString(group.ARN)
-		input := &elasticache.AddTagsToResourceInput{
-			ResourceName: group.ARN,
-			Tags: func() []elctypes.Tag {
-				tags := make([]elctypes.Tag, 0, len(t.tags))
-				for k, v := range t.tags {
-					tags = append(tags, elctypes.Tag{
-						Key:   aws.String(k),
-						Value: aws.String(v),
-					})
-				}
-				return tags
-			}(),
+		// Tag replication groups
+		for _, group := range repGroups.ReplicationGroups {
+			t.applyAndRecord(TagResult{
+				Service:      "ElastiCache",
+				ResourceType: "replication group",
+				ResourceID:   aws.ToString(group.ReplicationGroupId),
+				ARN:          aws.ToString(group.ARN),
+			}, func() error {
+				_, err := client.AddTagsToResource(t.ctx, &elasticache.AddTagsToResourceInput{
+					ResourceName: group.ARN,
+					Tags:         tags,
+				})
+				return err
+			})
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
-		if err != nil {
-			t.handleError(err, arn, "ElastiCache Replication Group")
-			continue
+		if aws.ToString(repGroups.Marker) == "" {
+			break
 		}
-		log.Printf("Successfully tagged ElastiCache replication group: %s", aws.ToString(group.ReplicationGroupId))
+		groupInput.Marker = repGroups.Marker
 	}
 
 	log.Println("Completed tagging ElastiCache resources")
 }
+
+// convertToElastiCacheTags converts the configured tags to ElastiCache tags
+func (t *AWSResourceTagger) convertToElastiCacheTags() []elctypes.Tag {
+	tags := make([]elctypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		tags = append(tags, elctypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return tags
+}
@@ -164,3 +164,99 @@ func TestTagElastiCacheResourcesWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestTagElastiCacheResourcesPagination(t *testing.T) {
+	var tagged []string
+	mockClient := &MockElastiCacheClient{
+		DescribeCacheClustersFunc: func(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error) {
+			if params.Marker == nil {
+				return &elasticache.DescribeCacheClustersOutput{
+					CacheClusters: []elctypes.CacheCluster{{CacheClusterId: aws.String("cluster-1"), ARN: aws.String("arn:cluster-1")}},
+					Marker:        aws.String("clusters-page-2"),
+				}, nil
+			}
+			if aws.ToString(params.Marker) != "clusters-page-2" {
+				t.Fatalf("unexpected cluster marker %q", aws.ToString(params.Marker))
+			}
+			return &elasticache.DescribeCacheClustersOutput{
+				CacheClusters: []elctypes.CacheCluster{{CacheClusterId: aws.String("cluster-2"), ARN: aws.String("arn:cluster-2")}},
+			}, nil
+		},
+		DescribeReplicationGroupsFunc: func(ctx context.Context, params *elasticache.DescribeReplicationGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeReplicationGroupsOutput, error) {
+			if params.Marker == nil {
+				return &elasticache.DescribeReplicationGroupsOutput{
+					ReplicationGroups: []elctypes.ReplicationGroup{{ReplicationGroupId: aws.String("group-1"), ARN: aws.String("arn:group-1")}},
+					Marker:            aws.String("groups-page-2"),
+				}, nil
+			}
+			if aws.ToString(params.Marker) != "groups-page-2" {
+				t.Fatalf("unexpected replication group marker %q", aws.ToString(params.Marker))
+			}
+			return &elasticache.DescribeReplicationGroupsOutput{
+				ReplicationGroups: []elctypes.ReplicationGroup{{ReplicationGroupId: aws.String("group-2"), ARN: aws.String("arn:group-2")}},
+			}, nil
+		},
+		AddTagsToResourceFunc: func(ctx context.Context, params *elasticache.AddTagsToResourceInput, optFns ...func(*elasticache.Options)) (*elasticache.AddTagsToResourceOutput, error) {
+			tagged = append(tagged, aws.ToString(params.ResourceName))
+			return &elasticache.AddTagsToResourceOutput{}, nil
+		},
+	}
+
+	tagger := &AWSResourceTagger{
+		ctx:  context.Background(),
+		tags: map[string]string{"Environment": "Test"},
+	}
+	tagger.tagElastiCacheResourcesWithClient(mockClient)
+
+	expected := []string{"arn:cluster-1", "arn:cluster-2", "arn:group-1", "arn:group-2"}
+	if strings.Join(tagged, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v to be tagged, got %v", expected, tagged)
+	}
+}
+
+func TestConvertToElastiCacheTags(t *testing.T) {
+	tagger := &AWSResourceTagger{
+		tags:          map[string]string{"b": "2", "a": "1"},
+		deterministic: true,
+	}
+
+	tags := tagger.convertToElastiCacheTags()
+
+	if len(tags) != 2 || aws.ToString(tags[0].Key) != "a" || aws.ToString(tags[1].Value) != "2" {
+		t.Errorf("Unexpected tags: %+v", tags)
+	}
+}
+
+func TestTagElastiCacheResourcesStopsOnEmptyMarker(t *testing.T) {
+	clusterCalls, groupCalls, tagCalls := 0, 0, 0
+	mockClient := &MockElastiCacheClient{
+		DescribeCacheClustersFunc: func(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error) {
+			clusterCalls++
+			return &elasticache.DescribeCacheClustersOutput{
+				CacheClusters: []elctypes.CacheCluster{{CacheClusterId: aws.String("cluster-1"), ARN: aws.String("arn:cluster-1")}},
+				Marker:        aws.String(""),
+			}, nil
+		},
+		DescribeReplicationGroupsFunc: func(ctx context.Context, params *elasticache.DescribeReplicationGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeReplicationGroupsOutput, error) {
+			groupCalls++
+			return &elasticache.DescribeReplicationGroupsOutput{
+				ReplicationGroups: []elctypes.ReplicationGroup{{ReplicationGroupId: aws.String("group-1"), ARN: aws.String("arn:group-1")}},
+				Marker:            aws.String(""),
+			}, nil
+		},
+		AddTagsToResourceFunc: func(ctx context.Context, params *elasticache.AddTagsToResourceInput, optFns ...func(*elasticache.Options)) (*elasticache.AddTagsToResourceOutput, error) {
+			tagCalls++
+			return &elasticache.AddTagsToResourceOutput{}, nil
+		},
+	}
+
+	tagger := &AWSResourceTagger{
+		ctx:  context.Background(),
+		tags: map[string]string{"Environment": "Test"},
+	}
+	tagger.tagElastiCacheResourcesWithClient(mockClient)
+
+	if clusterCalls != 1 || groupCalls != 1 || tagCalls != 2 {
+		t.Errorf("Expected one describe call each and two tag calls, got clusters=%d groups=%d tags=%d", clusterCalls, groupCalls, tagCalls)
+	}
+}
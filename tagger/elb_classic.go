@@ -24,12 +24,17 @@ type ELBv2API interface {
 	AddTags(ctx context.Context, params *elasticloadbalancingv2.AddTagsInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.AddTagsOutput, error)
 }
 
+func init() {
+	registerService("ELB", (*AWSResourceTagger).tagELBResources)
+}
+
 // tagELBResources creates clients and initiates the tagging process
-func (t *AWSResourceTagger) tagELBResources() {
+func (t *AWSResourceTagger) tagELBResources() error {
 	classicClient := elasticloadbalancing.NewFromConfig(t.cfg)
 	v2Client := elasticloadbalancingv2.NewFromConfig(t.cfg)
 
 	t.tagELBResourcesWithClients(classicClient, v2Client)
+	return nil
 }
 
 // tagELBResourcesWithClients tags both Classic and Application/Network Load Balancers
@@ -61,16 +66,13 @@ func (t *AWSResourceTagger) tagClassicLoadBalancersWithClient(client ClassicELBA
 
 	for _, lb := range result.LoadBalancerDescriptions {
 		lbName := aws.ToString(lb.LoadBalancerName)
-
-		_, err := client.AddTags(t.ctx, &elasticloadbalancing.AddTagsInput{
-			LoadBalancerNames: []string{lbName},
-			Tags:              t.convertToClassicELBTags(),
+		t.applyAndRecord(TagResult{Service: "ELB", ResourceType: "classic load balancer", ResourceID: lbName}, func() error {
+			_, err := client.AddTags(t.ctx, &elasticloadbalancing.AddTagsInput{
+				LoadBalancerNames: []string{lbName},
+				Tags:              t.convertToClassicELBTags(),
+			})
+			return err
 		})
-		if err != nil {
-			t.handleError(err, lbName, "Classic Load Balancer")
-			continue
-		}
-		log.Printf("Successfully tagged Classic Load Balancer: %s", lbName)
 	}
 }
 
@@ -82,6 +84,8 @@ func (t *AWSResourceTagger) tagApplicationAndNetworkLoadBalancersWithClient(clie
 		return // Error already logged in listLoadBalancers
 	}
 
+	// Target groups can be attached to several load balancers; tag each once
+	seenTargetGroups := make(map[string]bool)
 	for _, lb := range loadBalancers {
 		// Tag each load balancer
 		if err := t.tagLoadBalancer(client, lb); err != nil {
@@ -90,34 +94,69 @@ func (t *AWSResourceTagger) tagApplicationAndNetworkLoadBalancersWithClient(clie
 		}
 
 		// Tag target groups for successfully tagged load balancer
-		t.tagTargetGroupsForLoadBalancer(client, lb)
+		t.tagTargetGroupsForLoadBalancer(client, lb, seenTargetGroups)
 	}
 }
 
-// listLoadBalancers gets all ALB/NLB load balancers
+// listLoadBalancers gets all ALB/NLB load balancers, following NextMarker
 func (t *AWSResourceTagger) listLoadBalancers(client ELBv2API) ([]elbv2Types.LoadBalancer, error) {
-	result, err := client.DescribeLoadBalancers(t.ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
-	if err != nil {
-		t.handleError(err, "all", "ALB/NLB Load Balancers")
-		return nil, err
+	var loadBalancers []elbv2Types.LoadBalancer
+	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{}
+	for {
+		result, err := client.DescribeLoadBalancers(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "ALB/NLB Load Balancers")
+			return nil, err
+		}
+		loadBalancers = append(loadBalancers, result.LoadBalancers...)
+
+		if aws.ToString(result.NextMarker) == "" {
+			return loadBalancers, nil
+		}
+		input.Marker = result.NextMarker
+	}
+}
+
+// describeTargetGroups gets all target groups attached to a load balancer, following NextMarker
+func (t *AWSResourceTagger) describeTargetGroups(client ELBv2API, lbArn string) ([]elbv2Types.TargetGroup, error) {
+	var targetGroups []elbv2Types.TargetGroup
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(lbArn),
+	}
+	for {
+		result, err := client.DescribeTargetGroups(t.ctx, input)
+		if err != nil {
+			t.handleError(err, lbArn, "Target Groups")
+			return nil, err
+		}
+		targetGroups = append(targetGroups, result.TargetGroups...)
+
+		if aws.ToString(result.NextMarker) == "" {
+			return targetGroups, nil
+		}
+		input.Marker = result.NextMarker
 	}
-	return result.LoadBalancers, nil
 }
 
 // tagLoadBalancer tags a single ALB/NLB
 func (t *AWSResourceTagger) tagLoadBalancer(client ELBv2API, lb elbv2Types.LoadBalancer) error {
-	lbName := aws.ToString(lb.LoadBalancerName)
 	lbArn := aws.ToString(lb.LoadBalancerArn)
-
-	_, err := client.AddTags(t.ctx, &elasticloadbalancingv2.AddTagsInput{
-		ResourceArns: []string{lbArn},
-		Tags:         t.convertToELBv2Tags(),
-	})
-	if err != nil {
-		t.handleError(err, lbName, "ALB/NLB Load Balancer")
+	return t.applyAndRecord(TagResult{
+		Service:      "ELB",
+		ResourceType: loadBalancerType(lb) + " load balancer",
+		ResourceID:   aws.ToString(lb.LoadBalancerName),
+		ARN:          lbArn,
+	}, func() error {
+		_, err := client.AddTags(t.ctx, &elasticloadbalancingv2.AddTagsInput{
+			ResourceArns: []string{lbArn},
+			Tags:         t.convertToELBv2Tags(),
+		})
 		return err
-	}
+	})
+}
 
+// loadBalancerType names the kind of an ALB/NLB for results and logs
+func loadBalancerType(lb elbv2Types.LoadBalancer) string {
 	var lbType string
 	switch lb.Type {
 	case elbv2Types.LoadBalancerTypeEnumApplication:
@@ -129,23 +168,23 @@ func (t *AWSResourceTagger) tagLoadBalancer(client ELBv2API, lb elbv2Types.LoadB
 	default:
 		lbType = string(lb.Type)
 	}
-
-	log.Printf("Successfully tagged %s Load Balancer: %s", lbType, lbName)
-	return nil
+	return lbType
 }
 
-// tagTargetGroupsForLoadBalancer tags all target groups associated with a load balancer
-func (t *AWSResourceTagger) tagTargetGroupsForLoadBalancer(client ELBv2API, lb elbv2Types.LoadBalancer) {
-	lbArn := aws.ToString(lb.LoadBalancerArn)
-	targetGroups, err := client.DescribeTargetGroups(t.ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{
-		LoadBalancerArn: aws.String(lbArn),
-	})
+// tagTargetGroupsForLoadBalancer tags the target groups associated with a load
+// balancer, skipping those already in seen and adding the rest to it
+func (t *AWSResourceTagger) tagTargetGroupsForLoadBalancer(client ELBv2API, lb elbv2Types.LoadBalancer, seen map[string]bool) {
+	targetGroups, err := t.describeTargetGroups(client, aws.ToString(lb.LoadBalancerArn))
 	if err != nil {
-		t.handleError(err, lbArn, "Target Groups")
 		return
 	}
 
-	for _, tg := range targetGroups.TargetGroups {
+	for _, tg := range targetGroups {
+		tgArn := aws.ToString(tg.TargetGroupArn)
+		if seen[tgArn] {
+			continue
+		}
+		seen[tgArn] = true
 		if err := t.tagTargetGroup(client, tg); err != nil {
 			// Continue to next target group if tagging fails
 			continue
@@ -155,53 +194,38 @@ func (t *AWSResourceTagger) tagTargetGroupsForLoadBalancer(client ELBv2API, lb e
 
 // tagTargetGroup tags a single target group
 func (t *AWSResourceTagger) tagTargetGroup(client ELBv2API, tg elbv2Types.TargetGroup) error {
-	tgName := aws.ToString(tg.TargetGroupName)
 	tgArn := aws.ToString(tg.TargetGroupArn)
-
-	_, err := client.AddTags(t.ctx, &elasticloadbalancingv2.AddTagsInput{
-		ResourceArns: []string{tgArn},
-		Tags:         t.convertToELBv2Tags(),
-	})
-	if err != nil {
-		t.handleError(err, tgName, "Target Group")
+	return t.applyAndRecord(TagResult{
+		Service:      "ELB",
+		ResourceType: "target group",
+		ResourceID:   aws.ToString(tg.TargetGroupName),
+		ARN:          tgArn,
+	}, func() error {
+		_, err := client.AddTags(t.ctx, &elasticloadbalancingv2.AddTagsInput{
+			ResourceArns: []string{tgArn},
+			Tags:         t.convertToELBv2Tags(),
+		})
 		return err
-	}
-
-	log.Printf("Successfully tagged Target Group: %s", tgName)
-	return nil
+	})
 }
 
 // tagTargetGroupsWithClient tags target groups associated with ALB/NLB
 func (t *AWSResourceTagger) tagTargetGroupsWithClient(client ELBv2API, lbArn string) {
-	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{
-		LoadBalancerArn: aws.String(lbArn),
-	}
-
-	targetGroups, err := client.DescribeTargetGroups(t.ctx, input)
+	targetGroups, err := t.describeTargetGroups(client, lbArn)
 	if err != nil {
-		t.handleError(err, lbArn, "Target Groups")
 		return
 	}
 
-	for _, tg := range targetGroups.TargetGroups {
-		tgArn := aws.ToString(tg.TargetGroupArn)
-
-		_, err := client.AddTags(t.ctx, &elasticloadbalancingv2.AddTagsInput{
-			ResourceArns: []string{tgArn},
-			Tags:         t.convertToELBv2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(tg.TargetGroupName), "Target Group")
-			continue
-		}
-		log.Printf("Successfully tagged Target Group: %s", aws.ToString(tg.TargetGroupName))
+	for _, tg := range targetGroups {
+		t.tagTargetGroup(client, tg)
 	}
 }
 
 // Helper functions remain unchanged
 func (t *AWSResourceTagger) convertToClassicELBTags() []elbTypes.Tag {
 	elbTags := make([]elbTypes.Tag, 0, len(t.tags))
-	for k, v := range t.tags {
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
 		elbTags = append(elbTags, elbTypes.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
@@ -212,7 +236,8 @@ func (t *AWSResourceTagger) convertToClassicELBTags() []elbTypes.Tag {
 
 func (t *AWSResourceTagger) convertToELBv2Tags() []elbv2Types.Tag {
 	elbTags := make([]elbv2Types.Tag, 0, len(t.tags))
-	for k, v := range t.tags {
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
 		elbTags = append(elbTags, elbv2Types.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
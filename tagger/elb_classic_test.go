@@ -115,8 +115,8 @@ func TestTagClassicLoadBalancers(t *testing.T) {
 				})).Return(&elasticloadbalancing.AddTagsOutput{}, nil).Times(2)
 			},
 			expectLogs: []string{
-				"Successfully tagged Classic Load Balancer: classic-lb-1",
-				"Successfully tagged Classic Load Balancer: classic-lb-2",
+				"Successfully tagged ELB classic load balancer: classic-lb-1",
+				"Successfully tagged ELB classic load balancer: classic-lb-2",
 			},
 		},
 		{
@@ -145,7 +145,7 @@ func TestTagClassicLoadBalancers(t *testing.T) {
 					Return((*elasticloadbalancing.AddTagsOutput)(nil), fmt.Errorf("tagging error"))
 			},
 			expectLogs: []string{
-				"Error tagging Classic Load Balancer resource classic-lb-1: tagging error",
+				"Error tagging ELB classic load balancer resource classic-lb-1: tagging error",
 			},
 		},
 	}
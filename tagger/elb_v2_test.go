@@ -145,9 +145,9 @@ func TestTagApplicationAndNetworkLoadBalancers(t *testing.T) {
 				})).Return(&elasticloadbalancingv2.AddTagsOutput{}, nil)
 			},
 			expectLogs: []string{
-				"Successfully tagged application Load Balancer: alb-1",
-				"Successfully tagged network Load Balancer: nlb-1",
-				"Successfully tagged Target Group: tg-1",
+				"Successfully tagged ELB application load balancer: alb-1",
+				"Successfully tagged ELB network load balancer: nlb-1",
+				"Successfully tagged ELB target group: tg-1",
 			},
 		},
 		{
@@ -188,7 +188,7 @@ func TestTagApplicationAndNetworkLoadBalancers(t *testing.T) {
 				// No need to mock DescribeTargetGroups
 			},
 			expectLogs: []string{
-				"Error tagging ALB/NLB Load Balancer resource alb-1: tagging error",
+				"Error tagging ELB application load balancer resource arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/alb-1: tagging error",
 			},
 		},
 		{
@@ -219,7 +219,7 @@ func TestTagApplicationAndNetworkLoadBalancers(t *testing.T) {
 					Return((*elasticloadbalancingv2.DescribeTargetGroupsOutput)(nil), fmt.Errorf("API error"))
 			},
 			expectLogs: []string{
-				"Successfully tagged application Load Balancer: alb-1",
+				"Successfully tagged ELB application load balancer: alb-1",
 				"Error tagging Target Groups resource",
 			},
 		},
@@ -300,8 +300,8 @@ func TestTagTargetGroups(t *testing.T) {
 				})).Return(&elasticloadbalancingv2.AddTagsOutput{}, nil).Times(2)
 			},
 			expectLogs: []string{
-				"Successfully tagged Target Group: tg-1",
-				"Successfully tagged Target Group: tg-2",
+				"Successfully tagged ELB target group: tg-1",
+				"Successfully tagged ELB target group: tg-2",
 			},
 		},
 		{
@@ -333,7 +333,7 @@ func TestTagTargetGroups(t *testing.T) {
 					Return((*elasticloadbalancingv2.AddTagsOutput)(nil), fmt.Errorf("tagging error"))
 			},
 			expectLogs: []string{
-				"Error tagging Target Group resource tg-1: tagging error",
+				"Error tagging ELB target group resource arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1: tagging error",
 			},
 		},
 		{
@@ -383,3 +383,51 @@ func TestTagTargetGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestTagApplicationAndNetworkLoadBalancersPagination(t *testing.T) {
+	mockClient := new(MockELBv2Client)
+	mockClient.On("DescribeLoadBalancers", mock.Anything, &elasticloadbalancingv2.DescribeLoadBalancersInput{}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []elbv2Types.LoadBalancer{{LoadBalancerArn: aws.String("lb-1"), LoadBalancerName: aws.String("alb-1")}},
+			NextMarker:    aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("DescribeLoadBalancers", mock.Anything, &elasticloadbalancingv2.DescribeLoadBalancersInput{Marker: aws.String("page-2")}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []elbv2Types.LoadBalancer{{LoadBalancerArn: aws.String("lb-2"), LoadBalancerName: aws.String("alb-2")}},
+		}, nil).Once()
+
+	// lb-1 lists its target groups over two pages; tg-shared is also attached to lb-2
+	mockClient.On("DescribeTargetGroups", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String("lb-1"),
+	}).Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+		TargetGroups: []elbv2Types.TargetGroup{{TargetGroupArn: aws.String("tg-1"), TargetGroupName: aws.String("tg-1")}},
+		NextMarker:   aws.String("tg-page-2"),
+	}, nil).Once()
+	mockClient.On("DescribeTargetGroups", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String("lb-1"),
+		Marker:          aws.String("tg-page-2"),
+	}).Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+		TargetGroups: []elbv2Types.TargetGroup{{TargetGroupArn: aws.String("tg-shared"), TargetGroupName: aws.String("tg-shared")}},
+	}, nil).Once()
+	mockClient.On("DescribeTargetGroups", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String("lb-2"),
+	}).Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+		TargetGroups: []elbv2Types.TargetGroup{{TargetGroupArn: aws.String("tg-shared"), TargetGroupName: aws.String("tg-shared")}},
+	}, nil).Once()
+
+	var tagged []string
+	mockClient.On("AddTags", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			tagged = append(tagged, args.Get(1).(*elasticloadbalancingv2.AddTagsInput).ResourceArns...)
+		}).
+		Return(&elasticloadbalancingv2.AddTagsOutput{}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:  context.Background(),
+		tags: map[string]string{"Environment": "Test"},
+	}
+	tagger.tagApplicationAndNetworkLoadBalancersWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, []string{"lb-1", "tg-1", "tg-shared", "lb-2"}, tagged)
+}
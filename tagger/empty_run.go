@@ -0,0 +1,33 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrNoResourcesFound is returned when --error-on-empty is set and no service found any resource
+var ErrNoResourcesFound = errors.New("no resources found in any service")
+
+// WithErrorOnEmpty fails the run when no resource is discovered, which usually
+// means the wrong account or region was targeted
+func WithErrorOnEmpty(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.errorOnEmpty = enabled
+	}
+}
+
+// resourcesFound approximates the number of resources discovered: recorded
+// results, tag writes and dry-run writes. A resource may be counted more than
+// once, so it is only meaningful when compared with zero.
+func (t *AWSResourceTagger) resourcesFound() int64 {
+	return int64(len(t.Results())) + t.metrics.Writes() + atomic.LoadInt64(&t.dryRunCount)
+}
+
+// checkResourcesFound returns ErrNoResourcesFound for an empty run when --error-on-empty is set
+func (t *AWSResourceTagger) checkResourcesFound() error {
+	if !t.errorOnEmpty || t.resourcesFound() > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w in %s for account %s", ErrNoResourcesFound, t.regionLabel(), t.accountID)
+}
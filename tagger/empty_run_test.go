@@ -0,0 +1,72 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResourcesFound(t *testing.T) {
+	tests := []struct {
+		name         string
+		errorOnEmpty bool
+		setup        func(*AWSResourceTagger)
+		expectErr    bool
+	}{
+		{
+			name:         "Empty run fails when the flag is set",
+			errorOnEmpty: true,
+			setup:        func(*AWSResourceTagger) {},
+			expectErr:    true,
+		},
+		{
+			name:         "Empty run passes without the flag",
+			errorOnEmpty: false,
+			setup:        func(*AWSResourceTagger) {},
+		},
+		{
+			name:         "Recorded results count as found",
+			errorOnEmpty: true,
+			setup: func(tagger *AWSResourceTagger) {
+				tagger.recordResult(TagResult{Service: "Lambda", ResourceType: "function", ResourceID: "fn", Status: StatusSkipped})
+			},
+		},
+		{
+			name:         "Tag writes count as found",
+			errorOnEmpty: true,
+			setup: func(tagger *AWSResourceTagger) {
+				tagger.metrics.RecordWrite()
+			},
+		},
+		{
+			name:         "Dry-run writes count as found",
+			errorOnEmpty: true,
+			setup: func(tagger *AWSResourceTagger) {
+				tagger.dryRun = true
+				tagger.dryRunSkip("i-123")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &AWSResourceTagger{
+				ctx:       context.Background(),
+				accountID: "123456789012",
+				region:    "us-west-2",
+				results:   NewResultCollector(),
+				metrics:   NewMetricsCollector(),
+			}
+			WithErrorOnEmpty(tt.errorOnEmpty)(tagger)
+			tt.setup(tagger)
+
+			err := tagger.checkResourcesFound()
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrNoResourcesFound)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/emrserverless"
+)
+
+// EMRServerlessAPI interface for EMR Serverless client operations. EMR
+// Serverless uses the v1 SDK client built by newV1Session, like EFS.
+type EMRServerlessAPI interface {
+	ListApplicationsWithContext(ctx aws.Context, input *emrserverless.ListApplicationsInput, opts ...request.Option) (*emrserverless.ListApplicationsOutput, error)
+	TagResourceWithContext(ctx aws.Context, input *emrserverless.TagResourceInput, opts ...request.Option) (*emrserverless.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("EMRServerless", (*AWSResourceTagger).tagEMRServerlessResources)
+}
+
+// tagEMRServerlessResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagEMRServerlessResources() error {
+	sess, err := t.newV1Session(t.region)
+	if err != nil {
+		return fmt.Errorf("unable to create EMR Serverless session: %w", err)
+	}
+	t.tagEMRServerlessResourcesWithClient(emrserverless.New(sess))
+	return nil
+}
+
+// tagEMRServerlessResourcesWithClient tags EMR Serverless applications,
+// skipping terminated ones
+func (t *AWSResourceTagger) tagEMRServerlessResourcesWithClient(client EMRServerlessAPI) {
+	log.Println("Tagging EMR Serverless applications...")
+	defer log.Println("Completed tagging EMR Serverless applications")
+
+	input := &emrserverless.ListApplicationsInput{}
+	for {
+		output, err := client.ListApplicationsWithContext(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "EMR Serverless Applications")
+			return
+		}
+
+		for _, app := range output.Applications {
+			target := TagResult{
+				Service:      "EMRServerless",
+				ResourceType: "application",
+				ResourceID:   aws.StringValue(app.Id),
+				ARN:          aws.StringValue(app.Arn),
+			}
+			if aws.StringValue(app.State) == emrserverless.ApplicationStateTerminated {
+				t.skipResource(target, "application is terminated")
+				continue
+			}
+
+			t.applyAndRecord(target, func() error {
+				_, err := client.TagResourceWithContext(t.ctx, &emrserverless.TagResourceInput{
+					ResourceArn: app.Arn,
+					Tags:        aws.StringMap(t.tags),
+				})
+				return err
+			})
+		}
+
+		if output.NextToken == nil {
+			return
+		}
+		input.NextToken = output.NextToken
+	}
+}
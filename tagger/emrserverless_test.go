@@ -0,0 +1,90 @@
+package tagger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/emrserverless"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEMRServerlessClient is a mock implementation of EMRServerlessAPI
+type MockEMRServerlessClient struct {
+	mock.Mock
+}
+
+func (m *MockEMRServerlessClient) ListApplicationsWithContext(ctx aws.Context, input *emrserverless.ListApplicationsInput, opts ...request.Option) (*emrserverless.ListApplicationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emrserverless.ListApplicationsOutput), args.Error(1)
+}
+
+func (m *MockEMRServerlessClient) TagResourceWithContext(ctx aws.Context, input *emrserverless.TagResourceInput, opts ...request.Option) (*emrserverless.TagResourceOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emrserverless.TagResourceOutput), args.Error(1)
+}
+
+// emrServerlessApp builds an application summary in state
+func emrServerlessApp(id, state string) *emrserverless.ApplicationSummary {
+	return &emrserverless.ApplicationSummary{
+		Id:    aws.String(id),
+		Arn:   aws.String("arn:aws:emr-serverless:us-west-2:123456789012:/applications/" + id),
+		State: aws.String(state),
+	}
+}
+
+func TestTagEMRServerlessResources(t *testing.T) {
+	mockClient := new(MockEMRServerlessClient)
+	mockClient.On("ListApplicationsWithContext", mock.Anything, &emrserverless.ListApplicationsInput{}).
+		Return(&emrserverless.ListApplicationsOutput{
+			Applications: []*emrserverless.ApplicationSummary{
+				emrServerlessApp("00f1spark", emrserverless.ApplicationStateStarted),
+				emrServerlessApp("00f1old", emrserverless.ApplicationStateTerminated),
+			},
+			NextToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("ListApplicationsWithContext", mock.Anything, &emrserverless.ListApplicationsInput{NextToken: aws.String("page-2")}).
+		Return(&emrserverless.ListApplicationsOutput{
+			Applications: []*emrserverless.ApplicationSummary{
+				emrServerlessApp("00f1hive", emrserverless.ApplicationStateStopped),
+			},
+		}, nil).Once()
+	mockClient.On("TagResourceWithContext", mock.Anything, &emrserverless.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:emr-serverless:us-west-2:123456789012:/applications/00f1spark"),
+		Tags:        aws.StringMap(map[string]string{"Environment": "Test", "Project": "UnitTest"}),
+	}).Return(&emrserverless.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResourceWithContext", mock.Anything, mock.MatchedBy(func(input *emrserverless.TagResourceInput) bool {
+		return aws.StringValue(input.ResourceArn) == "arn:aws:emr-serverless:us-west-2:123456789012:/applications/00f1hive"
+	})).Return(nil, errors.New("AccessDeniedException")).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagEMRServerlessResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	results := tagger.Results()
+	assert.Len(t, results, 3)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, StatusSkipped, results[1].Status)
+	assert.Equal(t, "00f1old", results[1].ResourceID)
+	assert.Equal(t, StatusFailed, results[2].Status)
+}
+
+func TestTagEMRServerlessResourcesListError(t *testing.T) {
+	mockClient := new(MockEMRServerlessClient)
+	mockClient.On("ListApplicationsWithContext", mock.Anything, mock.Anything).Return(nil, errors.New("API error"))
+
+	tagger := createTestTagger()
+	tagger.tagEMRServerlessResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TagResourceWithContext", mock.Anything, mock.Anything)
+}
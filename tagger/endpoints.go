@@ -0,0 +1,30 @@
+package tagger
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// WithFIPSEndpoints sends every API call to the service's FIPS endpoint in the
+// region; services without one fail to resolve and are reported as unavailable.
+// Endpoint overrides from AWS_ENDPOINT_URL_<SERVICE> or the shared config file
+// take precedence, which is how private VPC endpoints without private DNS are
+// reached. There is no --endpoint-url flag because one URL cannot serve every
+// service. VPC endpoints with private DNS need no setting at all.
+func WithFIPSEndpoints(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.useFIPS = enabled
+	}
+}
+
+// configLoadOptions returns the SDK config options for profile in the tagger's region
+func (t *AWSResourceTagger) configLoadOptions(profile string) []func(*config.LoadOptions) error {
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(t.region),
+		config.WithSharedConfigProfile(profile),
+	}
+	if t.useFIPS {
+		opts = append(opts, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	return opts
+}
@@ -0,0 +1,58 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/emrserverless"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadOptions applies the tagger's config options to an empty LoadOptions
+func loadOptions(t *testing.T, tagger *AWSResourceTagger) config.LoadOptions {
+	t.Helper()
+	var options config.LoadOptions
+	for _, opt := range tagger.configLoadOptions("prod") {
+		require.NoError(t, opt(&options))
+	}
+	return options
+}
+
+func TestConfigLoadOptionsRequestFIPSEndpoints(t *testing.T) {
+	tagger := &AWSResourceTagger{region: "us-gov-west-1"}
+	WithFIPSEndpoints(true)(tagger)
+
+	options := loadOptions(t, tagger)
+
+	assert.Equal(t, aws.FIPSEndpointStateEnabled, options.UseFIPSEndpoint)
+	assert.Equal(t, "us-gov-west-1", options.Region)
+	assert.Equal(t, "prod", options.SharedConfigProfile)
+}
+
+func TestConfigLoadOptionsLeaveFIPSUnsetByDefault(t *testing.T) {
+	options := loadOptions(t, &AWSResourceTagger{region: "us-east-1"})
+
+	assert.Equal(t, aws.FIPSEndpointStateUnset, options.UseFIPSEndpoint)
+}
+
+func TestV1ClientsUseFIPSEndpoints(t *testing.T) {
+	tagger := &AWSResourceTagger{region: "us-east-1"}
+	WithFIPSEndpoints(true)(tagger)
+
+	sess, err := tagger.newV1Session(tagger.region)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://elasticfilesystem-fips.us-east-1.amazonaws.com", efs.New(sess).Endpoint)
+	assert.Equal(t, "https://emr-serverless-fips.us-east-1.amazonaws.com", emrserverless.New(sess).Endpoint)
+}
+
+func TestV1ClientsLeaveFIPSUnsetByDefault(t *testing.T) {
+	sess, err := (&AWSResourceTagger{region: "us-east-1"}).newV1Session("us-east-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://elasticfilesystem.us-east-1.amazonaws.com", efs.New(sess).Endpoint)
+	assert.Equal(t, "https://emr-serverless.us-east-1.amazonaws.com", emrserverless.New(sess).Endpoint)
+}
@@ -0,0 +1,76 @@
+package tagger
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// WithEnsureKeys adds each key with its default value only to resources that
+// do not carry the key at all, never overwriting an existing value. It applies
+// where existing tags are read: EC2 instances, volumes, snapshots and AMIs,
+// RDS resources and S3 buckets. Keys also set as regular tags are overwritten.
+func WithEnsureKeys(defaults map[string]string) Option {
+	return func(t *AWSResourceTagger) {
+		t.ensureKeys = defaults
+	}
+}
+
+// missingEnsureKeys returns the ensured keys absent from both the existing
+// keys and the configured tags, in key order
+func (t *AWSResourceTagger) missingEnsureKeys(existing []string) []string {
+	if len(t.ensureKeys) == 0 {
+		return nil
+	}
+	present := make(map[string]bool, len(existing))
+	for _, k := range existing {
+		present[k] = true
+	}
+
+	var missing []string
+	for _, k := range sortedTagKeys(t.ensureKeys) {
+		if _, configured := t.tags[k]; !configured && !present[k] {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// tagsFor returns the tags written to a resource that carries the existing
+// keys: the configured tags plus the ensured keys it lacks
+func (t *AWSResourceTagger) tagsFor(existing []string) map[string]string {
+	missing := t.missingEnsureKeys(existing)
+	if len(missing) == 0 {
+		return t.tags
+	}
+	tags := make(map[string]string, len(t.tags)+len(missing))
+	for k, v := range t.tags {
+		tags[k] = v
+	}
+	for _, k := range missing {
+		tags[k] = t.ensureKeys[k]
+	}
+	return tags
+}
+
+// ec2TagsFor returns the EC2 tags to write to a resource that carries existing
+func (t *AWSResourceTagger) ec2TagsFor(existing []types.Tag) []types.Tag {
+	missing := t.missingEnsureKeys(ec2TagKeys(existing))
+	if len(missing) == 0 {
+		return t.awsTags
+	}
+	tags := append(make([]types.Tag, 0, len(t.awsTags)+len(missing)), t.awsTags...)
+	for _, k := range missing {
+		tags = append(tags, types.Tag{Key: aws.String(k), Value: aws.String(t.ensureKeys[k])})
+	}
+	return tags
+}
+
+// rdsTagsFor returns the RDS tags to write to a resource that carries existing
+func (t *AWSResourceTagger) rdsTagsFor(existing []rdstypes.Tag) []rdstypes.Tag {
+	tags := t.convertToRDSTags()
+	for _, k := range t.missingEnsureKeys(rdsTagKeys(existing)) {
+		tags = append(tags, rdstypes.Tag{Key: aws.String(k), Value: aws.String(t.ensureKeys[k])})
+	}
+	return tags
+}
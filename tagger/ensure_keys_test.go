@@ -0,0 +1,102 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnsureKeysOnlyFillMissingEC2Keys(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{
+				Instances: []ec2types.Instance{
+					{InstanceId: aws.String("i-owned"), Tags: []ec2types.Tag{{Key: aws.String("owner"), Value: aws.String("alice")}}},
+					{InstanceId: aws.String("i-orphan")},
+				},
+			}},
+		}, nil).Once()
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeVolumesOutput{}, nil).Once()
+	expectNoSnapshotsOrImages(mockClient)
+
+	written := map[string]map[string]string{}
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			input := args.Get(1).(*ec2.CreateTagsInput)
+			tags := map[string]string{}
+			for _, tag := range input.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			written[input.Resources[0]] = tags
+		}).
+		Return(&ec2.CreateTagsOutput{}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:        context.Background(),
+		tags:       map[string]string{"env": "prod"},
+		awsTags:    []ec2types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		ensureKeys: map[string]string{"owner": "unassigned"},
+	}
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	assert.Equal(t, map[string]string{"env": "prod"}, written["i-owned"])
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "unassigned"}, written["i-orphan"])
+}
+
+func TestEnsureKeysDoNotOverwriteExistingBucketTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []s3types.Tag
+		expected map[string]string
+	}{
+		{
+			name:     "Present key is untouched",
+			existing: []s3types.Tag{{Key: aws.String("owner"), Value: aws.String("alice")}},
+			expected: map[string]string{"owner": "alice", "env": "prod"},
+		},
+		{
+			name:     "Absent key gets the default",
+			expected: map[string]string{"owner": "unassigned", "env": "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockS3Client)
+			mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).Return(&s3.GetBucketLocationOutput{}, nil)
+			mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).
+				Return(&s3.GetBucketTaggingOutput{TagSet: tt.existing}, nil)
+			mockClient.On("PutBucketTagging", mock.Anything, mock.MatchedBy(matchS3TagsInput(&s3.PutBucketTaggingInput{
+				Bucket:  aws.String("team-bucket"),
+				Tagging: &s3types.Tagging{TagSet: convertToS3Tags(tt.expected)},
+			}))).Return(&s3.PutBucketTaggingOutput{}, nil)
+
+			tagger := &AWSResourceTagger{
+				ctx:        context.Background(),
+				tags:       map[string]string{"env": "prod"},
+				ensureKeys: map[string]string{"owner": "unassigned"},
+			}
+
+			assert.NoError(t, tagger.tagBucket(mockClient, "team-bucket"))
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMissingEnsureKeysDefersToConfiguredTags(t *testing.T) {
+	tagger := &AWSResourceTagger{
+		tags:       map[string]string{"env": "prod"},
+		ensureKeys: map[string]string{"env": "dev", "team": "platform", "owner": "unassigned"},
+	}
+
+	assert.Equal(t, []string{"team"}, tagger.missingEnsureKeys([]string{"owner"}))
+}
@@ -0,0 +1,87 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+)
+
+// globalAcceleratorRegion is the only region serving the Global Accelerator control plane
+const globalAcceleratorRegion = "us-west-2"
+
+// GlobalAcceleratorAPI interface for Global Accelerator client operations. Global
+// Accelerator uses the v1 SDK client built by newV1Session, like EFS.
+type GlobalAcceleratorAPI interface {
+	ListAcceleratorsWithContext(ctx aws.Context, input *globalaccelerator.ListAcceleratorsInput, opts ...request.Option) (*globalaccelerator.ListAcceleratorsOutput, error)
+	TagResourceWithContext(ctx aws.Context, input *globalaccelerator.TagResourceInput, opts ...request.Option) (*globalaccelerator.TagResourceOutput, error)
+}
+
+func init() {
+	registerGlobalService("GlobalAccelerator", (*AWSResourceTagger).tagGlobalAcceleratorResources)
+}
+
+// tagGlobalAcceleratorResources is the main entry point that creates and uses the client.
+// Accelerators are global, so every call goes through the us-west-2 endpoint.
+func (t *AWSResourceTagger) tagGlobalAcceleratorResources() error {
+	sess, err := t.newV1Session(globalAcceleratorRegion)
+	if err != nil {
+		return fmt.Errorf("unable to create Global Accelerator session: %w", err)
+	}
+	t.tagGlobalAcceleratorResourcesWithClient(globalaccelerator.New(sess))
+	return nil
+}
+
+// tagGlobalAcceleratorResourcesWithClient tags every accelerator with a provided client.
+// Listeners and endpoint groups do not support tags and are covered by their accelerator.
+func (t *AWSResourceTagger) tagGlobalAcceleratorResourcesWithClient(client GlobalAcceleratorAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging Global Accelerator resources...")
+	defer log.Println("Completed tagging Global Accelerator resources")
+
+	input := &globalaccelerator.ListAcceleratorsInput{}
+	for {
+		output, err := client.ListAcceleratorsWithContext(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Global Accelerator accelerators")
+			return
+		}
+
+		for _, accelerator := range output.Accelerators {
+			arn := aws.StringValue(accelerator.AcceleratorArn)
+			target := TagResult{
+				Service:      "GlobalAccelerator",
+				ResourceType: "accelerator",
+				ResourceID:   arn[strings.LastIndex(arn, "/")+1:],
+				ARN:          arn,
+			}
+			t.applyAndRecord(target, func() error {
+				_, err := client.TagResourceWithContext(t.ctx, &globalaccelerator.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.globalAcceleratorTags(),
+				})
+				return err
+			})
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// globalAcceleratorTags converts the configured tags to Global Accelerator tags
+func (t *AWSResourceTagger) globalAcceleratorTags() []*globalaccelerator.Tag {
+	tags := make([]*globalaccelerator.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		tags = append(tags, &globalaccelerator.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,111 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGlobalAcceleratorClient is a mock implementation of GlobalAcceleratorAPI
+type MockGlobalAcceleratorClient struct {
+	mock.Mock
+}
+
+func (m *MockGlobalAcceleratorClient) ListAcceleratorsWithContext(ctx aws.Context, input *globalaccelerator.ListAcceleratorsInput, opts ...request.Option) (*globalaccelerator.ListAcceleratorsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*globalaccelerator.ListAcceleratorsOutput), args.Error(1)
+}
+
+func (m *MockGlobalAcceleratorClient) TagResourceWithContext(ctx aws.Context, input *globalaccelerator.TagResourceInput, opts ...request.Option) (*globalaccelerator.TagResourceOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*globalaccelerator.TagResourceOutput), args.Error(1)
+}
+
+func TestTagGlobalAcceleratorResourcesWithClient(t *testing.T) {
+	const (
+		untaggedARN = "arn:aws:globalaccelerator::123456789012:accelerator/1234abcd-abcd-1234-abcd-1234abcdefgh"
+		secondARN   = "arn:aws:globalaccelerator::123456789012:accelerator/5678efgh-abcd-1234-abcd-1234abcdefgh"
+	)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockGlobalAcceleratorClient)
+		expectStatuses map[string]string
+		expectLog      string
+	}{
+		{
+			// ListAccelerators finds accelerators that carry no tags at all,
+			// which the Resource Groups Tagging API never returns
+			name: "Tag accelerators that start without tags",
+			setupMock: func(m *MockGlobalAcceleratorClient) {
+				m.On("ListAcceleratorsWithContext", mock.Anything, &globalaccelerator.ListAcceleratorsInput{}).
+					Return(&globalaccelerator.ListAcceleratorsOutput{
+						Accelerators: []*globalaccelerator.Accelerator{{AcceleratorArn: aws.String(untaggedARN), Name: aws.String("edge")}},
+						NextToken:    aws.String("page-2"),
+					}, nil).Once()
+				m.On("ListAcceleratorsWithContext", mock.Anything, &globalaccelerator.ListAcceleratorsInput{NextToken: aws.String("page-2")}).
+					Return(&globalaccelerator.ListAcceleratorsOutput{
+						Accelerators: []*globalaccelerator.Accelerator{{AcceleratorArn: aws.String(secondARN), Name: aws.String("api")}},
+					}, nil).Once()
+				m.On("TagResourceWithContext", mock.Anything, &globalaccelerator.TagResourceInput{
+					ResourceArn: aws.String(untaggedARN),
+					Tags:        []*globalaccelerator.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+				}).Return(&globalaccelerator.TagResourceOutput{}, nil).Once()
+				m.On("TagResourceWithContext", mock.Anything, mock.MatchedBy(func(input *globalaccelerator.TagResourceInput) bool {
+					return aws.StringValue(input.ResourceArn) == secondARN
+				})).Return(nil, errors.New("AccessDenied")).Once()
+			},
+			expectStatuses: map[string]string{untaggedARN: StatusTagged, secondARN: StatusFailed},
+			expectLog:      "Successfully tagged GlobalAccelerator accelerator: 1234abcd-abcd-1234-abcd-1234abcdefgh",
+		},
+		{
+			name: "Listing error is logged",
+			setupMock: func(m *MockGlobalAcceleratorClient) {
+				m.On("ListAcceleratorsWithContext", mock.Anything, mock.Anything).Return(nil, errors.New("API error"))
+			},
+			expectStatuses: map[string]string{},
+			expectLog:      "Error tagging Global Accelerator accelerators resource all",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			mockClient := new(MockGlobalAcceleratorClient)
+			tt.setupMock(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:     context.Background(),
+				tags:    map[string]string{"env": "prod"},
+				results: NewResultCollector(),
+			}
+			tagger.tagGlobalAcceleratorResourcesWithClient(mockClient)
+
+			mockClient.AssertExpectations(t)
+			assert.Contains(t, logBuffer.String(), tt.expectLog)
+			statuses := map[string]string{}
+			for _, result := range tagger.Results() {
+				statuses[result.ARN] = result.Status
+			}
+			assert.Equal(t, tt.expectStatuses, statuses)
+		})
+	}
+}
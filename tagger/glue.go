@@ -3,32 +3,97 @@ package tagger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync/atomic"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 )
 
 // GlueMetrics struct extension
 type GlueMetrics struct {
-	DatabasesFound    int32
-	DatabasesTagged   int32
-	DatabasesFailed   int32
-	ConnectionsFound  int32
-	ConnectionsTagged int32
-	ConnectionsFailed int32
-	JobsFound         int32
-	JobsTagged        int32
-	JobsFailed        int32
-	CrawlersFound     int32
-	CrawlersTagged    int32
-	CrawlersFailed    int32
-	TriggersFound     int32
-	TriggersTagged    int32
-	TriggersFailed    int32
+	DatabasesFound      int32
+	DatabasesTagged     int32
+	DatabasesFailed     int32
+	DatabasesWouldTag   int32
+	DatabasesSkipped    int32
+	ConnectionsFound    int32
+	ConnectionsTagged   int32
+	ConnectionsFailed   int32
+	ConnectionsWouldTag int32
+	ConnectionsSkipped  int32
+	JobsFound           int32
+	JobsTagged          int32
+	JobsFailed          int32
+	JobsWouldTag        int32
+	JobsSkipped         int32
+	CrawlersFound       int32
+	CrawlersTagged      int32
+	CrawlersFailed      int32
+	CrawlersWouldTag    int32
+	CrawlersSkipped     int32
+	TriggersFound       int32
+	TriggersTagged      int32
+	TriggersFailed      int32
+	TriggersWouldTag    int32
+	TriggersSkipped     int32
+	ProfilesFound       int32
+	ProfilesTagged      int32
+	ProfilesFailed      int32
+	ProfilesWouldTag    int32
+	ProfilesSkipped     int32
+	WorkflowsFound      int32
+	WorkflowsTagged     int32
+	WorkflowsFailed     int32
+	WorkflowsWouldTag   int32
+	WorkflowsSkipped    int32
+}
+
+// errGlueSkipped is returned by the single-resource Glue taggers when the
+// resource already has every tag or is in the checkpoint; the skip has been
+// recorded already
+var errGlueSkipped = errors.New("glue resource already tagged")
+
+// Glue sub-resource kinds selectable with WithGlueResources
+const (
+	GlueDatabases     = "databases"
+	GlueConnections   = "connections"
+	GlueCrawlers      = "crawlers"
+	GlueJobs          = "jobs"
+	GlueTriggers      = "triggers"
+	GlueWorkflows     = "workflows"
+	GlueUsageProfiles = "usage-profiles"
+)
+
+// glueResourceTypes lists every Glue sub-resource kind in tagging order
+var glueResourceTypes = []string{GlueDatabases, GlueConnections, GlueCrawlers, GlueJobs, GlueTriggers, GlueWorkflows, GlueUsageProfiles}
+
+// WithGlueResources limits Glue tagging to the listed sub-resource kinds; empty tags every kind
+func WithGlueResources(kinds []string) Option {
+	return func(t *AWSResourceTagger) {
+		t.glueResources = lowerSet(kinds)
+	}
+}
+
+// ValidateGlueResources reports the first kind that is not a Glue sub-resource kind
+func ValidateGlueResources(kinds []string) error {
+	known := lowerSet(glueResourceTypes)
+	for _, kind := range kinds {
+		if !known[strings.ToLower(kind)] {
+			return fmt.Errorf("unknown Glue resource type %q; valid types are %s", kind, strings.Join(glueResourceTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// glueResourceEnabled reports whether kind passes the --glue-resources filter
+func (t *AWSResourceTagger) glueResourceEnabled(kind string) bool {
+	return len(t.glueResources) == 0 || t.glueResources[kind]
 }
 
 // GlueAPI interface for Glue client operations
@@ -39,16 +104,44 @@ type GlueAPI interface {
 	GetJobs(ctx context.Context, params *glue.GetJobsInput, optFns ...func(*glue.Options)) (*glue.GetJobsOutput, error)
 	GetCrawlers(ctx context.Context, params *glue.GetCrawlersInput, optFns ...func(*glue.Options)) (*glue.GetCrawlersOutput, error)
 	GetTriggers(ctx context.Context, params *glue.GetTriggersInput, optFns ...func(*glue.Options)) (*glue.GetTriggersOutput, error)
+	ListUsageProfiles(ctx context.Context, params *glue.ListUsageProfilesInput, optFns ...func(*glue.Options)) (*glue.ListUsageProfilesOutput, error)
+	GetTags(ctx context.Context, params *glue.GetTagsInput, optFns ...func(*glue.Options)) (*glue.GetTagsOutput, error)
+	ListWorkflows(ctx context.Context, params *glue.ListWorkflowsInput, optFns ...func(*glue.Options)) (*glue.ListWorkflowsOutput, error)
+}
+
+func init() {
+	registerService("Glue", (*AWSResourceTagger).tagGlueResources)
 }
 
 // tagGlueResources is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagGlueResources() {
-	client := glue.NewFromConfig(t.cfg)
-	t.tagGlueResourcesWithClient(client)
+func (t *AWSResourceTagger) tagGlueResources() error {
+	client := t.newGlueClient()
+	if t.useTaggingAPI {
+		t.loadGlueTaggedARNs(resourcegroupstaggingapi.NewFromConfig(t.glueConfig()))
+	}
+	return t.tagGlueResourcesWithClient(client)
 }
 
-// tagGlueResourcesWithClient handles the actual tagging logic with a provided client
-func (t *AWSResourceTagger) tagGlueResourcesWithClient(client GlueAPI) {
+// loadGlueTaggedARNs finds Glue resources that already carry all tags
+func (t *AWSResourceTagger) loadGlueTaggedARNs(client ResourceGroupsTaggingAPI) {
+	typeFilters := []string{
+		GlueDatabase.Service + ":" + GlueDatabase.Type,
+		GlueConnection.Service + ":" + GlueConnection.Type,
+		GlueCrawler.Service + ":" + GlueCrawler.Type,
+		GlueJob.Service + ":" + GlueJob.Type,
+		GlueTrigger.Service + ":" + GlueTrigger.Type,
+		GlueUsageProfile.Service + ":" + GlueUsageProfile.Type,
+		GlueWorkflow.Service + ":" + GlueWorkflow.Type,
+	}
+	if err := t.loadTaggedARNs(client, typeFilters); err != nil {
+		// Fall back to tagging every resource
+		t.handleError(err, "all", "Glue tagged resources")
+	}
+}
+
+// tagGlueResourcesWithClient handles the actual tagging logic with a provided
+// client, returning an error summarizing the resources that failed to tag
+func (t *AWSResourceTagger) tagGlueResourcesWithClient(client GlueAPI) error {
 	log.Println("Tagging Glue resources...")
 
 	metrics := &GlueMetrics{}
@@ -57,51 +150,135 @@ func (t *AWSResourceTagger) tagGlueResourcesWithClient(client GlueAPI) {
 	if err := t.validateTags(); err != nil {
 		log.Printf("Error: Invalid tags configuration: %v", err)
 		log.Println("Completed tagging Glue resources")
-		return
+		return fmt.Errorf("invalid tags configuration: %w", err)
+	}
+
+	// Record successful writes so they can be read back afterwards
+	var recorder *glueWriteRecorder
+	tagClient := client
+	if t.verify {
+		recorder = &glueWriteRecorder{GlueAPI: client}
+		tagClient = recorder
 	}
 
 	// Tag all supported Glue resource types
-	t.tagGlueDatabases(client, metrics)
-	t.tagGlueConnections(client, metrics)
-	t.tagGlueCrawlers(client, metrics)
-	t.tagGlueJobs(client, metrics)
-	t.tagGlueTriggers(client, metrics)
+	steps := []struct {
+		kind string
+		tag  func(GlueAPI, *GlueMetrics)
+	}{
+		{GlueDatabases, t.tagGlueDatabases},
+		{GlueConnections, t.tagGlueConnections},
+		{GlueCrawlers, t.tagGlueCrawlers},
+		{GlueJobs, t.tagGlueJobs},
+		{GlueTriggers, t.tagGlueTriggers},
+		{GlueWorkflows, t.tagGlueWorkflows},
+		{GlueUsageProfiles, t.tagGlueUsageProfiles},
+	}
+	for _, step := range steps {
+		if !t.glueResourceEnabled(step.kind) {
+			log.Printf("Skipping Glue %s (not selected by --glue-resources)", step.kind)
+			continue
+		}
+		step.tag(tagClient, metrics)
+	}
+
+	var verifyErr error
+	if recorder != nil {
+		if mismatched := t.verifyGlueTags(client, recorder.tagged()); mismatched > 0 {
+			atomic.AddInt64(&t.verifyMismatches, int64(mismatched))
+			verifyErr = fmt.Errorf("%d Glue resources failed tag verification", mismatched)
+		}
+	}
 
 	log.Println("Completed tagging Glue resources")
+	return errors.Join(metrics.failures(), verifyErr)
+}
+
+// failures summarizes the Failed counters as an error, or nil when nothing failed
+func (m *GlueMetrics) failures() error {
+	counts := []struct {
+		kind   string
+		failed *int32
+	}{
+		{"databases", &m.DatabasesFailed},
+		{"connections", &m.ConnectionsFailed},
+		{"crawlers", &m.CrawlersFailed},
+		{"jobs", &m.JobsFailed},
+		{"triggers", &m.TriggersFailed},
+		{"workflows", &m.WorkflowsFailed},
+		{"usage profiles", &m.ProfilesFailed},
+	}
+	var failed []string
+	for _, c := range counts {
+		if n := atomic.LoadInt32(c.failed); n > 0 {
+			failed = append(failed, fmt.Sprintf("%d %s", n, c.kind))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to tag Glue resources: %s", strings.Join(failed, ", "))
 }
 
 // tagGlueDatabases tags Glue databases (skipping tables since they're not taggable)
+// in every configured catalog
 func (t *AWSResourceTagger) tagGlueDatabases(client GlueAPI, metrics *GlueMetrics) {
-	databases, err := client.GetDatabases(t.ctx, &glue.GetDatabasesInput{})
+	for _, catalogID := range t.glueCatalogs() {
+		t.tagGlueDatabasesInCatalog(client, metrics, catalogID)
+	}
+
+	log.Printf("Databases: Found: %d, Tagged: %d, Failed: %d, Would tag: %d",
+		metrics.DatabasesFound, metrics.DatabasesTagged, metrics.DatabasesFailed, metrics.DatabasesWouldTag)
+}
+
+// tagGlueDatabasesInCatalog tags the databases of a single catalog; an empty
+// catalog ID selects the account's default catalog
+func (t *AWSResourceTagger) tagGlueDatabasesInCatalog(client GlueAPI, metrics *GlueMetrics, catalogID string) {
+	input := &glue.GetDatabasesInput{}
+	if catalogID != "" {
+		input.CatalogId = aws.String(catalogID)
+	}
+	databases, err := client.GetDatabases(t.ctx, input)
 	if err != nil {
-		t.handleError(err, "all", "Glue Databases")
+		t.handleError(err, glueCatalogLabel(catalogID), "Glue Databases")
 		return
 	}
 
-	atomic.StoreInt32(&metrics.DatabasesFound, int32(len(databases.DatabaseList)))
-	log.Printf("Found %d Glue databases to tag", metrics.DatabasesFound)
+	atomic.AddInt32(&metrics.DatabasesFound, int32(len(databases.DatabaseList)))
+	log.Printf("Found %d Glue databases to tag in %s", len(databases.DatabaseList), glueCatalogLabel(catalogID))
 
 	for _, db := range databases.DatabaseList {
 		dbName := aws.ToString(db.Name)
-		if err := t.tagDatabase(client, dbName); err != nil {
-			log.Printf("Error processing database %s: %v", dbName, err)
-			continue
+		err := t.tagDatabase(client, catalogID, dbName)
+		t.recordGlueResult(GlueDatabase, dbName, t.buildCatalogARN(GlueDatabase, catalogID, dbName), err)
+		switch {
+		case errors.Is(err, errGlueSkipped):
+			atomic.AddInt32(&metrics.DatabasesSkipped, 1)
+		case err != nil:
+			atomic.AddInt32(&metrics.DatabasesFailed, 1)
+		default:
+			t.countTagged(&metrics.DatabasesTagged, &metrics.DatabasesWouldTag)
 		}
-		atomic.AddInt32(&metrics.DatabasesTagged, 1)
 	}
-
-	log.Printf("Databases: Found: %d, Tagged: %d, Failed: %d",
-		metrics.DatabasesFound, metrics.DatabasesTagged, metrics.DatabasesFailed)
 }
 
 // tagDatabase tags a single Glue database
-func (t *AWSResourceTagger) tagDatabase(client GlueAPI, dbName string) error {
-	resourceArn := t.buildCompoundARN(GlueDatabase, dbName)
+func (t *AWSResourceTagger) tagDatabase(client GlueAPI, catalogID, dbName string) error {
+	resourceArn := t.buildCatalogARN(GlueDatabase, catalogID, dbName)
 	log.Printf("database ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueDatabase, dbName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
 
-	_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
-		ResourceArn: aws.String(resourceArn),
-		TagsToAdd:   t.convertToGlueTags(),
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("error tagging database %s: %w", dbName, err)
@@ -111,49 +288,111 @@ func (t *AWSResourceTagger) tagDatabase(client GlueAPI, dbName string) error {
 	return nil
 }
 
+// recordGlueResult records the outcome of tagging one Glue resource; resources
+// skipped with errGlueSkipped have been recorded already. Failures are counted
+// through handleError like every other service's.
+func (t *AWSResourceTagger) recordGlueResult(resourceType ResourceType, name, arn string, err error) {
+	result := glueTarget(resourceType, name, arn)
+	result.Status = t.taggedStatus()
+	if errors.Is(err, errGlueSkipped) {
+		return
+	}
+	if err != nil {
+		t.handleError(err, name, "Glue "+resourceType.Type)
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	t.recordResult(result)
+}
+
+// glueTarget describes a Glue resource for results
+func glueTarget(resourceType ResourceType, name, arn string) TagResult {
+	return TagResult{Service: "Glue", ResourceType: resourceType.Type, ResourceID: name, ARN: arn}
+}
+
+// glueCatalogs returns the catalog IDs to discover; an empty ID stands for the default catalog
+func (t *AWSResourceTagger) glueCatalogs() []string {
+	if len(t.glueCatalogIDs) == 0 {
+		return []string{""}
+	}
+	return t.glueCatalogIDs
+}
+
+// glueCatalogLabel names a catalog in log messages
+func glueCatalogLabel(catalogID string) string {
+	if catalogID == "" {
+		return "default catalog"
+	}
+	return "catalog " + catalogID
+}
+
 // convertToGlueTags converts the common tags map to Glue-specific tags
 func (t *AWSResourceTagger) convertToGlueTags() map[string]string {
 	return t.tags
 }
 
-// tagGlueConnections tags AWS Glue connections with metrics
+// tagGlueConnections tags AWS Glue connections with metrics in every configured catalog
 func (t *AWSResourceTagger) tagGlueConnections(client GlueAPI, metrics *GlueMetrics) {
 	log.Println("Tagging Glue connections...")
 
-	connections, err := client.GetConnections(t.ctx, &glue.GetConnectionsInput{})
+	for _, catalogID := range t.glueCatalogs() {
+		t.tagGlueConnectionsInCatalog(client, metrics, catalogID)
+	}
+
+	log.Printf("Connections: Found: %d, Tagged: %d, Failed: %d, Would tag: %d",
+		metrics.ConnectionsFound, metrics.ConnectionsTagged, metrics.ConnectionsFailed, metrics.ConnectionsWouldTag)
+}
+
+// tagGlueConnectionsInCatalog tags the connections of a single catalog
+func (t *AWSResourceTagger) tagGlueConnectionsInCatalog(client GlueAPI, metrics *GlueMetrics, catalogID string) {
+	input := &glue.GetConnectionsInput{}
+	if catalogID != "" {
+		input.CatalogId = aws.String(catalogID)
+	}
+	connections, err := client.GetConnections(t.ctx, input)
 	if err != nil {
-		t.handleError(err, "all", "Glue Connections")
+		t.handleError(err, glueCatalogLabel(catalogID), "Glue Connections")
 		return
 	}
 
-	atomic.StoreInt32(&metrics.ConnectionsFound, int32(len(connections.ConnectionList)))
-	log.Printf("Found %d Glue connections to tag", metrics.ConnectionsFound)
+	atomic.AddInt32(&metrics.ConnectionsFound, int32(len(connections.ConnectionList)))
+	log.Printf("Found %d Glue connections to tag in %s", len(connections.ConnectionList), glueCatalogLabel(catalogID))
 
 	for _, conn := range connections.ConnectionList {
-		if err := t.tagConnection(client, conn); err != nil {
-			log.Printf("Error tagging connection %s: %v", aws.ToString(conn.Name), err)
+		err := t.tagConnection(client, catalogID, conn)
+		t.recordGlueResult(GlueConnection, aws.ToString(conn.Name), t.buildCatalogARN(GlueConnection, catalogID, aws.ToString(conn.Name)), err)
+		switch {
+		case errors.Is(err, errGlueSkipped):
+			atomic.AddInt32(&metrics.ConnectionsSkipped, 1)
+		case err != nil:
 			atomic.AddInt32(&metrics.ConnectionsFailed, 1)
-			continue
+		default:
+			t.countTagged(&metrics.ConnectionsTagged, &metrics.ConnectionsWouldTag)
 		}
-		atomic.AddInt32(&metrics.ConnectionsTagged, 1)
 	}
-
-	log.Printf("Connections: Found: %d, Tagged: %d, Failed: %d",
-		metrics.ConnectionsFound, metrics.ConnectionsTagged, metrics.ConnectionsFailed)
 }
 
 // tagConnection tags a single Glue connection
-func (t *AWSResourceTagger) tagConnection(client GlueAPI, conn gluetypes.Connection) error {
+func (t *AWSResourceTagger) tagConnection(client GlueAPI, catalogID string, conn gluetypes.Connection) error {
 	connName := aws.ToString(conn.Name)
 
 	// Build connection ARN using the predefined pattern
-	resourceArn := t.buildCompoundARN(GlueConnection, connName)
+	resourceArn := t.buildCatalogARN(GlueConnection, catalogID, connName)
 	log.Printf("Connection ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueConnection, connName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
 
 	// Apply tags
-	_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
-		ResourceArn: aws.String(resourceArn),
-		TagsToAdd:   t.convertToGlueTags(),
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("error tagging connection %s: %w", connName, err)
@@ -189,12 +428,16 @@ func (t *AWSResourceTagger) tagGlueJobs(client GlueAPI, metrics *GlueMetrics) {
 		log.Printf("Found %d Glue jobs to tag in this batch", jobCount)
 
 		for _, job := range jobs.Jobs {
-			if err := t.tagJob(client, job); err != nil {
-				log.Printf("Error tagging job %s: %v", aws.ToString(job.Name), err)
+			err := t.tagJob(client, job)
+			t.recordGlueResult(GlueJob, aws.ToString(job.Name), t.buildCompoundARN(GlueJob, aws.ToString(job.Name)), err)
+			switch {
+			case errors.Is(err, errGlueSkipped):
+				atomic.AddInt32(&metrics.JobsSkipped, 1)
+			case err != nil:
 				atomic.AddInt32(&metrics.JobsFailed, 1)
-				continue
+			default:
+				t.countTagged(&metrics.JobsTagged, &metrics.JobsWouldTag)
 			}
-			atomic.AddInt32(&metrics.JobsTagged, 1)
 		}
 
 		// Check if there are more jobs to process
@@ -204,8 +447,8 @@ func (t *AWSResourceTagger) tagGlueJobs(client GlueAPI, metrics *GlueMetrics) {
 		nextToken = jobs.NextToken
 	}
 
-	log.Printf("Completed tagging Glue jobs. Found: %d, Tagged: %d, Failed: %d",
-		metrics.JobsFound, metrics.JobsTagged, metrics.JobsFailed)
+	log.Printf("Completed tagging Glue jobs. Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.JobsFound, metrics.JobsTagged, metrics.JobsFailed, metrics.JobsWouldTag, metrics.JobsSkipped)
 }
 
 // tagJob tags a single Glue job
@@ -215,11 +458,20 @@ func (t *AWSResourceTagger) tagJob(client GlueAPI, job gluetypes.Job) error {
 	// Build job ARN using the predefined pattern
 	resourceArn := t.buildCompoundARN(GlueJob, jobName)
 	log.Printf("Job ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueJob, jobName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
 
 	// Apply tags
-	_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
-		ResourceArn: aws.String(resourceArn),
-		TagsToAdd:   t.convertToGlueTags(),
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("error tagging job %s: %w", jobName, err)
@@ -255,12 +507,35 @@ func (t *AWSResourceTagger) tagGlueCrawlers(client GlueAPI, metrics *GlueMetrics
 		log.Printf("Found %d Glue crawlers to tag in this batch", crawlerCount)
 
 		for _, crawler := range crawlers.Crawlers {
-			if err := t.tagCrawler(client, crawler); err != nil {
-				log.Printf("Error tagging crawler %s: %v", aws.ToString(crawler.Name), err)
+			crawlerName := aws.ToString(crawler.Name)
+			result := TagResult{
+				Service:      "Glue",
+				ResourceType: GlueCrawler.Type,
+				ResourceID:   crawlerName,
+				Related:      crawlerRelatedResources(crawler),
+			}
+			// Tags can be added in any crawler state, but not without a name
+			if t.skipUnnamed(result, crawler.Name) {
+				atomic.AddInt32(&metrics.CrawlersSkipped, 1)
+				continue
+			}
+			result.ARN = t.buildCompoundARN(GlueCrawler, crawlerName)
+			err := t.tagCrawler(client, crawler)
+			if errors.Is(err, errGlueSkipped) {
+				atomic.AddInt32(&metrics.CrawlersSkipped, 1)
+				continue
+			}
+			if err != nil {
+				t.handleError(err, crawlerName, "Glue "+GlueCrawler.Type)
 				atomic.AddInt32(&metrics.CrawlersFailed, 1)
+				result.Status = StatusFailed
+				result.Error = err.Error()
+				t.recordResult(result)
 				continue
 			}
-			atomic.AddInt32(&metrics.CrawlersTagged, 1)
+			t.countTagged(&metrics.CrawlersTagged, &metrics.CrawlersWouldTag)
+			result.Status = t.taggedStatus()
+			t.recordResult(result)
 		}
 
 		// Check if there are more crawlers to process
@@ -270,8 +545,8 @@ func (t *AWSResourceTagger) tagGlueCrawlers(client GlueAPI, metrics *GlueMetrics
 		nextToken = crawlers.NextToken
 	}
 
-	log.Printf("Completed tagging Glue crawlers. Found: %d, Tagged: %d, Failed: %d",
-		metrics.CrawlersFound, metrics.CrawlersTagged, metrics.CrawlersFailed)
+	log.Printf("Completed tagging Glue crawlers. Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.CrawlersFound, metrics.CrawlersTagged, metrics.CrawlersFailed, metrics.CrawlersWouldTag, metrics.CrawlersSkipped)
 }
 
 // tagCrawler tags a single Glue crawler
@@ -281,11 +556,20 @@ func (t *AWSResourceTagger) tagCrawler(client GlueAPI, crawler gluetypes.Crawler
 	// Build crawler ARN using the predefined pattern
 	resourceArn := t.buildCompoundARN(GlueCrawler, crawlerName)
 	log.Printf("Crawler ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueCrawler, crawlerName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
 
 	// Apply tags
-	_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
-		ResourceArn: aws.String(resourceArn),
-		TagsToAdd:   t.convertToGlueTags(),
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("error tagging crawler %s: %w", crawlerName, err)
@@ -295,6 +579,51 @@ func (t *AWSResourceTagger) tagCrawler(client GlueAPI, crawler gluetypes.Crawler
 	return nil
 }
 
+// crawlerRelatedResources lists the databases and connections a crawler targets,
+// formatted as "database/<name>" and "connection/<name>"
+func crawlerRelatedResources(crawler gluetypes.Crawler) []string {
+	var related []string
+	seen := make(map[string]bool)
+	add := func(kind string, name *string) {
+		if aws.ToString(name) == "" {
+			return
+		}
+		entry := kind + "/" + aws.ToString(name)
+		if !seen[entry] {
+			seen[entry] = true
+			related = append(related, entry)
+		}
+	}
+
+	add("database", crawler.DatabaseName)
+	if crawler.Targets == nil {
+		return related
+	}
+	for _, target := range crawler.Targets.CatalogTargets {
+		add("database", target.DatabaseName)
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.S3Targets {
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.JdbcTargets {
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.MongoDBTargets {
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.DeltaTargets {
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.IcebergTargets {
+		add("connection", target.ConnectionName)
+	}
+	for _, target := range crawler.Targets.HudiTargets {
+		add("connection", target.ConnectionName)
+	}
+	return related
+}
+
 // tagGlueTriggers tags AWS Glue triggers with metrics
 func (t *AWSResourceTagger) tagGlueTriggers(client GlueAPI, metrics *GlueMetrics) {
 	log.Println("Tagging Glue triggers...")
@@ -320,12 +649,16 @@ func (t *AWSResourceTagger) tagGlueTriggers(client GlueAPI, metrics *GlueMetrics
 		log.Printf("Found %d Glue triggers to tag in this batch", triggerCount)
 
 		for _, trigger := range triggers.Triggers {
-			if err := t.tagTrigger(client, trigger); err != nil {
-				log.Printf("Error tagging trigger %s: %v", aws.ToString(trigger.Name), err)
+			err := t.tagTrigger(client, trigger)
+			t.recordGlueResult(GlueTrigger, aws.ToString(trigger.Name), t.buildCompoundARN(GlueTrigger, aws.ToString(trigger.Name)), err)
+			switch {
+			case errors.Is(err, errGlueSkipped):
+				atomic.AddInt32(&metrics.TriggersSkipped, 1)
+			case err != nil:
 				atomic.AddInt32(&metrics.TriggersFailed, 1)
-				continue
+			default:
+				t.countTagged(&metrics.TriggersTagged, &metrics.TriggersWouldTag)
 			}
-			atomic.AddInt32(&metrics.TriggersTagged, 1)
 		}
 
 		// Check if there are more triggers to process
@@ -335,8 +668,8 @@ func (t *AWSResourceTagger) tagGlueTriggers(client GlueAPI, metrics *GlueMetrics
 		nextToken = triggers.NextToken
 	}
 
-	log.Printf("Completed tagging Glue triggers. Found: %d, Tagged: %d, Failed: %d",
-		metrics.TriggersFound, metrics.TriggersTagged, metrics.TriggersFailed)
+	log.Printf("Completed tagging Glue triggers. Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.TriggersFound, metrics.TriggersTagged, metrics.TriggersFailed, metrics.TriggersWouldTag, metrics.TriggersSkipped)
 }
 
 // tagTrigger tags a single Glue trigger
@@ -346,11 +679,20 @@ func (t *AWSResourceTagger) tagTrigger(client GlueAPI, trigger gluetypes.Trigger
 	// Build trigger ARN using the predefined pattern
 	resourceArn := t.buildCompoundARN(GlueTrigger, triggerName)
 	log.Printf("Trigger ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueTrigger, triggerName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
 
 	// Apply tags
-	_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
-		ResourceArn: aws.String(resourceArn),
-		TagsToAdd:   t.convertToGlueTags(),
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("error tagging trigger %s: %w", triggerName, err)
@@ -359,3 +701,159 @@ func (t *AWSResourceTagger) tagTrigger(client GlueAPI, trigger gluetypes.Trigger
 	log.Printf("Successfully tagged Glue trigger: %s", triggerName)
 	return nil
 }
+
+// Glue Workflows
+// tagGlueWorkflows tags AWS Glue workflows with metrics
+func (t *AWSResourceTagger) tagGlueWorkflows(client GlueAPI, metrics *GlueMetrics) {
+	log.Println("Tagging Glue workflows...")
+
+	// Initialize paging parameters
+	maxResults := int32(25)
+	var nextToken *string
+
+	for {
+		input := &glue.ListWorkflowsInput{
+			MaxResults: aws.Int32(maxResults),
+			NextToken:  nextToken,
+		}
+
+		workflows, err := client.ListWorkflows(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Glue Workflows")
+			return
+		}
+
+		workflowCount := int32(len(workflows.Workflows))
+		atomic.AddInt32(&metrics.WorkflowsFound, workflowCount)
+		log.Printf("Found %d Glue workflows to tag in this batch", workflowCount)
+
+		for _, workflowName := range workflows.Workflows {
+			err := t.tagWorkflow(client, workflowName)
+			t.recordGlueResult(GlueWorkflow, workflowName, t.buildCompoundARN(GlueWorkflow, workflowName), err)
+			switch {
+			case errors.Is(err, errGlueSkipped):
+				atomic.AddInt32(&metrics.WorkflowsSkipped, 1)
+			case err != nil:
+				atomic.AddInt32(&metrics.WorkflowsFailed, 1)
+			default:
+				t.countTagged(&metrics.WorkflowsTagged, &metrics.WorkflowsWouldTag)
+			}
+		}
+
+		// Check if there are more workflows to process; an empty token also ends the listing
+		if aws.ToString(workflows.NextToken) == "" {
+			break
+		}
+		nextToken = workflows.NextToken
+	}
+
+	log.Printf("Completed tagging Glue workflows. Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.WorkflowsFound, metrics.WorkflowsTagged, metrics.WorkflowsFailed, metrics.WorkflowsWouldTag, metrics.WorkflowsSkipped)
+}
+
+// tagWorkflow tags a single Glue workflow
+func (t *AWSResourceTagger) tagWorkflow(client GlueAPI, workflowName string) error {
+	// Build workflow ARN using the predefined pattern
+	resourceArn := t.buildCompoundARN(GlueWorkflow, workflowName)
+	log.Printf("Workflow ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueWorkflow, workflowName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
+
+	// Apply tags
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error tagging workflow %s: %w", workflowName, err)
+	}
+
+	log.Printf("Successfully tagged Glue workflow: %s", workflowName)
+	return nil
+}
+
+// Glue Usage Profiles
+// tagGlueUsageProfiles tags AWS Glue usage profiles with metrics
+func (t *AWSResourceTagger) tagGlueUsageProfiles(client GlueAPI, metrics *GlueMetrics) {
+	log.Println("Tagging Glue usage profiles...")
+
+	// Initialize paging parameters
+	maxResults := int32(100)
+	var nextToken *string
+
+	for {
+		input := &glue.ListUsageProfilesInput{
+			MaxResults: aws.Int32(maxResults),
+			NextToken:  nextToken,
+		}
+
+		profiles, err := client.ListUsageProfiles(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Glue Usage Profiles")
+			return
+		}
+
+		profileCount := int32(len(profiles.Profiles))
+		atomic.AddInt32(&metrics.ProfilesFound, profileCount)
+		log.Printf("Found %d Glue usage profiles to tag in this batch", profileCount)
+
+		for _, profile := range profiles.Profiles {
+			err := t.tagUsageProfile(client, profile)
+			t.recordGlueResult(GlueUsageProfile, aws.ToString(profile.Name), t.buildCompoundARN(GlueUsageProfile, aws.ToString(profile.Name)), err)
+			switch {
+			case errors.Is(err, errGlueSkipped):
+				atomic.AddInt32(&metrics.ProfilesSkipped, 1)
+			case err != nil:
+				atomic.AddInt32(&metrics.ProfilesFailed, 1)
+			default:
+				t.countTagged(&metrics.ProfilesTagged, &metrics.ProfilesWouldTag)
+			}
+		}
+
+		// Check if there are more usage profiles to process
+		if profiles.NextToken == nil {
+			break
+		}
+		nextToken = profiles.NextToken
+	}
+
+	log.Printf("Completed tagging Glue usage profiles. Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.ProfilesFound, metrics.ProfilesTagged, metrics.ProfilesFailed, metrics.ProfilesWouldTag, metrics.ProfilesSkipped)
+}
+
+// tagUsageProfile tags a single Glue usage profile
+func (t *AWSResourceTagger) tagUsageProfile(client GlueAPI, profile gluetypes.UsageProfileDefinition) error {
+	profileName := aws.ToString(profile.Name)
+
+	// Build usage profile ARN using the predefined pattern
+	resourceArn := t.buildCompoundARN(GlueUsageProfile, profileName)
+	log.Printf("Usage profile ARN: %s", resourceArn)
+	if t.glueAlreadyTagged(client, resourceArn) || t.checkpointed(glueTarget(GlueUsageProfile, profileName, resourceArn)) {
+		return errGlueSkipped
+	}
+	if t.dryRunSkip(resourceArn) {
+		return nil
+	}
+
+	// Apply tags
+	err := t.retryWithBackoff(func() error {
+		_, err := client.TagResource(t.ctx, &glue.TagResourceInput{
+			ResourceArn: aws.String(resourceArn),
+			TagsToAdd:   t.convertToGlueTags(),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error tagging usage profile %s: %w", profileName, err)
+	}
+
+	log.Printf("Successfully tagged Glue usage profile: %s", profileName)
+	return nil
+}
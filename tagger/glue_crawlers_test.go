@@ -235,3 +235,70 @@ func TestTagGlueCrawlersMixedResults(t *testing.T) {
 	assert.Equal(t, int32(2), metrics.CrawlersTagged) // 2 successful tags
 	assert.Equal(t, int32(2), metrics.CrawlersFailed) // 2 failed tags
 }
+
+func TestTagGlueCrawlersRelatedResources(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	metrics := &GlueMetrics{}
+
+	crawler := gluetypes.Crawler{
+		Name:         aws.String("crawler1"),
+		DatabaseName: aws.String("sales_db"),
+		Targets: &gluetypes.CrawlerTargets{
+			JdbcTargets: []gluetypes.JdbcTarget{
+				{ConnectionName: aws.String("postgres-conn"), Path: aws.String("sales/%")},
+			},
+			CatalogTargets: []gluetypes.CatalogTarget{
+				{DatabaseName: aws.String("sales_db"), Tables: []string{"orders"}},
+			},
+		},
+	}
+
+	mockClient.On("GetCrawlers", mock.Anything, &glue.GetCrawlersInput{
+		MaxResults: aws.Int32(100),
+	}).Return(&glue.GetCrawlersOutput{
+		Crawlers: []gluetypes.Crawler{crawler},
+	}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.Anything).
+		Return(&glue.TagResourceOutput{}, nil)
+
+	tagger.tagGlueCrawlers(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "crawler1", results[0].ResourceID)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Contains(t, results[0].Related, "database/sales_db")
+	assert.Contains(t, results[0].Related, "connection/postgres-conn")
+	assert.Len(t, results[0].Related, 2, "duplicate database entries should be collapsed")
+}
+
+func TestTagGlueCrawlersSkipsUnnamedCrawler(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	metrics := &GlueMetrics{}
+
+	mockClient.On("GetCrawlers", mock.Anything, &glue.GetCrawlersInput{
+		MaxResults: aws.Int32(100),
+	}).Return(&glue.GetCrawlersOutput{
+		Crawlers: []gluetypes.Crawler{
+			{Name: nil, DatabaseName: aws.String("sales_db"), State: gluetypes.CrawlerStateRunning},
+		},
+	}, nil)
+
+	tagger.tagGlueCrawlers(mockClient, metrics)
+
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	assert.Equal(t, int32(1), metrics.CrawlersSkipped)
+	assert.Equal(t, int32(0), metrics.CrawlersFailed)
+
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, "resource has no name", results[0].Error)
+	assert.Empty(t, results[0].ARN, "no ARN is built for an unnamed crawler")
+}
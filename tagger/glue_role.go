@@ -0,0 +1,29 @@
+package tagger
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// WithGlueAssumeRole tags Glue resources with credentials of roleARN, such as
+// an Organizations delegated-admin role for catalogs set with WithGlueCatalogIDs
+func WithGlueAssumeRole(roleARN string) Option {
+	return func(t *AWSResourceTagger) {
+		t.glueRoleARN = roleARN
+	}
+}
+
+// glueConfig returns the config Glue clients are built from, assuming the
+// Glue role when one is set
+func (t *AWSResourceTagger) glueConfig() aws.Config {
+	if t.glueRoleARN == "" {
+		return t.cfg
+	}
+	return assumeRoleConfig(t.cfg, sts.NewFromConfig(t.cfg), t.glueRoleARN, "")
+}
+
+// newGlueClient builds a Glue client from the Glue config
+func (t *AWSResourceTagger) newGlueClient() *glue.Client {
+	return glue.NewFromConfig(t.glueConfig())
+}
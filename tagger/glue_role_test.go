@@ -0,0 +1,71 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAssumeRoleClient is a mock implementation of stscreds.AssumeRoleAPIClient
+type mockAssumeRoleClient struct {
+	mock.Mock
+}
+
+func (m *mockAssumeRoleClient) AssumeRole(ctx context.Context, params *sts.AssumeRoleInput, optFns ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sts.AssumeRoleOutput), args.Error(1)
+}
+
+func TestGlueClientUsesAssumedRoleAndCatalog(t *testing.T) {
+	const roleARN = "arn:aws:iam::210987654321:role/GlueDelegatedAdmin"
+
+	stsClient := new(mockAssumeRoleClient)
+	stsClient.On("AssumeRole", mock.Anything, mock.MatchedBy(func(input *sts.AssumeRoleInput) bool {
+		return aws.ToString(input.RoleArn) == roleARN
+	})).Return(&sts.AssumeRoleOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String("ASSUMEDKEY"),
+			SecretAccessKey: aws.String("assumed-secret"),
+			SessionToken:    aws.String("assumed-token"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil).Once()
+
+	tagger := createTestTagger()
+	WithGlueCatalogIDs([]string{"210987654321"})(tagger)
+	WithGlueAssumeRole(roleARN)(tagger)
+	tagger.cfg = aws.Config{Region: "us-west-2"}
+
+	client := glue.NewFromConfig(assumeRoleConfig(tagger.cfg, stsClient, tagger.glueRoleARN, ""))
+	creds, err := client.Options().Credentials.Retrieve(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "ASSUMEDKEY", creds.AccessKeyID)
+	assert.Equal(t, []string{"210987654321"}, tagger.glueCatalogs())
+	stsClient.AssertExpectations(t)
+	assert.Nil(t, tagger.cfg.Credentials, "the base config keeps its own credentials")
+}
+
+func TestGlueConfigWithoutRole(t *testing.T) {
+	base := aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "BASEKEY", SecretAccessKey: "secret"}, nil
+	})
+	tagger := createTestTagger()
+	tagger.cfg = aws.Config{Region: "us-west-2", Credentials: base}
+
+	creds, err := tagger.newGlueClient().Options().Credentials.Retrieve(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "BASEKEY", creds.AccessKeyID)
+}
@@ -3,6 +3,7 @@ package tagger
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -71,6 +72,33 @@ func (m *MockGlueClient) GetTriggers(ctx context.Context, params *glue.GetTrigge
 	return args.Get(0).(*glue.GetTriggersOutput), args.Error(1)
 }
 
+// ListUsageProfiles mock implementation
+func (m *MockGlueClient) ListUsageProfiles(ctx context.Context, params *glue.ListUsageProfilesInput, optFns ...func(*glue.Options)) (*glue.ListUsageProfilesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*glue.ListUsageProfilesOutput), args.Error(1)
+}
+
+// GetTags mock implementation
+func (m *MockGlueClient) GetTags(ctx context.Context, params *glue.GetTagsInput, optFns ...func(*glue.Options)) (*glue.GetTagsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*glue.GetTagsOutput), args.Error(1)
+}
+
+// ListWorkflows mock implementation
+func (m *MockGlueClient) ListWorkflows(ctx context.Context, params *glue.ListWorkflowsInput, optFns ...func(*glue.Options)) (*glue.ListWorkflowsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*glue.ListWorkflowsOutput), args.Error(1)
+}
+
 // Helper function to create a test tagger instance
 func createTestTagger() *AWSResourceTagger {
 	return &AWSResourceTagger{
@@ -147,6 +175,45 @@ func TestTagGlueDatabases(t *testing.T) {
 	}
 }
 
+func TestTagGlueResourcesAcrossCatalogs(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	WithGlueCatalogIDs([]string{"111111111111", "222222222222"})(tagger)
+	metrics := &GlueMetrics{}
+
+	for _, catalogID := range []string{"111111111111", "222222222222"} {
+		mockClient.On("GetDatabases", mock.Anything, &glue.GetDatabasesInput{CatalogId: aws.String(catalogID)}).
+			Return(&glue.GetDatabasesOutput{
+				DatabaseList: []gluetypes.Database{{Name: aws.String("db-" + catalogID)}},
+			}, nil).Once()
+		mockClient.On("GetConnections", mock.Anything, &glue.GetConnectionsInput{CatalogId: aws.String(catalogID)}).
+			Return(&glue.GetConnectionsOutput{
+				ConnectionList: []gluetypes.Connection{{Name: aws.String("conn-" + catalogID)}},
+			}, nil).Once()
+		mockClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+			ResourceArn: aws.String(fmt.Sprintf("arn:aws:glue:%s:%s:database/db-%s", tagger.region, catalogID, catalogID)),
+			TagsToAdd:   tagger.convertToGlueTags(),
+		}).Return(&glue.TagResourceOutput{}, nil).Once()
+		mockClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+			ResourceArn: aws.String(fmt.Sprintf("arn:aws:glue:%s:%s:connection/conn-%s", tagger.region, catalogID, catalogID)),
+			TagsToAdd:   tagger.convertToGlueTags(),
+		}).Return(&glue.TagResourceOutput{}, nil).Once()
+	}
+
+	tagger.tagGlueDatabases(mockClient, metrics)
+	tagger.tagGlueConnections(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "GetDatabases", 2)
+	mockClient.AssertNumberOfCalls(t, "GetConnections", 2)
+
+	// Metrics are aggregated across catalogs
+	assert.Equal(t, int32(2), metrics.DatabasesFound)
+	assert.Equal(t, int32(2), metrics.DatabasesTagged)
+	assert.Equal(t, int32(2), metrics.ConnectionsFound)
+	assert.Equal(t, int32(2), metrics.ConnectionsTagged)
+}
+
 func TestTagGlueDatabasesError(t *testing.T) {
 	// Create mock client
 	mockClient := new(MockGlueClient)
@@ -180,6 +247,7 @@ func TestTagGlueResourcesWithClient(t *testing.T) {
 		expectedCrawlers      int32
 		expectedTriggers      int32
 		expectedFailedMetrics bool
+		expectError           bool
 	}{
 		{
 			name: "Successfully tag all resources",
@@ -238,6 +306,26 @@ func TestTagGlueResourcesWithClient(t *testing.T) {
 				m.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
 					return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:trigger/trigger1"
 				})).Return(&glue.TagResourceOutput{}, nil)
+
+				// Mock successful workflow calls
+				m.On("ListWorkflows", mock.Anything, mock.Anything).
+					Return(&glue.ListWorkflowsOutput{
+						Workflows: []string{"workflow1"},
+					}, nil)
+				m.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+					return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:workflow/workflow1"
+				})).Return(&glue.TagResourceOutput{}, nil)
+
+				// Mock successful usage profile calls
+				m.On("ListUsageProfiles", mock.Anything, mock.Anything).
+					Return(&glue.ListUsageProfilesOutput{
+						Profiles: []gluetypes.UsageProfileDefinition{
+							{Name: aws.String("profile1")},
+						},
+					}, nil)
+				m.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+					return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:usageProfile/profile1"
+				})).Return(&glue.TagResourceOutput{}, nil)
 			},
 			invalidTags:           false,
 			expectedDatabases:     1,
@@ -259,6 +347,7 @@ func TestTagGlueResourcesWithClient(t *testing.T) {
 			expectedCrawlers:      0,
 			expectedTriggers:      0,
 			expectedFailedMetrics: false,
+			expectError:           true,
 		},
 		{
 			name: "Resource API failures",
@@ -274,6 +363,10 @@ func TestTagGlueResourcesWithClient(t *testing.T) {
 					Return(nil, errors.New("API error"))
 				m.On("GetTriggers", mock.Anything, mock.Anything).
 					Return(nil, errors.New("API error"))
+				m.On("ListWorkflows", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
+				m.On("ListUsageProfiles", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
 			},
 			invalidTags:           false,
 			expectedDatabases:     0,
@@ -302,12 +395,102 @@ func TestTagGlueResourcesWithClient(t *testing.T) {
 			tt.setupMock(mockClient)
 
 			// Execute test
-			tagger.tagGlueResourcesWithClient(mockClient)
+			err := tagger.tagGlueResourcesWithClient(mockClient)
 
 			// Verify expectations
 			mockClient.AssertExpectations(t)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagGlueResourcesWithClientReturnsAggregateError(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+
+	mockClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("db1")}},
+	}, nil)
+	mockClient.On("GetConnections", mock.Anything, mock.Anything).Return(&glue.GetConnectionsOutput{}, nil)
+	mockClient.On("GetCrawlers", mock.Anything, mock.Anything).Return(&glue.GetCrawlersOutput{}, nil)
+	mockClient.On("GetJobs", mock.Anything, mock.Anything).Return(&glue.GetJobsOutput{
+		Jobs: []gluetypes.Job{{Name: aws.String("job1")}, {Name: aws.String("job2")}},
+	}, nil)
+	mockClient.On("GetTriggers", mock.Anything, mock.Anything).Return(&glue.GetTriggersOutput{}, nil)
+	mockClient.On("ListWorkflows", mock.Anything, mock.Anything).Return(&glue.ListWorkflowsOutput{}, nil)
+	mockClient.On("ListUsageProfiles", mock.Anything, mock.Anything).Return(&glue.ListUsageProfilesOutput{}, nil)
+
+	tagged := func(arn string) interface{} {
+		return mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+			return aws.ToString(input.ResourceArn) == arn
 		})
 	}
+	mockClient.On("TagResource", mock.Anything, tagged("arn:aws:glue:us-west-2:123456789012:database/db1")).
+		Return(&glue.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, tagged("arn:aws:glue:us-west-2:123456789012:job/job1")).
+		Return(nil, &mockAPIError{code: "AccessDeniedException", message: "denied"}).Once()
+	mockClient.On("TagResource", mock.Anything, tagged("arn:aws:glue:us-west-2:123456789012:job/job2")).
+		Return(&glue.TagResourceOutput{}, nil).Once()
+
+	err := tagger.tagGlueResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to tag Glue resources: 1 jobs")
+}
+
+func TestGlueTagFailuresCountTowardsMaxAPIErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := new(MockGlueClient)
+	mockClient.On("ListWorkflows", mock.Anything, mock.Anything).Return(&glue.ListWorkflowsOutput{
+		Workflows: []string{"etl-1", "etl-2", "etl-3"},
+	}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, mock.Anything).
+		Return(nil, &mockAPIError{code: "AccessDeniedException", message: "denied"})
+
+	tagger := createTestTagger()
+	tagger.ctx = ctx
+	tagger.cancel = cancel
+	tagger.metrics = NewMetricsCollector()
+	WithMaxAPIErrors(1)(tagger)
+	metrics := &GlueMetrics{}
+	tagger.tagGlueWorkflows(mockClient, metrics)
+
+	assert.Equal(t, int32(3), metrics.WorkflowsFailed)
+	assert.Equal(t, int64(2), tagger.metrics.APIErrors(), "failures after the abort are not counted")
+	assert.Error(t, ctx.Err(), "exceeding --max-api-errors cancels the run")
+}
+
+func TestTagGlueResourcesWithClientOnlySelectedResources(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	WithGlueResources([]string{"Databases"})(tagger)
+
+	mockClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("db1")}},
+	}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:database/db1"
+	})).Return(&glue.TagResourceOutput{}, nil).Once()
+
+	err := tagger.tagGlueResourcesWithClient(mockClient)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	for _, method := range []string{"GetConnections", "GetJobs", "GetCrawlers", "GetTriggers", "ListWorkflows", "ListUsageProfiles"} {
+		mockClient.AssertNotCalled(t, method, mock.Anything, mock.Anything)
+	}
+}
+
+func TestValidateGlueResources(t *testing.T) {
+	assert.NoError(t, ValidateGlueResources([]string{"databases", "Jobs", "usage-profiles"}))
+	assert.EqualError(t, ValidateGlueResources([]string{"databases", "tables"}),
+		`unknown Glue resource type "tables"; valid types are databases, connections, crawlers, jobs, triggers, workflows, usage-profiles`)
 }
 
 func TestTagGlueResources(t *testing.T) {
@@ -374,4 +557,8 @@ func verifyResourceMetrics(t *testing.T, metrics *GlueMetrics, expected *GlueMet
 	assert.Equal(t, expected.TriggersFound, metrics.TriggersFound, "Triggers found mismatch")
 	assert.Equal(t, expected.TriggersTagged, metrics.TriggersTagged, "Triggers tagged mismatch")
 	assert.Equal(t, expected.TriggersFailed, metrics.TriggersFailed, "Triggers failed mismatch")
+
+	assert.Equal(t, expected.WorkflowsFound, metrics.WorkflowsFound, "Workflows found mismatch")
+	assert.Equal(t, expected.WorkflowsTagged, metrics.WorkflowsTagged, "Workflows tagged mismatch")
+	assert.Equal(t, expected.WorkflowsFailed, metrics.WorkflowsFailed, "Workflows failed mismatch")
 }
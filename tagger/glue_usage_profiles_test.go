@@ -0,0 +1,70 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagGlueUsageProfilesPagination(t *testing.T) {
+	// Create mock client
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	metrics := &GlueMetrics{}
+
+	// Setup paginated ListUsageProfiles calls
+	mockClient.On("ListUsageProfiles", mock.Anything, &glue.ListUsageProfilesInput{
+		MaxResults: aws.Int32(100),
+	}).Return(&glue.ListUsageProfilesOutput{
+		Profiles:  []gluetypes.UsageProfileDefinition{{Name: aws.String("analysts")}},
+		NextToken: aws.String("next-token"),
+	}, nil).Once()
+	mockClient.On("ListUsageProfiles", mock.Anything, &glue.ListUsageProfilesInput{
+		MaxResults: aws.Int32(100),
+		NextToken:  aws.String("next-token"),
+	}).Return(&glue.ListUsageProfilesOutput{
+		Profiles: []gluetypes.UsageProfileDefinition{{Name: aws.String("engineers")}},
+	}, nil).Once()
+
+	// Make the second profile fail
+	mockClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:glue:us-west-2:123456789012:usageProfile/analysts"),
+		TagsToAdd:   tagger.convertToGlueTags(),
+	}).Return(&glue.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:glue:us-west-2:123456789012:usageProfile/engineers"),
+		TagsToAdd:   tagger.convertToGlueTags(),
+	}).Return(nil, assert.AnError).Once()
+
+	// Execute test
+	tagger.tagGlueUsageProfiles(mockClient, metrics)
+
+	// Verify expectations
+	mockClient.AssertExpectations(t)
+
+	// Verify metrics
+	assert.Equal(t, int32(2), metrics.ProfilesFound)
+	assert.Equal(t, int32(1), metrics.ProfilesTagged)
+	assert.Equal(t, int32(1), metrics.ProfilesFailed)
+}
+
+func TestTagGlueUsageProfilesListError(t *testing.T) {
+	// Create mock client
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	metrics := &GlueMetrics{}
+
+	mockClient.On("ListUsageProfiles", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	// Execute test
+	tagger.tagGlueUsageProfiles(mockClient, metrics)
+
+	// Verify no profile was tagged
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	assert.Equal(t, int32(0), metrics.ProfilesFound)
+}
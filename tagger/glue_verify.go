@@ -0,0 +1,118 @@
+package tagger
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+)
+
+// WithVerify reads tags back after a run's writes and reports resources missing any (Glue)
+func WithVerify(verify bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.verify = verify
+	}
+}
+
+// glueWriteRecorder is a GlueAPI that remembers the ARNs it tagged successfully
+type glueWriteRecorder struct {
+	GlueAPI
+	mu   sync.Mutex
+	arns []string
+}
+
+// TagResource tags the resource and records its ARN on success
+func (r *glueWriteRecorder) TagResource(ctx context.Context, params *glue.TagResourceInput, optFns ...func(*glue.Options)) (*glue.TagResourceOutput, error) {
+	out, err := r.GlueAPI.TagResource(ctx, params, optFns...)
+	if err == nil {
+		r.mu.Lock()
+		r.arns = append(r.arns, aws.ToString(params.ResourceArn))
+		r.mu.Unlock()
+	}
+	return out, err
+}
+
+// tagged returns the recorded ARNs without duplicates
+func (r *glueWriteRecorder) tagged() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seen := make(map[string]bool, len(r.arns))
+	var arns []string
+	for _, arn := range r.arns {
+		if !seen[arn] {
+			seen[arn] = true
+			arns = append(arns, arn)
+		}
+	}
+	return arns
+}
+
+// verifyWorkers returns how many verification reads run at once: the shared
+// limiter's current limit, or one when no limiter is set, capped at n
+func (t *AWSResourceTagger) verifyWorkers(n int) int {
+	workers := 1
+	if t.limiter != nil {
+		workers = t.limiter.Limit()
+	}
+	return min(workers, n)
+}
+
+// verifyGlueTags reads back the tags of each ARN, retrying throttled reads,
+// and returns how many resources are missing any of the tags
+func (t *AWSResourceTagger) verifyGlueTags(client GlueAPI, arns []string) int32 {
+	var mismatched int32
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < t.verifyWorkers(len(arns)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for arn := range queue {
+				if !t.verifyGlueResource(client, arn) {
+					atomic.AddInt32(&mismatched, 1)
+				}
+			}
+		}()
+	}
+	for _, arn := range arns {
+		queue <- arn
+	}
+	close(queue)
+	wg.Wait()
+
+	log.Printf("Verified %d Glue resources, %d missing tags", len(arns), mismatched)
+	return mismatched
+}
+
+// verifyGlueResource reports whether arn carries every tag with the expected value
+func (t *AWSResourceTagger) verifyGlueResource(client GlueAPI, arn string) bool {
+	var tags map[string]string
+	err := t.retryWithBackoff(func() error {
+		out, err := client.GetTags(t.ctx, &glue.GetTagsInput{ResourceArn: aws.String(arn)})
+		if err == nil {
+			tags = out.Tags
+		}
+		return err
+	})
+	if err != nil {
+		t.handleError(err, arn, "Glue verification")
+		return false
+	}
+
+	var missing []string
+	for k, v := range t.tags {
+		if tags[k] != v {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		log.Printf("Verification failed for %s: missing or changed tags %v", arn, missing)
+		return false
+	}
+	return true
+}
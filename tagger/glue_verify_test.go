@@ -0,0 +1,99 @@
+package tagger
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifyGlueTagsRespectsLimiterAndRetriesThrottles(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	tagger.limiter = newConcurrencyLimiter(2, false)
+	tagger.retryBaseDelay = time.Millisecond
+	tagger.metrics = NewMetricsCollector()
+
+	var arns []string
+	for i := 0; i < 6; i++ {
+		arns = append(arns, fmt.Sprintf("arn:aws:glue:us-west-2:123456789012:job/job-%d", i))
+	}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	track := func(mock.Arguments) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+	mockClient.On("GetTags", mock.Anything, &glue.GetTagsInput{ResourceArn: aws.String(arns[0])}).
+		Run(track).Return(nil, &mockAPIError{code: "ThrottlingException", message: "Rate exceeded"}).Once()
+	mockClient.On("GetTags", mock.Anything, mock.Anything).
+		Run(track).Return(&glue.GetTagsOutput{Tags: tagger.convertToGlueTags()}, nil)
+
+	mismatched := tagger.verifyGlueTags(mockClient, arns)
+
+	assert.Equal(t, int32(0), mismatched)
+	assert.LessOrEqual(t, peak, 2)
+	mockClient.AssertNumberOfCalls(t, "GetTags", 7)
+	assert.Equal(t, int64(1), tagger.metrics.Throttles())
+}
+
+func TestVerifyGlueTagsReportsMissingTags(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+
+	mockClient.On("GetTags", mock.Anything, mock.Anything).
+		Return(&glue.GetTagsOutput{Tags: map[string]string{"Environment": "Test"}}, nil)
+
+	assert.Equal(t, int32(1), tagger.verifyGlueTags(mockClient, []string{"arn:aws:glue:us-west-2:123456789012:job/etl"}))
+}
+
+func TestVerifyMismatchesFailTheGlueRunAndReachTheReport(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetJobs", mock.Anything, mock.Anything).Return(&glue.GetJobsOutput{
+		Jobs: []gluetypes.Job{{Name: aws.String("etl")}},
+	}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil)
+	mockClient.On("GetTags", mock.Anything, mock.Anything).
+		Return(&glue.GetTagsOutput{Tags: map[string]string{"Environment": "Test"}}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	WithVerify(true)(tagger)
+	WithGlueResources([]string{GlueJobs})(tagger)
+
+	err := tagger.tagGlueResourcesWithClient(mockClient)
+
+	assert.EqualError(t, err, "1 Glue resources failed tag verification")
+	assert.Equal(t, int64(1), tagger.buildReport().VerifyMismatches)
+}
+
+func TestGlueWriteRecorderKeepsSuccessfulWrites(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+
+	mockClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("sales")}, {Name: aws.String("locked")}},
+	}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:database/locked"
+	})).Return(nil, &mockAPIError{code: "AccessDeniedException", message: "denied"})
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil)
+
+	recorder := &glueWriteRecorder{GlueAPI: mockClient}
+	tagger.tagGlueDatabases(recorder, &GlueMetrics{})
+
+	assert.Equal(t, []string{"arn:aws:glue:us-west-2:123456789012:database/sales"}, recorder.tagged())
+}
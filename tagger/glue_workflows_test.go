@@ -0,0 +1,129 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagGlueWorkflows(t *testing.T) {
+	tests := []struct {
+		name           string
+		workflows      []string
+		failWorkflow   string
+		expectedFound  int32
+		expectedTagged int32
+		expectedFailed int32
+	}{
+		{
+			name:           "Successfully tag multiple workflows",
+			workflows:      []string{"nightly-etl", "hourly-sync"},
+			expectedFound:  2,
+			expectedTagged: 2,
+		},
+		{
+			name:      "Empty workflow list",
+			workflows: []string{},
+		},
+		{
+			name:           "Tag resource fails for some workflows",
+			workflows:      []string{"nightly-etl", "hourly-sync"},
+			failWorkflow:   "hourly-sync",
+			expectedFound:  2,
+			expectedTagged: 1,
+			expectedFailed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockGlueClient)
+			tagger := createTestTagger()
+			metrics := &GlueMetrics{}
+
+			mockClient.On("ListWorkflows", mock.Anything, &glue.ListWorkflowsInput{
+				MaxResults: aws.Int32(25),
+			}).Return(&glue.ListWorkflowsOutput{Workflows: tt.workflows}, nil)
+			for _, name := range tt.workflows {
+				var tagError error
+				if name == tt.failWorkflow {
+					tagError = assert.AnError
+				}
+				mockClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+					ResourceArn: aws.String("arn:aws:glue:us-west-2:123456789012:workflow/" + name),
+					TagsToAdd:   tagger.convertToGlueTags(),
+				}).Return(&glue.TagResourceOutput{}, tagError)
+			}
+
+			tagger.tagGlueWorkflows(mockClient, metrics)
+
+			mockClient.AssertExpectations(t)
+			verifyResourceMetrics(t, metrics, &GlueMetrics{
+				WorkflowsFound:  tt.expectedFound,
+				WorkflowsTagged: tt.expectedTagged,
+				WorkflowsFailed: tt.expectedFailed,
+			})
+		})
+	}
+}
+
+func TestTagGlueWorkflowsPagination(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	metrics := &GlueMetrics{}
+
+	mockClient.On("ListWorkflows", mock.Anything, &glue.ListWorkflowsInput{
+		MaxResults: aws.Int32(25),
+	}).Return(&glue.ListWorkflowsOutput{
+		Workflows: []string{"workflow1", "workflow2"},
+		NextToken: aws.String("next-token"),
+	}, nil).Once()
+	mockClient.On("ListWorkflows", mock.Anything, &glue.ListWorkflowsInput{
+		MaxResults: aws.Int32(25),
+		NextToken:  aws.String("next-token"),
+	}).Return(&glue.ListWorkflowsOutput{
+		Workflows: []string{"workflow3"},
+	}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil).Times(3)
+
+	tagger.tagGlueWorkflows(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, int32(3), metrics.WorkflowsFound)
+	assert.Equal(t, int32(3), metrics.WorkflowsTagged)
+}
+
+func TestTagGlueWorkflowsPaginationStopsOnEmptyToken(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	metrics := &GlueMetrics{}
+
+	mockClient.On("ListWorkflows", mock.Anything, mock.Anything).Return(&glue.ListWorkflowsOutput{
+		Workflows: []string{"workflow1"},
+		NextToken: aws.String(""),
+	}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil).Once()
+
+	tagger.tagGlueWorkflows(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "ListWorkflows", 1)
+	verifyResourceMetrics(t, metrics, &GlueMetrics{WorkflowsFound: 1, WorkflowsTagged: 1})
+}
+
+func TestTagGlueWorkflowsListError(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	metrics := &GlueMetrics{}
+
+	mockClient.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	tagger.tagGlueWorkflows(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	verifyResourceMetrics(t, metrics, &GlueMetrics{})
+}
@@ -0,0 +1,30 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+)
+
+// TagAllResourcesIdempotent runs TagAllResources twice and fails if the second
+// run issues any tag writes, guarding against accidental re-tagging
+func (t *AWSResourceTagger) TagAllResourcesIdempotent() error {
+	return t.assertIdempotent(t.TagAllResources)
+}
+
+// assertIdempotent runs a tagging pass twice and compares the tag write counter
+func (t *AWSResourceTagger) assertIdempotent(run func() error) error {
+	if err := run(); err != nil {
+		return err
+	}
+	first := t.metrics.Writes()
+	log.Printf("First run issued %d tag writes; running again to verify idempotency", first)
+
+	if err := run(); err != nil {
+		return fmt.Errorf("idempotency check run failed: %w", err)
+	}
+	if second := t.metrics.Writes() - first; second > 0 {
+		return fmt.Errorf("idempotency check failed: second run issued %d tag writes", second)
+	}
+	log.Println("Idempotency check passed: second run issued no tag writes")
+	return nil
+}
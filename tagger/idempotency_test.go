@@ -0,0 +1,107 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHTTPClient answers every request with an empty JSON document
+type fakeHTTPClient struct{}
+
+func (fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte("{}"))),
+		Request:    req,
+	}, nil
+}
+
+func TestCountTagWrites(t *testing.T) {
+	metrics := NewMetricsCollector()
+	cfg := aws.Config{
+		Region:      "us-west-2",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  fakeHTTPClient{},
+		APIOptions:  []func(*middleware.Stack) error{countTagWrites(metrics)},
+	}
+	client := athena.NewFromConfig(cfg)
+
+	_, err := client.ListWorkGroups(context.Background(), &athena.ListWorkGroupsInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), metrics.Writes(), "list calls are not writes")
+
+	_, err = client.TagResource(context.Background(), &athena.TagResourceInput{
+		ResourceARN: aws.String("arn:aws:athena:us-west-2:123456789012:workgroup/analytics"),
+		Tags:        (&AWSResourceTagger{tags: map[string]string{"env": "prod"}}).convertToAthenaTags(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), metrics.Writes())
+}
+
+func TestIsTagWriteOperation(t *testing.T) {
+	for _, op := range []string{"TagResource", "TagResources", "AddTags", "AddTagsToResource", "CreateTags", "UpdateTagsForResource", "PutBucketTagging", "UpdateProject"} {
+		assert.True(t, isTagWriteOperation(op), op)
+	}
+	for _, op := range []string{"ListTags", "DescribeInstances", "GetBucketTagging", "PutObject", "UntagResource"} {
+		assert.False(t, isTagWriteOperation(op), op)
+	}
+}
+
+func TestAssertIdempotent(t *testing.T) {
+	tests := []struct {
+		name         string
+		writesPerRun []int
+		runErr       error
+		expectError  string
+	}{
+		{
+			name:         "Second run with no writes passes",
+			writesPerRun: []int{5, 0},
+		},
+		{
+			name:         "Second run with writes fails",
+			writesPerRun: []int{5, 2},
+			expectError:  "second run issued 2 tag writes",
+		},
+		{
+			name:         "First run error is returned",
+			writesPerRun: []int{0},
+			runErr:       errors.New("SSO session validation failed"),
+			expectError:  "SSO session validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &AWSResourceTagger{metrics: NewMetricsCollector()}
+
+			runs := 0
+			run := func() error {
+				for i := 0; i < tt.writesPerRun[runs]; i++ {
+					tagger.metrics.RecordWrite()
+				}
+				runs++
+				return tt.runErr
+			}
+
+			err := tagger.assertIdempotent(run)
+			if tt.expectError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, len(tt.writesPerRun), runs)
+		})
+	}
+}
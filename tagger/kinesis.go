@@ -0,0 +1,78 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+)
+
+// KinesisAPI interface for Kinesis client operations
+type KinesisAPI interface {
+	ListStreams(ctx context.Context, params *kinesis.ListStreamsInput, optFns ...func(*kinesis.Options)) (*kinesis.ListStreamsOutput, error)
+	AddTagsToStream(ctx context.Context, params *kinesis.AddTagsToStreamInput, optFns ...func(*kinesis.Options)) (*kinesis.AddTagsToStreamOutput, error)
+}
+
+func init() {
+	registerService("Kinesis", (*AWSResourceTagger).tagKinesisResources)
+}
+
+// tagKinesisResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagKinesisResources() error {
+	client := kinesis.NewFromConfig(t.cfg)
+	t.tagKinesisResourcesWithClient(client)
+	return nil
+}
+
+// tagKinesisResourcesWithClient tags every Kinesis data stream in the region.
+// AddTagsToStream accepts 10 tags per call, so larger tag sets are split.
+func (t *AWSResourceTagger) tagKinesisResourcesWithClient(client KinesisAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging Kinesis streams...")
+	defer log.Println("Completed tagging Kinesis streams")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping Kinesis stream tagging")
+		return
+	}
+
+	input := &kinesis.ListStreamsInput{}
+	for {
+		output, err := client.ListStreams(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Kinesis Streams")
+			return
+		}
+
+		for _, stream := range output.StreamSummaries {
+			name := aws.ToString(stream.StreamName)
+			streamARN := aws.ToString(stream.StreamARN)
+			if streamARN == "" {
+				streamARN = t.buildARN(KinesisStream, name)
+			}
+			t.applyAndRecord(TagResult{
+				Service:      "Kinesis",
+				ResourceType: KinesisStream.Type,
+				ResourceID:   name,
+				ARN:          streamARN,
+			}, func() error {
+				for _, chunk := range tagChunks("Kinesis", t.tags) {
+					if _, err := client.AddTagsToStream(t.ctx, &kinesis.AddTagsToStreamInput{
+						StreamARN: aws.String(streamARN),
+						Tags:      chunk,
+					}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input = &kinesis.ListStreamsInput{NextToken: output.NextToken}
+	}
+}
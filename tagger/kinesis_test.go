@@ -0,0 +1,126 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	kinesistypes "github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockKinesisClient is a mock implementation of KinesisAPI
+type MockKinesisClient struct {
+	mock.Mock
+}
+
+func (m *MockKinesisClient) ListStreams(ctx context.Context, params *kinesis.ListStreamsInput, optFns ...func(*kinesis.Options)) (*kinesis.ListStreamsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kinesis.ListStreamsOutput), args.Error(1)
+}
+
+func (m *MockKinesisClient) AddTagsToStream(ctx context.Context, params *kinesis.AddTagsToStreamInput, optFns ...func(*kinesis.Options)) (*kinesis.AddTagsToStreamOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kinesis.AddTagsToStreamOutput), args.Error(1)
+}
+
+func kinesisStreamSummary(name string) kinesistypes.StreamSummary {
+	return kinesistypes.StreamSummary{
+		StreamName: aws.String(name),
+		StreamARN:  aws.String("arn:aws:kinesis:us-west-2:123456789012:stream/" + name),
+	}
+}
+
+func TestTagKinesisStreamsChunksLargeTagSets(t *testing.T) {
+	tags := make(map[string]string, 15)
+	for i := 0; i < 15; i++ {
+		tags[fmt.Sprintf("key-%02d", i)] = "value"
+	}
+
+	mockClient := new(MockKinesisClient)
+	mockClient.On("ListStreams", mock.Anything, &kinesis.ListStreamsInput{}).
+		Return(&kinesis.ListStreamsOutput{
+			StreamSummaries: []kinesistypes.StreamSummary{kinesisStreamSummary("events")},
+		}, nil).Once()
+
+	var chunkSizes []int
+	received := make(map[string]string)
+	mockClient.On("AddTagsToStream", mock.Anything, mock.AnythingOfType("*kinesis.AddTagsToStreamInput")).
+		Run(func(args mock.Arguments) {
+			input := args.Get(1).(*kinesis.AddTagsToStreamInput)
+			chunkSizes = append(chunkSizes, len(input.Tags))
+			for k, v := range input.Tags {
+				received[k] = v
+			}
+		}).
+		Return(&kinesis.AddTagsToStreamOutput{}, nil).Twice()
+
+	tagger := createTestTagger()
+	tagger.tags = tags
+	tagger.results = NewResultCollector()
+	tagger.tagKinesisResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "AddTagsToStream", 2)
+	assert.Equal(t, []int{10, 5}, chunkSizes)
+	assert.Equal(t, tags, received)
+
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusTagged, results[0].Status)
+}
+
+func TestTagKinesisStreams(t *testing.T) {
+	mockClient := new(MockKinesisClient)
+	mockClient.On("ListStreams", mock.Anything, &kinesis.ListStreamsInput{}).
+		Return(&kinesis.ListStreamsOutput{
+			StreamSummaries: []kinesistypes.StreamSummary{kinesisStreamSummary("events"), kinesisStreamSummary("locked")},
+			NextToken:       aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("ListStreams", mock.Anything, &kinesis.ListStreamsInput{NextToken: aws.String("page-2")}).
+		Return(&kinesis.ListStreamsOutput{
+			StreamSummaries: []kinesistypes.StreamSummary{kinesisStreamSummary("clicks")},
+		}, nil).Once()
+	mockClient.On("AddTagsToStream", mock.Anything, mock.MatchedBy(func(input *kinesis.AddTagsToStreamInput) bool {
+		return aws.ToString(input.StreamARN) == aws.ToString(kinesisStreamSummary("locked").StreamARN)
+	})).Return(nil, errors.New("AccessDeniedException")).Once()
+	mockClient.On("AddTagsToStream", mock.Anything, mock.Anything).Return(&kinesis.AddTagsToStreamOutput{}, nil).Twice()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagKinesisResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	var statuses []string
+	for _, result := range tagger.Results() {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []string{StatusTagged, StatusFailed, StatusTagged}, statuses)
+}
+
+func TestTagChunks(t *testing.T) {
+	small := map[string]string{"a": "1", "b": "2"}
+	assert.Equal(t, []map[string]string{small}, tagChunks("Kinesis", small))
+
+	large := make(map[string]string, 25)
+	for i := 0; i < 25; i++ {
+		large[fmt.Sprintf("k%02d", i)] = "v"
+	}
+	assert.Equal(t, []map[string]string{large}, tagChunks("EC2", large))
+
+	chunks := tagChunks("Kinesis", large)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[2], 5)
+	assert.Contains(t, chunks[0], "k00")
+	assert.Contains(t, chunks[2], "k24")
+}
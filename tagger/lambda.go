@@ -0,0 +1,70 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// LambdaAPI interface for Lambda client operations
+type LambdaAPI interface {
+	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+	TagResource(ctx context.Context, params *lambda.TagResourceInput, optFns ...func(*lambda.Options)) (*lambda.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("Lambda", (*AWSResourceTagger).tagLambdaResources)
+}
+
+// tagLambdaResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagLambdaResources() error {
+	client := lambda.NewFromConfig(t.cfg)
+	t.tagLambdaResourcesWithClient(client)
+	return nil
+}
+
+// tagLambdaResourcesWithClient tags every Lambda function in the region
+func (t *AWSResourceTagger) tagLambdaResourcesWithClient(client LambdaAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging Lambda functions...")
+	defer log.Println("Completed tagging Lambda functions")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping Lambda function tagging")
+		return
+	}
+
+	input := &lambda.ListFunctionsInput{}
+	for {
+		output, err := client.ListFunctions(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Lambda Functions")
+			return
+		}
+
+		for _, function := range output.Functions {
+			arn := aws.ToString(function.FunctionArn)
+			t.applyAndRecord(TagResult{
+				Service:      "Lambda",
+				ResourceType: "function",
+				ResourceID:   aws.ToString(function.FunctionName),
+				ARN:          arn,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &lambda.TagResourceInput{
+					Resource: aws.String(arn),
+					Tags:     t.tags,
+				})
+				return err
+			})
+		}
+
+		// An empty marker also signals the last page
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+}
@@ -0,0 +1,143 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLambdaClient is a mock implementation of LambdaAPI
+type MockLambdaClient struct {
+	mock.Mock
+}
+
+func (m *MockLambdaClient) ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lambda.ListFunctionsOutput), args.Error(1)
+}
+
+func (m *MockLambdaClient) TagResource(ctx context.Context, params *lambda.TagResourceInput, optFns ...func(*lambda.Options)) (*lambda.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lambda.TagResourceOutput), args.Error(1)
+}
+
+func lambdaFunctionARN(name string) string {
+	return "arn:aws:lambda:us-west-2:123456789012:function:" + name
+}
+
+func TestTagLambdaResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		tags           map[string]string
+		setupMock      func(*MockLambdaClient)
+		expectedStatus []string
+	}{
+		{
+			name: "paginates and tags every function",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockLambdaClient) {
+				m.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{}).
+					Return(&lambda.ListFunctionsOutput{
+						Functions:  []lambdatypes.FunctionConfiguration{{FunctionName: aws.String("ingest"), FunctionArn: aws.String(lambdaFunctionARN("ingest"))}},
+						NextMarker: aws.String("page-2"),
+					}, nil).Once()
+				m.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{Marker: aws.String("page-2")}).
+					Return(&lambda.ListFunctionsOutput{
+						Functions: []lambdatypes.FunctionConfiguration{{FunctionName: aws.String("export"), FunctionArn: aws.String(lambdaFunctionARN("export"))}},
+					}, nil).Once()
+				for _, name := range []string{"ingest", "export"} {
+					m.On("TagResource", mock.Anything, &lambda.TagResourceInput{
+						Resource: aws.String(lambdaFunctionARN(name)),
+						Tags:     map[string]string{"map-migrated": "mig12345"},
+					}).Return(&lambda.TagResourceOutput{}, nil).Once()
+				}
+			},
+			expectedStatus: []string{StatusTagged, StatusTagged},
+		},
+		{
+			name:           "skips when no tags are configured",
+			tags:           map[string]string{},
+			setupMock:      func(m *MockLambdaClient) {},
+			expectedStatus: nil,
+		},
+		{
+			name: "continues after a TagResource error",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockLambdaClient) {
+				m.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{}).
+					Return(&lambda.ListFunctionsOutput{
+						Functions: []lambdatypes.FunctionConfiguration{
+							{FunctionName: aws.String("locked"), FunctionArn: aws.String(lambdaFunctionARN("locked"))},
+							{FunctionName: aws.String("open"), FunctionArn: aws.String(lambdaFunctionARN("open"))},
+						},
+					}, nil).Once()
+				m.On("TagResource", mock.Anything, mock.MatchedBy(func(input *lambda.TagResourceInput) bool {
+					return aws.ToString(input.Resource) == lambdaFunctionARN("locked")
+				})).Return(nil, errors.New("AccessDeniedException")).Once()
+				m.On("TagResource", mock.Anything, mock.MatchedBy(func(input *lambda.TagResourceInput) bool {
+					return aws.ToString(input.Resource) == lambdaFunctionARN("open")
+				})).Return(&lambda.TagResourceOutput{}, nil).Once()
+			},
+			expectedStatus: []string{StatusFailed, StatusTagged},
+		},
+		{
+			name: "stops on an empty marker",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockLambdaClient) {
+				m.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{}).
+					Return(&lambda.ListFunctionsOutput{
+						Functions:  []lambdatypes.FunctionConfiguration{{FunctionName: aws.String("ingest"), FunctionArn: aws.String(lambdaFunctionARN("ingest"))}},
+						NextMarker: aws.String(""),
+					}, nil).Once()
+				m.On("TagResource", mock.Anything, mock.Anything).Return(&lambda.TagResourceOutput{}, nil).Once()
+			},
+			expectedStatus: []string{StatusTagged},
+		},
+		{
+			name: "stops when listing fails",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockLambdaClient) {
+				m.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{}).
+					Return(nil, errors.New("AccessDeniedException")).Once()
+			},
+			expectedStatus: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockLambdaClient)
+			tt.setupMock(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:     context.Background(),
+				tags:    tt.tags,
+				results: NewResultCollector(),
+			}
+			tagger.tagLambdaResourcesWithClient(mockClient)
+
+			mockClient.AssertExpectations(t)
+			if len(tt.tags) == 0 {
+				mockClient.AssertNotCalled(t, "ListFunctions", mock.Anything, mock.Anything)
+			}
+
+			var statuses []string
+			for _, result := range tagger.Results() {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.expectedStatus, statuses)
+		})
+	}
+}
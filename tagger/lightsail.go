@@ -0,0 +1,115 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lightsail"
+	lstypes "github.com/aws/aws-sdk-go-v2/service/lightsail/types"
+)
+
+// LightsailAPI interface for Lightsail client operations
+type LightsailAPI interface {
+	GetInstances(ctx context.Context, params *lightsail.GetInstancesInput, optFns ...func(*lightsail.Options)) (*lightsail.GetInstancesOutput, error)
+	GetRelationalDatabases(ctx context.Context, params *lightsail.GetRelationalDatabasesInput, optFns ...func(*lightsail.Options)) (*lightsail.GetRelationalDatabasesOutput, error)
+	TagResource(ctx context.Context, params *lightsail.TagResourceInput, optFns ...func(*lightsail.Options)) (*lightsail.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("Lightsail", (*AWSResourceTagger).tagLightsailResources)
+}
+
+// tagLightsailResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagLightsailResources() error {
+	client := lightsail.NewFromConfig(t.cfg)
+	t.tagLightsailResourcesWithClient(client)
+	return nil
+}
+
+// tagLightsailResourcesWithClient tags Lightsail instances and relational databases
+func (t *AWSResourceTagger) tagLightsailResourcesWithClient(client LightsailAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging Lightsail resources...")
+	defer log.Println("Completed tagging Lightsail resources")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping Lightsail resource tagging")
+		return
+	}
+
+	t.tagLightsailInstances(client)
+	t.tagLightsailDatabases(client)
+}
+
+// tagLightsailInstances tags every Lightsail instance
+func (t *AWSResourceTagger) tagLightsailInstances(client LightsailAPI) {
+	input := &lightsail.GetInstancesInput{}
+	for {
+		output, err := client.GetInstances(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Lightsail Instances")
+			return
+		}
+
+		for _, instance := range output.Instances {
+			t.tagLightsailResource(client, "instance", aws.ToString(instance.Name), aws.ToString(instance.Arn))
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		input.PageToken = output.NextPageToken
+	}
+}
+
+// tagLightsailDatabases tags every Lightsail relational database
+func (t *AWSResourceTagger) tagLightsailDatabases(client LightsailAPI) {
+	input := &lightsail.GetRelationalDatabasesInput{}
+	for {
+		output, err := client.GetRelationalDatabases(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Lightsail Databases")
+			return
+		}
+
+		for _, database := range output.RelationalDatabases {
+			t.tagLightsailResource(client, "database", aws.ToString(database.Name), aws.ToString(database.Arn))
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		input.PageToken = output.NextPageToken
+	}
+}
+
+// tagLightsailResource tags one Lightsail resource, which the API addresses by name
+func (t *AWSResourceTagger) tagLightsailResource(client LightsailAPI, resourceType, name, arn string) {
+	t.applyAndRecord(TagResult{
+		Service:      "Lightsail",
+		ResourceType: resourceType,
+		ResourceID:   name,
+		ARN:          arn,
+	}, func() error {
+		_, err := client.TagResource(t.ctx, &lightsail.TagResourceInput{
+			ResourceName: aws.String(name),
+			Tags:         t.lightsailTags(),
+		})
+		return err
+	})
+}
+
+// lightsailTags converts the configured tags to Lightsail tags
+func (t *AWSResourceTagger) lightsailTags() []lstypes.Tag {
+	tags := make([]lstypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
+		tags = append(tags, lstypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,120 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lightsail"
+	lstypes "github.com/aws/aws-sdk-go-v2/service/lightsail/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLightsailClient is a mock implementation of LightsailAPI
+type MockLightsailClient struct {
+	mock.Mock
+}
+
+func (m *MockLightsailClient) GetInstances(ctx context.Context, params *lightsail.GetInstancesInput, optFns ...func(*lightsail.Options)) (*lightsail.GetInstancesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lightsail.GetInstancesOutput), args.Error(1)
+}
+
+func (m *MockLightsailClient) GetRelationalDatabases(ctx context.Context, params *lightsail.GetRelationalDatabasesInput, optFns ...func(*lightsail.Options)) (*lightsail.GetRelationalDatabasesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lightsail.GetRelationalDatabasesOutput), args.Error(1)
+}
+
+func (m *MockLightsailClient) TagResource(ctx context.Context, params *lightsail.TagResourceInput, optFns ...func(*lightsail.Options)) (*lightsail.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lightsail.TagResourceOutput), args.Error(1)
+}
+
+// lightsailResourceNamed matches a TagResource call for the named resource
+func lightsailResourceNamed(name string) interface{} {
+	return mock.MatchedBy(func(input *lightsail.TagResourceInput) bool {
+		return aws.ToString(input.ResourceName) == name
+	})
+}
+
+func TestTagLightsailResources(t *testing.T) {
+	mockClient := new(MockLightsailClient)
+	mockClient.On("GetInstances", mock.Anything, &lightsail.GetInstancesInput{}).
+		Return(&lightsail.GetInstancesOutput{
+			Instances:     []lstypes.Instance{{Name: aws.String("web-1"), Arn: aws.String("arn:aws:lightsail:us-west-2:123456789012:Instance/web-1")}},
+			NextPageToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("GetInstances", mock.Anything, &lightsail.GetInstancesInput{PageToken: aws.String("page-2")}).
+		Return(&lightsail.GetInstancesOutput{
+			Instances: []lstypes.Instance{{Name: aws.String("web-2"), Arn: aws.String("arn:aws:lightsail:us-west-2:123456789012:Instance/web-2")}},
+		}, nil).Once()
+	mockClient.On("GetRelationalDatabases", mock.Anything, &lightsail.GetRelationalDatabasesInput{}).
+		Return(&lightsail.GetRelationalDatabasesOutput{
+			RelationalDatabases: []lstypes.RelationalDatabase{{Name: aws.String("orders"), Arn: aws.String("arn:aws:lightsail:us-west-2:123456789012:RelationalDatabase/orders")}},
+			NextPageToken:       aws.String("db-page-2"),
+		}, nil).Once()
+	mockClient.On("GetRelationalDatabases", mock.Anything, &lightsail.GetRelationalDatabasesInput{PageToken: aws.String("db-page-2")}).
+		Return(&lightsail.GetRelationalDatabasesOutput{
+			RelationalDatabases: []lstypes.RelationalDatabase{{Name: aws.String("billing"), Arn: aws.String("arn:aws:lightsail:us-west-2:123456789012:RelationalDatabase/billing")}},
+		}, nil).Once()
+	for _, name := range []string{"web-1", "web-2", "orders", "billing"} {
+		mockClient.On("TagResource", mock.Anything, lightsailResourceNamed(name)).
+			Return(&lightsail.TagResourceOutput{}, nil).Once()
+	}
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"map-migrated": "mig12345"},
+		results: NewResultCollector(),
+	}
+	tagger.tagLightsailResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+
+	results := tagger.Results()
+	assert.Len(t, results, 4)
+	assert.Equal(t, "instance", results[0].ResourceType)
+	assert.Equal(t, "database", results[3].ResourceType)
+	for _, result := range results {
+		assert.Equal(t, StatusTagged, result.Status)
+	}
+}
+
+func TestTagLightsailResourcesFailures(t *testing.T) {
+	mockClient := new(MockLightsailClient)
+	mockClient.On("GetInstances", mock.Anything, mock.Anything).
+		Return(&lightsail.GetInstancesOutput{
+			Instances: []lstypes.Instance{{Name: aws.String("locked")}, {Name: aws.String("open")}},
+		}, nil).Once()
+	mockClient.On("GetRelationalDatabases", mock.Anything, mock.Anything).
+		Return(nil, errors.New("AccessDeniedException")).Once()
+	mockClient.On("TagResource", mock.Anything, lightsailResourceNamed("locked")).
+		Return(nil, errors.New("AccessDeniedException")).Once()
+	mockClient.On("TagResource", mock.Anything, lightsailResourceNamed("open")).
+		Return(&lightsail.TagResourceOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"map-migrated": "mig12345"},
+		results: NewResultCollector(),
+	}
+	tagger.tagLightsailResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Equal(t, StatusTagged, results[1].Status)
+}
@@ -0,0 +1,285 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// MetricsCollector tracks run-wide API statistics shared by all service taggers
+type MetricsCollector struct {
+	apiErrors int64
+	throttles int64
+	writes    int64
+	// unavailable counts calls to services without an endpoint in the region
+	unavailable int64
+	// alreadyTagged counts resources skipped because they carry every desired tag
+	alreadyTagged int64
+
+	keysMu sync.Mutex
+	// appliedKeys counts, per tag key, the resources successfully tagged with it
+	appliedKeys map[string]int64
+
+	typesMu sync.Mutex
+	// typeCounts counts results by status per service and resource type
+	typeCounts map[resourceTypeKey]*ReportSummary
+}
+
+// resourceTypeKey identifies a resource type within a service
+type resourceTypeKey struct {
+	service      string
+	resourceType string
+}
+
+// ResourceTypeSummary counts the results of one resource type within a service
+type ResourceTypeSummary struct {
+	Service      string `json:"service"`
+	ResourceType string `json:"resource_type"`
+	ReportSummary
+}
+
+// NewMetricsCollector creates an empty metrics collector
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{}
+}
+
+// RecordAPIError counts a non-throttling API failure and returns the new total
+func (m *MetricsCollector) RecordAPIError() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.AddInt64(&m.apiErrors, 1)
+}
+
+// RecordThrottle counts a throttled API call
+func (m *MetricsCollector) RecordThrottle() {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.throttles, 1)
+}
+
+// RecordUnavailable counts a call skipped because the service is not offered in the region
+func (m *MetricsCollector) RecordUnavailable() {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.unavailable, 1)
+}
+
+// Unavailable returns the number of calls skipped because the service is not offered in the region
+func (m *MetricsCollector) Unavailable() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.unavailable)
+}
+
+// RecordAlreadyTagged counts a resource skipped because it already carries every desired tag
+func (m *MetricsCollector) RecordAlreadyTagged() {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.alreadyTagged, 1)
+}
+
+// AlreadyTagged returns the number of resources skipped because they already carried every desired tag
+func (m *MetricsCollector) AlreadyTagged() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.alreadyTagged)
+}
+
+// APIErrors returns the number of non-throttling API failures recorded
+func (m *MetricsCollector) APIErrors() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.apiErrors)
+}
+
+// Throttles returns the number of throttled API calls recorded
+func (m *MetricsCollector) Throttles() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.throttles)
+}
+
+// RecordWrite counts a tag write API call
+func (m *MetricsCollector) RecordWrite() {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.writes, 1)
+}
+
+// Writes returns the number of tag write API calls issued
+func (m *MetricsCollector) Writes() int64 {
+	if m == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&m.writes)
+}
+
+// RecordAppliedKeys counts one successfully tagged resource for each key
+func (m *MetricsCollector) RecordAppliedKeys(keys []string) {
+	if m == nil {
+		return
+	}
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+	if m.appliedKeys == nil {
+		m.appliedKeys = make(map[string]int64, len(keys))
+	}
+	for _, k := range keys {
+		m.appliedKeys[k]++
+	}
+}
+
+// AppliedKeys returns a copy of the per-key counts of successfully tagged resources
+func (m *MetricsCollector) AppliedKeys() map[string]int64 {
+	if m == nil {
+		return nil
+	}
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+	if len(m.appliedKeys) == 0 {
+		return nil
+	}
+	counts := make(map[string]int64, len(m.appliedKeys))
+	for k, n := range m.appliedKeys {
+		counts[k] = n
+	}
+	return counts
+}
+
+// RecordResourceResult counts one result for the service's resource type
+func (m *MetricsCollector) RecordResourceResult(service, resourceType, status string) {
+	if m == nil {
+		return
+	}
+	m.typesMu.Lock()
+	defer m.typesMu.Unlock()
+	if m.typeCounts == nil {
+		m.typeCounts = make(map[resourceTypeKey]*ReportSummary)
+	}
+	key := resourceTypeKey{service: service, resourceType: resourceType}
+	counts := m.typeCounts[key]
+	if counts == nil {
+		counts = &ReportSummary{}
+		m.typeCounts[key] = counts
+	}
+	switch status {
+	case StatusTagged:
+		counts.Tagged++
+	case StatusFailed:
+		counts.Failed++
+	case StatusSkipped:
+		counts.Skipped++
+	case StatusWouldTag:
+		counts.WouldTag++
+	}
+}
+
+// ResourceTypes returns the per resource type counts ordered by service and type
+func (m *MetricsCollector) ResourceTypes() []ResourceTypeSummary {
+	if m == nil {
+		return nil
+	}
+	m.typesMu.Lock()
+	defer m.typesMu.Unlock()
+	if len(m.typeCounts) == 0 {
+		return nil
+	}
+	summaries := make([]ResourceTypeSummary, 0, len(m.typeCounts))
+	for key, counts := range m.typeCounts {
+		summaries = append(summaries, ResourceTypeSummary{
+			Service:       key.service,
+			ResourceType:  key.resourceType,
+			ReportSummary: *counts,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Service != summaries[j].Service {
+			return summaries[i].Service < summaries[j].Service
+		}
+		return summaries[i].ResourceType < summaries[j].ResourceType
+	})
+	return summaries
+}
+
+// formatResourceTypes renders one line per service as "Service: type tagged/total, ...",
+// where tagged includes resources a dry run would have tagged
+func formatResourceTypes(summaries []ResourceTypeSummary) string {
+	var b strings.Builder
+	for i := 0; i < len(summaries); {
+		service := summaries[i].Service
+		var parts []string
+		for ; i < len(summaries) && summaries[i].Service == service; i++ {
+			s := summaries[i]
+			total := s.Tagged + s.Failed + s.Skipped + s.WouldTag
+			parts = append(parts, fmt.Sprintf("%s %d/%d", s.ResourceType, s.Tagged+s.WouldTag, total))
+		}
+		fmt.Fprintf(&b, "%s: %s\n", service, strings.Join(parts, ", "))
+	}
+	return b.String()
+}
+
+// formatAppliedKeys renders key counts as "key (n), ..." with the most applied first
+func formatAppliedKeys(counts map[string]int64) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s (%d)", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// isTagWriteOperation reports whether an API operation adds or changes tags.
+// CodeBuild has no tag API; the tagger only calls UpdateProject to set tags.
+func isTagWriteOperation(operation string) bool {
+	switch {
+	case operation == "UpdateProject":
+		return true
+	case strings.HasPrefix(operation, "Tag"),
+		strings.HasPrefix(operation, "AddTags"),
+		strings.HasPrefix(operation, "CreateTags"),
+		strings.HasPrefix(operation, "UpdateTags"):
+		return true
+	case strings.HasPrefix(operation, "Put") && strings.HasSuffix(operation, "Tagging"):
+		return true
+	}
+	return false
+}
+
+// countTagWrites returns an SDK API option that records every tag write
+// issued by any service client built from the config
+func countTagWrites(m *MetricsCollector) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("CountTagWrites",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				if isTagWriteOperation(awsmiddleware.GetOperationName(ctx)) {
+					m.RecordWrite()
+				}
+				return next.HandleInitialize(ctx, in)
+			}), middleware.After)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/opensearch"
@@ -19,6 +20,16 @@ type OpenSearchAPI interface {
 	ListTags(ctx context.Context, params *opensearch.ListTagsInput, optFns ...func(*opensearch.Options)) (*opensearch.ListTagsOutput, error)
 }
 
+// defaultOpenSearchWorkers is how many domains are tagged at once when --opensearch-workers is unset
+const defaultOpenSearchWorkers = 4
+
+// WithOpenSearchWorkers sets how many OpenSearch domains are tagged at once; zero uses the default
+func WithOpenSearchWorkers(n int) Option {
+	return func(t *AWSResourceTagger) {
+		t.openSearchWorkerCount = n
+	}
+}
+
 // formatTags converts a slice of OpenSearch tags to a human-readable string
 func formatTags(tags []ostypes.Tag) string {
 	var tagPairs []string
@@ -30,8 +41,12 @@ func formatTags(tags []ostypes.Tag) string {
 	return fmt.Sprintf("{%s}", strings.Join(tagPairs, ", "))
 }
 
+func init() {
+	registerService("OpenSearch", (*AWSResourceTagger).tagOpenSearchResources)
+}
+
 // tagOpenSearchResources is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagOpenSearchResources() {
+func (t *AWSResourceTagger) tagOpenSearchResources() error {
 	fmt.Println("====================================")
 	log.Println("Starting OpenSearch resource tagging...")
 
@@ -39,9 +54,11 @@ func (t *AWSResourceTagger) tagOpenSearchResources() {
 	t.tagOpenSearchResourcesWithClient(client)
 
 	log.Println("Completed OpenSearch resource tagging")
+	return nil
 }
 
-// tagOpenSearchResourcesWithClient handles the actual tagging logic with a provided client
+// tagOpenSearchResourcesWithClient handles the actual tagging logic with a
+// provided client, describing and tagging domains in parallel
 func (t *AWSResourceTagger) tagOpenSearchResourcesWithClient(client OpenSearchAPI) {
 	// List all OpenSearch domains
 	listDomainsOutput, err := client.ListDomainNames(t.ctx, &opensearch.ListDomainNamesInput{})
@@ -53,52 +70,81 @@ func (t *AWSResourceTagger) tagOpenSearchResourcesWithClient(client OpenSearchAP
 	// Convert the generic tags map to OpenSearch TagList
 	openSearchTags := convertToOpenSearchTags(t.tags)
 
-	// Tag each domain
+	domains := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < t.openSearchWorkers(len(listDomainsOutput.DomainNames)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for domainName := range domains {
+				t.tagOpenSearchDomain(client, domainName, openSearchTags)
+			}
+		}()
+	}
 	for _, domain := range listDomainsOutput.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
+		domains <- aws.ToString(domain.DomainName)
+	}
+	close(domains)
+	wg.Wait()
+}
 
-		// Get the domain's ARN
-		describeOutput, err := client.DescribeDomain(t.ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
-			t.handleError(err, domainName, "OpenSearch")
-			continue
-		}
+// openSearchWorkers returns how many domains are tagged at once: the
+// --opensearch-workers setting or defaultOpenSearchWorkers, capped at the domain count
+func (t *AWSResourceTagger) openSearchWorkers(domains int) int {
+	workers := defaultOpenSearchWorkers
+	if t.openSearchWorkerCount > 0 {
+		workers = t.openSearchWorkerCount
+	}
+	if workers > domains {
+		workers = domains
+	}
+	return workers
+}
 
-		// Add tags to the domain
-		_, err = client.AddTags(t.ctx, &opensearch.AddTagsInput{
+// tagOpenSearchDomain describes a single domain for its ARN and tags it
+func (t *AWSResourceTagger) tagOpenSearchDomain(client OpenSearchAPI, domainName string, openSearchTags []ostypes.Tag) {
+	// Get the domain's ARN
+	describeOutput, err := client.DescribeDomain(t.ctx, &opensearch.DescribeDomainInput{
+		DomainName: aws.String(domainName),
+	})
+	if err != nil {
+		t.handleError(err, domainName, "OpenSearch")
+		return
+	}
+
+	// Add tags to the domain
+	t.applyAndRecord(TagResult{
+		Service:      "OpenSearch",
+		ResourceType: "domain",
+		ResourceID:   domainName,
+		ARN:          aws.ToString(describeOutput.DomainStatus.ARN),
+	}, func() error {
+		_, err := client.AddTags(t.ctx, &opensearch.AddTagsInput{
 			ARN:     describeOutput.DomainStatus.ARN,
 			TagList: openSearchTags,
 		})
-		if err != nil {
-			t.handleError(err, domainName, "OpenSearch")
-			log.Printf("Failed to tag OpenSearch domain: %s", domainName)
-		} else {
-			log.Printf("Successfully tagged OpenSearch domain: %s with tags %s",
-				domainName, formatTags(openSearchTags))
-		}
+		return err
+	})
 
-		// List current tags for verification
-		listTagsOutput, err := client.ListTags(t.ctx, &opensearch.ListTagsInput{
-			ARN: describeOutput.DomainStatus.ARN,
-		})
-		if err != nil {
-			log.Printf("Error listing tags for OpenSearch domain %s: %v", domainName, err)
-		} else {
-			log.Printf("Current tags for OpenSearch domain %s: %s",
-				domainName, formatTags(listTagsOutput.TagList))
-		}
+	// List current tags for verification
+	listTagsOutput, err := client.ListTags(t.ctx, &opensearch.ListTagsInput{
+		ARN: describeOutput.DomainStatus.ARN,
+	})
+	if err != nil {
+		log.Printf("Error listing tags for OpenSearch domain %s: %v", domainName, err)
+	} else {
+		log.Printf("Current tags for OpenSearch domain %s: %s",
+			domainName, formatTags(listTagsOutput.TagList))
 	}
 }
 
 // Helper function to convert tags map to OpenSearch tags
 func convertToOpenSearchTags(tags map[string]string) []ostypes.Tag {
 	openSearchTags := make([]ostypes.Tag, 0, len(tags))
-	for k, v := range tags {
+	for _, k := range sortedTagKeys(tags) {
 		openSearchTags = append(openSearchTags, ostypes.Tag{
 			Key:   aws.String(k),
-			Value: aws.String(v),
+			Value: aws.String(tags[k]),
 		})
 	}
 	return openSearchTags
@@ -197,3 +197,49 @@ func TestTagOpenSearchResources(t *testing.T) {
 		})
 	}
 }
+
+func TestTagOpenSearchDomainsInParallel(t *testing.T) {
+	mockClient := new(MockOpenSearchClient)
+	var domains []ostypes.DomainInfo
+	for _, name := range []string{"logs", "broken", "search", "metrics", "audit"} {
+		domains = append(domains, ostypes.DomainInfo{DomainName: aws.String(name)})
+	}
+	mockClient.On("ListDomainNames", mock.Anything, mock.Anything).Return(&opensearch.ListDomainNamesOutput{DomainNames: domains}, nil)
+
+	for _, domain := range domains {
+		name := aws.ToString(domain.DomainName)
+		if name == "broken" {
+			mockClient.On("DescribeDomain", mock.Anything, &opensearch.DescribeDomainInput{DomainName: domain.DomainName}).
+				Return(nil, errors.New("ResourceNotFoundException"))
+			continue
+		}
+		mockClient.On("DescribeDomain", mock.Anything, &opensearch.DescribeDomainInput{DomainName: domain.DomainName}).
+			Return(&opensearch.DescribeDomainOutput{DomainStatus: &ostypes.DomainStatus{ARN: aws.String("arn:aws:es:us-west-2:123456789012:domain/" + name)}}, nil)
+		mockClient.On("AddTags", mock.Anything, mock.MatchedBy(func(input *opensearch.AddTagsInput) bool {
+			return aws.ToString(input.ARN) == "arn:aws:es:us-west-2:123456789012:domain/"+name
+		})).Return(&opensearch.AddTagsOutput{}, nil).Once()
+	}
+	mockClient.On("ListTags", mock.Anything, mock.Anything).Return(&opensearch.ListTagsOutput{}, nil)
+
+	tagger := createTestTagger()
+	WithOpenSearchWorkers(3)(tagger)
+	tagger.tagOpenSearchResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "DescribeDomain", 5)
+	mockClient.AssertNumberOfCalls(t, "AddTags", 4)
+}
+
+func TestOpenSearchWorkers(t *testing.T) {
+	tagger := &AWSResourceTagger{}
+	assert.Equal(t, defaultOpenSearchWorkers, tagger.openSearchWorkers(10))
+	assert.Equal(t, 2, tagger.openSearchWorkers(2))
+	assert.Equal(t, 0, tagger.openSearchWorkers(0))
+
+	WithOpenSearchWorkers(8)(tagger)
+	assert.Equal(t, 8, tagger.openSearchWorkers(10))
+
+	// The API call cap does not change the worker count
+	WithConcurrency(2)(tagger)
+	assert.Equal(t, 8, tagger.openSearchWorkers(10))
+}
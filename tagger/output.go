@@ -0,0 +1,161 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Artifact names written under --output-dir
+const (
+	reportFileName   = "report.json"
+	reportCSVName    = "report.csv"
+	failuresFileName = "failures.txt"
+	summaryFileName  = "summary.json"
+	metricsFileName  = "metrics.prom"
+)
+
+// RunSummary is the compact run overview written to summary.json
+type RunSummary struct {
+	AccountID   string        `json:"account_id"`
+	Region      string        `json:"region"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Complete    bool          `json:"complete"`
+	Interrupted string        `json:"interrupted,omitempty"`
+	Summary     ReportSummary `json:"summary"`
+	// Regions breaks Summary down per region with --output-summary-per-region
+	Regions     []RegionSummary `json:"regions,omitempty"`
+	APIErrors   int64           `json:"api_errors"`
+	Throttles   int64           `json:"throttles"`
+	TagWrites   int64           `json:"tag_writes"`
+	Unavailable int64           `json:"unavailable"`
+	// AlreadyTagged counts resources skipped because they carried every desired tag
+	AlreadyTagged int64 `json:"already_tagged"`
+	// AppliedKeys counts, per tag key, the resources successfully tagged with it
+	AppliedKeys map[string]int64 `json:"applied_keys,omitempty"`
+	// ResourceTypes breaks Summary down per service and resource type with --resource-type-summary
+	ResourceTypes []ResourceTypeSummary `json:"resource_types,omitempty"`
+}
+
+// outputPaths lists where each run artifact is written; empty means not written
+type outputPaths struct {
+	report   string
+	failures string
+	summary  string
+	metrics  string
+}
+
+// WithOutputDir writes the report, failed ARNs, summary and metrics into a
+// timestamped subfolder of dir. Individual file options take precedence.
+func WithOutputDir(dir string) Option {
+	return func(t *AWSResourceTagger) {
+		t.outputDir = dir
+	}
+}
+
+// outputPaths resolves the artifact paths for a run that ended at generatedAt,
+// creating the timestamped output folder when --output-dir is set
+func (t *AWSResourceTagger) outputPaths(generatedAt time.Time) (outputPaths, error) {
+	paths := outputPaths{report: t.reportFile, failures: t.failedARNsFile}
+	if t.outputDir == "" {
+		return paths, nil
+	}
+
+	dir := filepath.Join(t.outputDir, generatedAt.UTC().Format("20060102T150405Z"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return paths, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if paths.report == "" && t.outputFormat == OutputCSV {
+		paths.report = filepath.Join(dir, reportCSVName)
+	} else if paths.report == "" {
+		paths.report = filepath.Join(dir, reportFileName)
+	}
+	if paths.failures == "" {
+		paths.failures = filepath.Join(dir, failuresFileName)
+	}
+	paths.summary = filepath.Join(dir, summaryFileName)
+	paths.metrics = filepath.Join(dir, metricsFileName)
+	return paths, nil
+}
+
+// buildRunSummary condenses a report and the run's API metrics
+func (t *AWSResourceTagger) buildRunSummary(report Report) RunSummary {
+	return RunSummary{
+		AccountID:     report.AccountID,
+		Region:        report.Region,
+		GeneratedAt:   report.GeneratedAt,
+		Complete:      report.Complete,
+		Interrupted:   report.Interrupted,
+		Summary:       report.Summary,
+		Regions:       report.Regions,
+		APIErrors:     t.metrics.APIErrors(),
+		Throttles:     t.metrics.Throttles(),
+		TagWrites:     t.metrics.Writes(),
+		Unavailable:   t.metrics.Unavailable(),
+		AlreadyTagged: t.metrics.AlreadyTagged(),
+		AppliedKeys:   t.metrics.AppliedKeys(),
+		ResourceTypes: t.resourceTypeSummaries(),
+	}
+}
+
+// WithResourceTypeSummary adds a per service and resource type breakdown of the
+// results to the end-of-run log and the summary file
+func WithResourceTypeSummary(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.resourceTypeSummary = enabled
+	}
+}
+
+// resourceTypeSummaries returns the per resource type counts when --resource-type-summary is set
+func (t *AWSResourceTagger) resourceTypeSummaries() []ResourceTypeSummary {
+	if !t.resourceTypeSummary {
+		return nil
+	}
+	return t.metrics.ResourceTypes()
+}
+
+// writeMetricsFile writes the run summary in the Prometheus text exposition
+// format, suitable for the node_exporter textfile collector
+func writeMetricsFile(path string, summary RunSummary) error {
+	var b strings.Builder
+	writeMetric := func(name, help, labels string, value int64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		fmt.Fprintf(&b, "%s{%s} %d\n", name, labels, value)
+	}
+	labels := fmt.Sprintf("account_id=%q,region=%q", summary.AccountID, summary.Region)
+
+	fmt.Fprintf(&b, "# HELP aws_tagger_resources Resources processed in the last run by status\n")
+	fmt.Fprintf(&b, "# TYPE aws_tagger_resources gauge\n")
+	for _, s := range []struct {
+		status string
+		count  int
+	}{
+		{StatusTagged, summary.Summary.Tagged},
+		{StatusFailed, summary.Summary.Failed},
+		{StatusSkipped, summary.Summary.Skipped},
+		{StatusWouldTag, summary.Summary.WouldTag},
+	} {
+		fmt.Fprintf(&b, "aws_tagger_resources{%s,status=%q} %d\n", labels, s.status, s.count)
+	}
+	writeMetric("aws_tagger_api_errors", "Non-throttling API errors in the last run", labels, summary.APIErrors)
+	writeMetric("aws_tagger_throttles", "Throttled API calls in the last run", labels, summary.Throttles)
+	writeMetric("aws_tagger_tag_writes", "Tag write API calls issued in the last run", labels, summary.TagWrites)
+	writeMetric("aws_tagger_unavailable", "Calls skipped because the service is not offered in the region", labels, summary.Unavailable)
+	writeMetric("aws_tagger_already_tagged", "Resources skipped because they already carried every desired tag", labels, summary.AlreadyTagged)
+
+	complete := int64(0)
+	if summary.Complete {
+		complete = 1
+	}
+	writeMetric("aws_tagger_run_complete", "Whether the last run finished without interruption", labels, complete)
+	writeMetric("aws_tagger_last_run_timestamp_seconds", "Unix time the last run ended", labels, summary.GeneratedAt.Unix())
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	log.Printf("Wrote metrics to %s", path)
+	return nil
+}
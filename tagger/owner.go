@@ -0,0 +1,23 @@
+package tagger
+
+import "github.com/aws/aws-sdk-go-v2/aws"
+
+// WithOwnedOnly skips resources whose describe output names another account
+// as owner, such as RAM-shared transit gateways. EBS snapshots and AMIs are
+// always listed with owner self, so the option does not change them.
+func WithOwnedOnly(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.ownedOnly = enabled
+	}
+}
+
+// skipNotOwned records a skip when --owned-only is set and ownerID is not the
+// tagger's account. An unknown owner is treated as owned.
+func (t *AWSResourceTagger) skipNotOwned(target TagResult, ownerID *string) bool {
+	owner := aws.ToString(ownerID)
+	if !t.ownedOnly || owner == "" || owner == t.accountID {
+		return false
+	}
+	t.skipResource(target, "owned by account "+owner)
+	return true
+}
@@ -0,0 +1,75 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSnapshotsAndImagesAreListedByOwnerSelfRegardlessOfOwnedOnly(t *testing.T) {
+	for _, ownedOnly := range []bool{false, true} {
+		mockClient := new(MockEC2Client)
+		mockClient.On("DescribeSnapshots", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeSnapshotsInput) bool {
+			return assert.ObjectsAreEqual([]string{"self"}, input.OwnerIds) && input.RestorableByUserIds == nil
+		})).Return(&ec2.DescribeSnapshotsOutput{
+			Snapshots: []ec2types.Snapshot{{SnapshotId: aws.String("snap-own"), OwnerId: aws.String("123456789012")}},
+		}, nil).Once()
+		mockClient.On("DescribeImages", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeImagesInput) bool {
+			return assert.ObjectsAreEqual([]string{"self"}, input.Owners) && input.ExecutableUsers == nil
+		})).Return(&ec2.DescribeImagesOutput{
+			Images: []ec2types.Image{{ImageId: aws.String("ami-own"), OwnerId: aws.String("123456789012")}},
+		}, nil).Once()
+		var tagged []string
+		mockClient.On("CreateTags", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			tagged = append(tagged, args.Get(1).(*ec2.CreateTagsInput).Resources...)
+		}).Return(&ec2.CreateTagsOutput{}, nil)
+
+		tagger := createTestTagger()
+		tagger.accountID = "123456789012"
+		WithOwnedOnly(ownedOnly)(tagger)
+		tagger.tagEC2SnapshotsWithClient(mockClient)
+		tagger.tagEC2ImagesWithClient(mockClient)
+
+		mockClient.AssertExpectations(t)
+		assert.Equal(t, []string{"snap-own", "ami-own"}, tagged, "owned-only=%v", ownedOnly)
+	}
+}
+
+func TestOwnedOnlySkipsSharedTransitGateway(t *testing.T) {
+	mockClient := new(MockVPCClient)
+	mockClient.On("DescribeTransitGateways", mock.Anything, mock.Anything).Return(&ec2.DescribeTransitGatewaysOutput{
+		TransitGateways: []ec2types.TransitGateway{
+			{TransitGatewayId: aws.String("tgw-shared"), OwnerId: aws.String("210987654321")},
+		},
+	}, nil)
+	mockClient.On("DescribeTransitGatewayAttachments", mock.Anything, mock.Anything).Return(&ec2.DescribeTransitGatewayAttachmentsOutput{
+		TransitGatewayAttachments: []ec2types.TransitGatewayAttachment{
+			{TransitGatewayAttachmentId: aws.String("tgw-attach-ours"), TransitGatewayId: aws.String("tgw-shared")},
+		},
+	}, nil)
+	mockClient.On("DescribeTransitGatewayPeeringAttachments", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeTransitGatewayPeeringAttachmentsOutput{}, nil)
+	mockClient.On("DescribeTransitGatewayConnectPeers", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeTransitGatewayConnectPeersOutput{}, nil)
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	WithOwnedOnly(true)(tagger)
+	tagger.tagTransitGatewayResourcesWithClient(mockClient)
+
+	mockClient.AssertNotCalled(t, "CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "tgw-shared"
+	}))
+	mockClient.AssertCalled(t, "CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "tgw-attach-ours"
+	}))
+	results := tagger.Results()
+	assert.NotEmpty(t, results)
+	assert.Equal(t, "tgw-shared", results[0].ResourceID)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+}
@@ -16,11 +16,16 @@ type RDSAPI interface {
 	DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error)
 	DescribeDBSnapshots(ctx context.Context, params *rds.DescribeDBSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error)
 	DescribeDBClusterSnapshots(ctx context.Context, params *rds.DescribeDBClusterSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error)
+	DescribeEventSubscriptions(ctx context.Context, params *rds.DescribeEventSubscriptionsInput, optFns ...func(*rds.Options)) (*rds.DescribeEventSubscriptionsOutput, error)
 	AddTagsToResource(ctx context.Context, params *rds.AddTagsToResourceInput, optFns ...func(*rds.Options)) (*rds.AddTagsToResourceOutput, error)
 }
 
+func init() {
+	registerService("RDS", (*AWSResourceTagger).tagRDSResources)
+}
+
 // tagRDSResources is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagRDSResources() {
+func (t *AWSResourceTagger) tagRDSResources() error {
 	fmt.Println("=====================================")
 	log.Println("Tagging RDS resources...")
 
@@ -28,6 +33,7 @@ func (t *AWSResourceTagger) tagRDSResources() {
 	t.tagRDSResourcesWithClient(client)
 
 	log.Println("Completed tagging RDS resources")
+	return nil
 }
 
 // tagRDSResourcesWithClient handles the actual tagging logic with a provided client
@@ -36,102 +42,161 @@ func (t *AWSResourceTagger) tagRDSResourcesWithClient(client RDSAPI) {
 	t.tagDBClustersWithClient(client)
 	t.tagDBSnapshotsWithClient(client)
 	t.tagClusterSnapshotsWithClient(client)
+	t.tagEventSubscriptionsWithClient(client)
 }
 
 // tagDBInstancesWithClient tags RDS DB instances
 func (t *AWSResourceTagger) tagDBInstancesWithClient(client RDSAPI) {
-	instances, err := client.DescribeDBInstances(t.ctx, &rds.DescribeDBInstancesInput{})
-	if err != nil {
-		t.handleError(err, "all", "RDS DB Instances")
-		return
-	}
+	input := &rds.DescribeDBInstancesInput{}
+	for {
+		instances, err := client.DescribeDBInstances(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "RDS DB Instances")
+			return
+		}
 
-	for _, instance := range instances.DBInstances {
-		arn := aws.ToString(instance.DBInstanceArn)
-		input := &rds.AddTagsToResourceInput{
-			ResourceName: instance.DBInstanceArn,
-			Tags:         t.convertToRDSTags(),
+		for _, instance := range instances.DBInstances {
+			target := TagResult{
+				Service:      "RDS",
+				ResourceType: "instance",
+				ResourceID:   aws.ToString(instance.DBInstanceIdentifier),
+				ARN:          aws.ToString(instance.DBInstanceArn),
+			}
+			if t.skipCreatedBefore(target, instance.InstanceCreateTime) {
+				continue
+			}
+			t.tagRDSResource(client, target, instance.TagList)
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
-		if err != nil {
-			t.handleError(err, arn, "RDS DB Instance")
-			continue
+		if aws.ToString(instances.Marker) == "" {
+			break
 		}
-		log.Printf("Successfully tagged RDS instance: %s", aws.ToString(instance.DBInstanceIdentifier))
+		input.Marker = instances.Marker
 	}
 }
 
 // tagDBClustersWithClient tags RDS DB clusters
 func (t *AWSResourceTagger) tagDBClustersWithClient(client RDSAPI) {
-	clusters, err := client.DescribeDBClusters(t.ctx, &rds.DescribeDBClustersInput{})
-	if err != nil {
-		t.handleError(err, "all", "RDS DB Clusters")
-		return
-	}
+	input := &rds.DescribeDBClustersInput{}
+	for {
+		clusters, err := client.DescribeDBClusters(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "RDS DB Clusters")
+			return
+		}
 
-	for _, cluster := range clusters.DBClusters {
-		arn := aws.ToString(cluster.DBClusterArn)
-		input := &rds.AddTagsToResourceInput{
-			ResourceName: cluster.DBClusterArn,
-			Tags:         t.convertToRDSTags(),
+		for _, cluster := range clusters.DBClusters {
+			t.tagRDSResource(client, TagResult{
+				Service:      "RDS",
+				ResourceType: "cluster",
+				ResourceID:   aws.ToString(cluster.DBClusterIdentifier),
+				ARN:          aws.ToString(cluster.DBClusterArn),
+			}, cluster.TagList)
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
-		if err != nil {
-			t.handleError(err, arn, "RDS DB Cluster")
-			continue
+		if aws.ToString(clusters.Marker) == "" {
+			break
 		}
-		log.Printf("Successfully tagged RDS cluster: %s", aws.ToString(cluster.DBClusterIdentifier))
+		input.Marker = clusters.Marker
 	}
 }
 
 // tagDBSnapshotsWithClient tags RDS DB snapshots
 func (t *AWSResourceTagger) tagDBSnapshotsWithClient(client RDSAPI) {
-	snapshots, err := client.DescribeDBSnapshots(t.ctx, &rds.DescribeDBSnapshotsInput{})
-	if err != nil {
-		t.handleError(err, "all", "RDS DB Snapshots")
-		return
-	}
+	input := &rds.DescribeDBSnapshotsInput{}
+	for {
+		snapshots, err := client.DescribeDBSnapshots(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "RDS DB Snapshots")
+			return
+		}
 
-	for _, snapshot := range snapshots.DBSnapshots {
-		arn := aws.ToString(snapshot.DBSnapshotArn)
-		input := &rds.AddTagsToResourceInput{
-			ResourceName: snapshot.DBSnapshotArn,
-			Tags:         t.convertToRDSTags(),
+		for _, snapshot := range snapshots.DBSnapshots {
+			t.tagRDSResource(client, TagResult{
+				Service:      "RDS",
+				ResourceType: "snapshot",
+				ResourceID:   aws.ToString(snapshot.DBSnapshotIdentifier),
+				ARN:          aws.ToString(snapshot.DBSnapshotArn),
+			}, snapshot.TagList)
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
-		if err != nil {
-			t.handleError(err, arn, "RDS DB Snapshot")
-			continue
+		if aws.ToString(snapshots.Marker) == "" {
+			break
 		}
-		log.Printf("Successfully tagged RDS snapshot: %s", aws.ToString(snapshot.DBSnapshotIdentifier))
+		input.Marker = snapshots.Marker
 	}
 }
 
 // tagClusterSnapshotsWithClient tags RDS cluster snapshots
 func (t *AWSResourceTagger) tagClusterSnapshotsWithClient(client RDSAPI) {
-	snapshots, err := client.DescribeDBClusterSnapshots(t.ctx, &rds.DescribeDBClusterSnapshotsInput{})
-	if err != nil {
-		t.handleError(err, "all", "RDS Cluster Snapshots")
-		return
-	}
+	input := &rds.DescribeDBClusterSnapshotsInput{}
+	for {
+		snapshots, err := client.DescribeDBClusterSnapshots(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "RDS Cluster Snapshots")
+			return
+		}
 
-	for _, snapshot := range snapshots.DBClusterSnapshots {
-		arn := aws.ToString(snapshot.DBClusterSnapshotArn)
-		input := &rds.AddTagsToResourceInput{
-			ResourceName: snapshot.DBClusterSnapshotArn,
-			Tags:         t.convertToRDSTags(),
+		for _, snapshot := range snapshots.DBClusterSnapshots {
+			t.tagRDSResource(client, TagResult{
+				Service:      "RDS",
+				ResourceType: "cluster snapshot",
+				ResourceID:   aws.ToString(snapshot.DBClusterSnapshotIdentifier),
+				ARN:          aws.ToString(snapshot.DBClusterSnapshotArn),
+			}, snapshot.TagList)
 		}
 
-		_, err := client.AddTagsToResource(t.ctx, input)
+		if aws.ToString(snapshots.Marker) == "" {
+			break
+		}
+		input.Marker = snapshots.Marker
+	}
+}
+
+// tagEventSubscriptionsWithClient tags RDS event subscriptions
+func (t *AWSResourceTagger) tagEventSubscriptionsWithClient(client RDSAPI) {
+	input := &rds.DescribeEventSubscriptionsInput{}
+	for {
+		subscriptions, err := client.DescribeEventSubscriptions(t.ctx, input)
 		if err != nil {
-			t.handleError(err, arn, "RDS Cluster Snapshot")
-			continue
+			t.handleError(err, "all", "RDS Event Subscriptions")
+			return
 		}
-		log.Printf("Successfully tagged RDS cluster snapshot: %s", aws.ToString(snapshot.DBClusterSnapshotIdentifier))
+
+		for _, subscription := range subscriptions.EventSubscriptionsList {
+			// DescribeEventSubscriptions does not return existing tags
+			t.tagRDSResource(client, TagResult{
+				Service:      "RDS",
+				ResourceType: "event subscription",
+				ResourceID:   aws.ToString(subscription.CustSubscriptionId),
+				ARN:          aws.ToString(subscription.EventSubscriptionArn),
+			}, nil)
+		}
+
+		if aws.ToString(subscriptions.Marker) == "" {
+			break
+		}
+		input.Marker = subscriptions.Marker
+	}
+}
+
+// tagRDSResource adds the tags to a single RDS resource and records the outcome
+func (t *AWSResourceTagger) tagRDSResource(client RDSAPI, target TagResult, existing []rdstypes.Tag) {
+	if t.skipAlreadyApplied(target, rdsTagMap(existing)) {
+		return
 	}
+	tags := t.rdsTagsFor(existing)
+	if t.skipOverTagLimit(target, rdsTagKeys(existing), rdsTagKeys(tags)) {
+		return
+	}
+	target.Tags = rdsTagMap(tags)
+	t.applyAndRecord(target, func() error {
+		_, err := client.AddTagsToResource(t.ctx, &rds.AddTagsToResourceInput{
+			ResourceName: aws.String(target.ARN),
+			Tags:         tags,
+		})
+		return err
+	})
 }
 
 // tagDBInstances tags RDS DB instances
@@ -233,7 +298,8 @@ func (t *AWSResourceTagger) tagClusterSnapshots(client *rds.Client) {
 // convertToRDSTags converts the common tags map to RDS-specific tags
 func (t *AWSResourceTagger) convertToRDSTags() []rdstypes.Tag {
 	rdsTags := make([]rdstypes.Tag, 0, len(t.tags))
-	for k, v := range t.tags {
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
 		rdsTags = append(rdsTags, rdstypes.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
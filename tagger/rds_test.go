@@ -3,6 +3,7 @@ package tagger
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -51,6 +52,14 @@ func (m *MockRDSClient) DescribeDBClusterSnapshots(ctx context.Context, params *
 	return args.Get(0).(*rds.DescribeDBClusterSnapshotsOutput), args.Error(1)
 }
 
+func (m *MockRDSClient) DescribeEventSubscriptions(ctx context.Context, params *rds.DescribeEventSubscriptionsInput, optFns ...func(*rds.Options)) (*rds.DescribeEventSubscriptionsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*rds.DescribeEventSubscriptionsOutput), args.Error(1)
+}
+
 func (m *MockRDSClient) AddTagsToResource(ctx context.Context, params *rds.AddTagsToResourceInput, optFns ...func(*rds.Options)) (*rds.AddTagsToResourceOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -273,6 +282,60 @@ func TestTagDBClusters(t *testing.T) {
 	}
 }
 
+func TestTagEventSubscriptions(t *testing.T) {
+	tags := map[string]string{"env": "prod"}
+	mockClient := new(MockRDSClient)
+
+	// Two pages linked by Marker
+	mockClient.On("DescribeEventSubscriptions", mock.Anything, &rds.DescribeEventSubscriptionsInput{}).
+		Return(&rds.DescribeEventSubscriptionsOutput{
+			EventSubscriptionsList: []rdstypes.EventSubscription{
+				{
+					CustSubscriptionId:   aws.String("sub-1"),
+					EventSubscriptionArn: aws.String("arn:aws:rds:region:account:es:sub-1"),
+				},
+			},
+			Marker: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("DescribeEventSubscriptions", mock.Anything, &rds.DescribeEventSubscriptionsInput{Marker: aws.String("page-2")}).
+		Return(&rds.DescribeEventSubscriptionsOutput{
+			EventSubscriptionsList: []rdstypes.EventSubscription{
+				{
+					CustSubscriptionId:   aws.String("sub-2"),
+					EventSubscriptionArn: aws.String("arn:aws:rds:region:account:es:sub-2"),
+				},
+			},
+		}, nil).Once()
+
+	for _, arn := range []string{"arn:aws:rds:region:account:es:sub-1", "arn:aws:rds:region:account:es:sub-2"} {
+		mockClient.On("AddTagsToResource", mock.Anything, mock.MatchedBy(matchTagsInput(&rds.AddTagsToResourceInput{
+			ResourceName: aws.String(arn),
+			Tags:         convertToRDSTags(tags),
+		}))).Return(&rds.AddTagsToResourceOutput{}, nil).Once()
+	}
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    tags,
+		results: NewResultCollector(),
+	}
+	tagger.tagEventSubscriptionsWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	for _, r := range results {
+		assert.Equal(t, "event subscription", r.ResourceType)
+		assert.Equal(t, StatusTagged, r.Status)
+	}
+
+	// A describe error stops without tagging
+	failing := new(MockRDSClient)
+	failing.On("DescribeEventSubscriptions", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDenied"))
+	tagger.tagEventSubscriptionsWithClient(failing)
+	failing.AssertNotCalled(t, "AddTagsToResource", mock.Anything, mock.Anything)
+}
+
 // convertToRDSTags converts a map of tags to a slice of rdstypes.Tag to be used with AWS RDS operations.
 func convertToRDSTags(tags map[string]string) []rdstypes.Tag {
 	rdsTags := make([]rdstypes.Tag, 0, len(tags))
@@ -284,3 +347,171 @@ func convertToRDSTags(tags map[string]string) []rdstypes.Tag {
 	}
 	return rdsTags
 }
+
+func TestTagDBInstancesTagLimit(t *testing.T) {
+	// db-full already carries 49 unrelated keys, so adding two more exceeds 50
+	var fullTags []rdstypes.Tag
+	for i := 0; i < 49; i++ {
+		fullTags = append(fullTags, rdstypes.Tag{Key: aws.String(fmt.Sprintf("existing-%d", i)), Value: aws.String("v")})
+	}
+
+	mockClient := new(MockRDSClient)
+	mockClient.On("DescribeDBInstances", mock.Anything, mock.Anything).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db-full"),
+				DBInstanceArn:        aws.String("arn:aws:rds:region:account:db:db-full"),
+				TagList:              fullTags,
+			},
+			{
+				DBInstanceIdentifier: aws.String("db-ok"),
+				DBInstanceArn:        aws.String("arn:aws:rds:region:account:db:db-ok"),
+				// Re-applying an existing key does not add to the count
+				TagList: append(fullTags[:48:48], rdstypes.Tag{Key: aws.String("env"), Value: aws.String("dev")}),
+			},
+		},
+	}, nil)
+	mockClient.On("AddTagsToResource", mock.Anything, mock.MatchedBy(func(input *rds.AddTagsToResourceInput) bool {
+		return aws.ToString(input.ResourceName) == "arn:aws:rds:region:account:db:db-ok"
+	})).Return(&rds.AddTagsToResourceOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod", "team": "platform"},
+		results: NewResultCollector(),
+	}
+	tagger.tagDBInstancesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "AddTagsToResource", 1)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, "db-full", results[0].ResourceID)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, "merged tags would have 51 keys, over the 50-key limit", results[0].Error)
+	assert.Equal(t, StatusTagged, results[1].Status)
+
+	// A lower --max-tag-keys applies to every service
+	WithMaxTagKeys(10)(tagger)
+	assert.Equal(t, 10, tagger.tagKeyLimit("RDS"))
+	assert.Equal(t, 10, tagger.tagKeyLimit("EC2"))
+}
+
+func TestTagRDSResourcesPagination(t *testing.T) {
+	arn := func(kind, id string) string {
+		return fmt.Sprintf("arn:aws:rds:us-west-2:123456789012:%s:%s", kind, id)
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*MockRDSClient)
+		run       func(*AWSResourceTagger, RDSAPI)
+		expected  []string
+	}{
+		{
+			name: "DB instances",
+			setupMock: func(m *MockRDSClient) {
+				m.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{}).Return(&rds.DescribeDBInstancesOutput{
+					DBInstances: []rdstypes.DBInstance{{DBInstanceIdentifier: aws.String("db-1"), DBInstanceArn: aws.String(arn("db", "db-1"))}},
+					Marker:      aws.String("page-2"),
+				}, nil).Once()
+				m.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{Marker: aws.String("page-2")}).Return(&rds.DescribeDBInstancesOutput{
+					DBInstances: []rdstypes.DBInstance{{DBInstanceIdentifier: aws.String("db-2"), DBInstanceArn: aws.String(arn("db", "db-2"))}},
+				}, nil).Once()
+			},
+			run:      func(tagger *AWSResourceTagger, client RDSAPI) { tagger.tagDBInstancesWithClient(client) },
+			expected: []string{arn("db", "db-1"), arn("db", "db-2")},
+		},
+		{
+			name: "DB clusters",
+			setupMock: func(m *MockRDSClient) {
+				m.On("DescribeDBClusters", mock.Anything, &rds.DescribeDBClustersInput{}).Return(&rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{{DBClusterIdentifier: aws.String("cluster-1"), DBClusterArn: aws.String(arn("cluster", "cluster-1"))}},
+					Marker:     aws.String("page-2"),
+				}, nil).Once()
+				m.On("DescribeDBClusters", mock.Anything, &rds.DescribeDBClustersInput{Marker: aws.String("page-2")}).Return(&rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{{DBClusterIdentifier: aws.String("cluster-2"), DBClusterArn: aws.String(arn("cluster", "cluster-2"))}},
+				}, nil).Once()
+			},
+			run:      func(tagger *AWSResourceTagger, client RDSAPI) { tagger.tagDBClustersWithClient(client) },
+			expected: []string{arn("cluster", "cluster-1"), arn("cluster", "cluster-2")},
+		},
+		{
+			name: "DB snapshots",
+			setupMock: func(m *MockRDSClient) {
+				m.On("DescribeDBSnapshots", mock.Anything, &rds.DescribeDBSnapshotsInput{}).Return(&rds.DescribeDBSnapshotsOutput{
+					DBSnapshots: []rdstypes.DBSnapshot{{DBSnapshotIdentifier: aws.String("snap-1"), DBSnapshotArn: aws.String(arn("snapshot", "snap-1"))}},
+					Marker:      aws.String("page-2"),
+				}, nil).Once()
+				m.On("DescribeDBSnapshots", mock.Anything, &rds.DescribeDBSnapshotsInput{Marker: aws.String("page-2")}).Return(&rds.DescribeDBSnapshotsOutput{
+					DBSnapshots: []rdstypes.DBSnapshot{{DBSnapshotIdentifier: aws.String("snap-2"), DBSnapshotArn: aws.String(arn("snapshot", "snap-2"))}},
+				}, nil).Once()
+			},
+			run:      func(tagger *AWSResourceTagger, client RDSAPI) { tagger.tagDBSnapshotsWithClient(client) },
+			expected: []string{arn("snapshot", "snap-1"), arn("snapshot", "snap-2")},
+		},
+		{
+			name: "cluster snapshots",
+			setupMock: func(m *MockRDSClient) {
+				m.On("DescribeDBClusterSnapshots", mock.Anything, &rds.DescribeDBClusterSnapshotsInput{}).Return(&rds.DescribeDBClusterSnapshotsOutput{
+					DBClusterSnapshots: []rdstypes.DBClusterSnapshot{{DBClusterSnapshotIdentifier: aws.String("csnap-1"), DBClusterSnapshotArn: aws.String(arn("cluster-snapshot", "csnap-1"))}},
+					Marker:             aws.String("page-2"),
+				}, nil).Once()
+				m.On("DescribeDBClusterSnapshots", mock.Anything, &rds.DescribeDBClusterSnapshotsInput{Marker: aws.String("page-2")}).Return(&rds.DescribeDBClusterSnapshotsOutput{
+					DBClusterSnapshots: []rdstypes.DBClusterSnapshot{{DBClusterSnapshotIdentifier: aws.String("csnap-2"), DBClusterSnapshotArn: aws.String(arn("cluster-snapshot", "csnap-2"))}},
+				}, nil).Once()
+			},
+			run:      func(tagger *AWSResourceTagger, client RDSAPI) { tagger.tagClusterSnapshotsWithClient(client) },
+			expected: []string{arn("cluster-snapshot", "csnap-1"), arn("cluster-snapshot", "csnap-2")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockRDSClient)
+			tt.setupMock(mockClient)
+			for _, resourceARN := range tt.expected {
+				mockClient.On("AddTagsToResource", mock.Anything, mock.MatchedBy(func(input *rds.AddTagsToResourceInput) bool {
+					return aws.ToString(input.ResourceName) == resourceARN
+				})).Return(&rds.AddTagsToResourceOutput{}, nil).Once()
+			}
+
+			tagger := createTestTagger()
+			tagger.results = NewResultCollector()
+			tt.run(tagger, mockClient)
+
+			mockClient.AssertExpectations(t)
+			results := tagger.Results()
+			assert.Len(t, results, len(tt.expected))
+			for _, result := range results {
+				assert.Equal(t, StatusTagged, result.Status)
+			}
+		})
+	}
+}
+
+func TestTagDBInstancesPaginationStopsOnEmptyMarker(t *testing.T) {
+	mockClient := new(MockRDSClient)
+	mockClient.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{}).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{DBInstanceIdentifier: aws.String("db-1"), DBInstanceArn: aws.String("arn:aws:rds:us-west-2:123456789012:db:db-1")},
+			{DBInstanceIdentifier: aws.String("db-2"), DBInstanceArn: aws.String("arn:aws:rds:us-west-2:123456789012:db:db-2")},
+		},
+		Marker: aws.String("page-2"),
+	}, nil).Once()
+	mockClient.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{Marker: aws.String("page-2")}).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{DBInstanceIdentifier: aws.String("db-3"), DBInstanceArn: aws.String("arn:aws:rds:us-west-2:123456789012:db:db-3")},
+		},
+		Marker: aws.String(""),
+	}, nil).Once()
+	mockClient.On("AddTagsToResource", mock.Anything, mock.Anything).Return(&rds.AddTagsToResourceOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.tagDBInstancesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "DescribeDBInstances", 2)
+	mockClient.AssertNumberOfCalls(t, "AddTagsToResource", 3)
+}
@@ -0,0 +1,67 @@
+package tagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegionSummary counts the results recorded in one region
+type RegionSummary struct {
+	Region string `json:"region"`
+	ReportSummary
+}
+
+// WithRegionSummary adds a per-region breakdown of the results to the report,
+// the summary file and the end-of-run log in multi-region runs. Global
+// services run alongside the first region and are counted there.
+func WithRegionSummary(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.regionSummary = enabled
+	}
+}
+
+// summarize counts results by status
+func summarize(results []TagResult) ReportSummary {
+	var summary ReportSummary
+	for _, r := range results {
+		switch r.Status {
+		case StatusTagged:
+			summary.Tagged++
+		case StatusFailed:
+			summary.Failed++
+		case StatusSkipped:
+			summary.Skipped++
+		case StatusWouldTag:
+			summary.WouldTag++
+		}
+	}
+	return summary
+}
+
+// regionSummaries counts results per target region, in run order
+func (t *AWSResourceTagger) regionSummaries(results []TagResult) []RegionSummary {
+	byRegion := make(map[string][]TagResult)
+	for _, r := range results {
+		byRegion[r.Region] = append(byRegion[r.Region], r)
+	}
+	summaries := make([]RegionSummary, 0, len(t.regions))
+	for _, region := range t.regions {
+		summaries = append(summaries, RegionSummary{Region: region, ReportSummary: summarize(byRegion[region])})
+	}
+	return summaries
+}
+
+// formatRegionSummaries renders one line per region followed by the total
+func formatRegionSummaries(regions []RegionSummary, total ReportSummary) string {
+	var b strings.Builder
+	b.WriteString("Summary by region:\n")
+	line := func(label string, s ReportSummary) {
+		fmt.Fprintf(&b, "  %-16s %d tagged, %d failed, %d skipped, %d would tag\n",
+			label+":", s.Tagged, s.Failed, s.Skipped, s.WouldTag)
+	}
+	for _, r := range regions {
+		line(r.Region, r.ReportSummary)
+	}
+	line("total", total)
+	return b.String()
+}
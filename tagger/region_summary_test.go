@@ -0,0 +1,54 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionSummaryAcrossTwoRegions(t *testing.T) {
+	tagger := &AWSResourceTagger{ctx: context.Background(), region: "us-east-1", results: NewResultCollector()}
+	tagger.cfg.Region = "us-east-1"
+	WithRegions([]string{"eu-west-1", "ap-southeast-2"})(tagger)
+	WithRegionSummary(true)(tagger)
+
+	err := tagger.runAcrossRegions(map[string]func() error{
+		"EC2": func() error {
+			tagger.recordResult(TagResult{Service: "EC2", ResourceID: "i-1", Status: StatusTagged})
+			if tagger.region == "ap-southeast-2" {
+				tagger.recordResult(TagResult{Service: "EC2", ResourceID: "i-2", Status: StatusFailed})
+			}
+			return nil
+		},
+		"S3Buckets": func() error {
+			tagger.recordResult(TagResult{Service: "S3", ResourceID: "logs", Status: StatusSkipped})
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+
+	report := tagger.buildReport()
+	assert.Equal(t, ReportSummary{Tagged: 2, Failed: 1, Skipped: 1}, report.Summary)
+	assert.Equal(t, []RegionSummary{
+		{Region: "eu-west-1", ReportSummary: ReportSummary{Tagged: 1, Skipped: 1}},
+		{Region: "ap-southeast-2", ReportSummary: ReportSummary{Tagged: 1, Failed: 1}},
+	}, report.Regions)
+	assert.Equal(t, report.Regions, tagger.buildRunSummary(report).Regions)
+
+	assert.Equal(t, "Summary by region:\n"+
+		"  eu-west-1:       1 tagged, 0 failed, 1 skipped, 0 would tag\n"+
+		"  ap-southeast-2:  1 tagged, 1 failed, 0 skipped, 0 would tag\n"+
+		"  total:           2 tagged, 1 failed, 1 skipped, 0 would tag\n",
+		formatRegionSummaries(report.Regions, report.Summary))
+}
+
+func TestRegionSummaryOmittedWhenDisabled(t *testing.T) {
+	tagger := &AWSResourceTagger{ctx: context.Background(), region: "us-east-1", results: NewResultCollector()}
+	WithRegions([]string{"eu-west-1", "ap-southeast-2"})(tagger)
+	tagger.recordResult(TagResult{Service: "EC2", ResourceID: "i-1", Status: StatusTagged})
+
+	report := tagger.buildReport()
+	assert.Nil(t, report.Regions)
+	assert.Equal(t, "us-east-1", report.Results[0].Region)
+}
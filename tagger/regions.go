@@ -0,0 +1,115 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// RegionsAPI interface for listing the regions enabled for an account
+type RegionsAPI interface {
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+}
+
+// WithRegions tags region-scoped services in each listed region. The account
+// ID and credentials resolved for the tagger's own region are reused.
+func WithRegions(regions []string) Option {
+	return func(t *AWSResourceTagger) {
+		seen := make(map[string]bool, len(regions))
+		t.regions = nil
+		for _, region := range regions {
+			if region == "" || seen[region] {
+				continue
+			}
+			seen[region] = true
+			t.regions = append(t.regions, region)
+		}
+	}
+}
+
+// WithAllRegions tags every region enabled for the account in place of the
+// regions set with WithRegions
+func WithAllRegions(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.allRegions = enabled
+	}
+}
+
+// enabledRegions lists the regions enabled for the account, home region first
+func (t *AWSResourceTagger) enabledRegions(client RegionsAPI) ([]string, error) {
+	// Without AllRegions, DescribeRegions leaves out regions that are not opted in
+	output, err := client.DescribeRegions(t.ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, aws.ToString(region.RegionName))
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		if (regions[i] == t.region) != (regions[j] == t.region) {
+			return regions[i] == t.region
+		}
+		return regions[i] < regions[j]
+	})
+	return regions, nil
+}
+
+// targetRegions returns the regions a run covers
+func (t *AWSResourceTagger) targetRegions() []string {
+	if len(t.regions) == 0 {
+		return []string{t.region}
+	}
+	return t.regions
+}
+
+// setRegion points the tagger and the clients it creates at region
+func (t *AWSResourceTagger) setRegion(region string) {
+	t.region = region
+	t.cfg.Region = region
+}
+
+// runAcrossRegions runs the region-scoped taggers once per target region, one
+// region at a time, and the global taggers once alongside the first region
+func (t *AWSResourceTagger) runAcrossRegions(resourceTaggers map[string]func() error) error {
+	if len(t.regions) == 0 {
+		return t.runResourceTaggers(resourceTaggers)
+	}
+
+	regional := make(map[string]func() error, len(resourceTaggers))
+	for name, tagger := range resourceTaggers {
+		if !isGlobalService(name) {
+			regional[name] = tagger
+		}
+	}
+
+	home := t.region
+	defer t.setRegion(home)
+	var regionErrors []error
+	for i, region := range t.regions {
+		t.setRegion(region)
+		batch := regional
+		if i == 0 {
+			batch = resourceTaggers
+		}
+		log.Printf("Tagging resources in region %s (%d of %d)", region, i+1, len(t.regions))
+		if err := t.runResourceTaggers(batch); err != nil {
+			if t.stopped() {
+				return err
+			}
+			regionErrors = append(regionErrors, fmt.Errorf("%s: %w", region, err))
+		}
+	}
+	return errors.Join(regionErrors...)
+}
+
+// regionLabel names the target regions for log and error messages
+func (t *AWSResourceTagger) regionLabel() string {
+	return strings.Join(t.targetRegions(), ",")
+}
@@ -0,0 +1,113 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRegionsClient is a mock implementation of RegionsAPI
+type mockRegionsClient struct {
+	mock.Mock
+}
+
+func (m *mockRegionsClient) DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeRegionsOutput), args.Error(1)
+}
+
+func TestWithRegionsDropsDuplicates(t *testing.T) {
+	tagger := &AWSResourceTagger{region: "us-east-1"}
+	assert.Equal(t, []string{"us-east-1"}, tagger.targetRegions())
+
+	WithRegions([]string{"us-east-1", "", "eu-west-1", "us-east-1"})(tagger)
+	assert.Equal(t, []string{"us-east-1", "eu-west-1"}, tagger.targetRegions())
+	assert.Equal(t, "us-east-1,eu-west-1", tagger.regionLabel())
+}
+
+func TestRunAcrossRegions(t *testing.T) {
+	tagger := &AWSResourceTagger{ctx: context.Background(), region: "us-east-1"}
+	tagger.cfg.Region = "us-east-1"
+	WithRegions([]string{"eu-west-1", "ap-southeast-2"})(tagger)
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) func() error {
+		return func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name+"@"+tagger.region+"/"+tagger.cfg.Region)
+			return nil
+		}
+	}
+
+	err := tagger.runAcrossRegions(map[string]func() error{
+		"EC2":       record("EC2"),
+		"S3Buckets": record("S3Buckets"),
+	})
+
+	assert.NoError(t, err)
+	sort.Strings(calls)
+	assert.Equal(t, []string{
+		"EC2@ap-southeast-2/ap-southeast-2",
+		"EC2@eu-west-1/eu-west-1",
+		"S3Buckets@eu-west-1/eu-west-1",
+	}, calls)
+	assert.Equal(t, "us-east-1", tagger.region, "home region is restored after the run")
+	assert.Equal(t, "us-east-1", tagger.cfg.Region)
+}
+
+func TestDiscoveryCacheIsScopedByRegion(t *testing.T) {
+	tagger := &AWSResourceTagger{region: "eu-west-1"}
+	WithDiscoveryCache(true)(tagger)
+
+	calls := 0
+	discover := func() ([]string, error) {
+		calls++
+		return []string{tagger.region}, nil
+	}
+
+	first, _ := cachedDiscovery(tagger, "svc", discover)
+	tagger.setRegion("us-east-1")
+	second, _ := cachedDiscovery(tagger, "svc", discover)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"eu-west-1"}, first)
+	assert.Equal(t, []string{"us-east-1"}, second)
+}
+
+func TestEnabledRegions(t *testing.T) {
+	client := new(mockRegionsClient)
+	client.On("DescribeRegions", mock.Anything, &ec2.DescribeRegionsInput{}).Return(&ec2.DescribeRegionsOutput{
+		Regions: []ec2types.Region{
+			{RegionName: aws.String("us-west-2")},
+			{RegionName: aws.String("eu-west-1")},
+			{RegionName: aws.String("us-east-1")},
+			{RegionName: aws.String("ap-southeast-2")},
+		},
+	}, nil).Once()
+
+	tagger := &AWSResourceTagger{ctx: context.Background(), region: "us-east-1"}
+	regions, err := tagger.enabledRegions(client)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-east-1", "ap-southeast-2", "eu-west-1", "us-west-2"}, regions)
+	client.AssertExpectations(t)
+
+	client = new(mockRegionsClient)
+	client.On("DescribeRegions", mock.Anything, mock.Anything).Return(nil, errors.New("UnauthorizedOperation"))
+	_, err = tagger.enabledRegions(client)
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package tagger
+
+import "fmt"
+
+// serviceEntry is the entry point that tags all resources of one service,
+// returning an error when any of them could not be tagged
+type serviceEntry func(t *AWSResourceTagger) error
+
+// serviceRegistration is a registered service's entry point; global services
+// are not region scoped and run once per multi-region run
+type serviceRegistration struct {
+	entry  serviceEntry
+	global bool
+}
+
+// serviceRegistry maps each service name accepted by --only-services and
+// --exclude-services to its registration. Service files add themselves from init.
+var serviceRegistry = map[string]serviceRegistration{}
+
+// registerService adds a region-scoped service to the registry, panicking on duplicate names
+func registerService(name string, entry serviceEntry) {
+	addService(name, serviceRegistration{entry: entry})
+}
+
+// registerGlobalService adds a service that is tagged once per run rather than once per region
+func registerGlobalService(name string, entry serviceEntry) {
+	addService(name, serviceRegistration{entry: entry, global: true})
+}
+
+// addService stores a registration, panicking on duplicate names
+func addService(name string, registration serviceRegistration) {
+	if _, exists := serviceRegistry[name]; exists {
+		panic(fmt.Sprintf("tagger: service %q registered twice", name))
+	}
+	serviceRegistry[name] = registration
+}
+
+// isGlobalService reports whether name was registered with registerGlobalService
+func isGlobalService(name string) bool {
+	return serviceRegistry[name].global
+}
+
+// resourceTaggers binds every registered service to this tagger
+func (t *AWSResourceTagger) resourceTaggers() map[string]func() error {
+	taggers := make(map[string]func() error, len(serviceRegistry))
+	for name, registration := range serviceRegistry {
+		entry := registration.entry
+		taggers[name] = func() error { return entry(t) }
+	}
+	return taggers
+}
@@ -0,0 +1,73 @@
+package tagger
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRegistryIsComplete(t *testing.T) {
+	expected := []string{
+		"AIServices", "Athena", "Beanstalk", "CloudWatch", "Code", "DynamoDB", "EC2", "ECS", "EMRServerless",
+		"EFS", "EKS", "ELB", "ElastiCache", "GlobalAccelerator", "Glue", "Kinesis", "Lambda",
+		"Lightsail", "OpenSearch", "RDS", "S3Buckets", "S3DirectoryBuckets", "SNS", "SQS", "VPC",
+	}
+	sort.Strings(expected)
+
+	assert.Equal(t, expected, ServiceNames())
+	for name, registration := range serviceRegistry {
+		assert.NotNil(t, registration.entry, "service %s has no entry point", name)
+	}
+}
+
+func TestTagAllResourcesInvokesEveryRegisteredService(t *testing.T) {
+	saved := serviceRegistry
+	defer func() { serviceRegistry = saved }()
+
+	var mu sync.Mutex
+	invoked := map[string]int{}
+	serviceRegistry = map[string]serviceRegistration{}
+	for name := range saved {
+		name := name
+		registerService(name, func(*AWSResourceTagger) error {
+			mu.Lock()
+			defer mu.Unlock()
+			invoked[name]++
+			return nil
+		})
+	}
+
+	tagger := &AWSResourceTagger{ctx: context.Background(), metrics: NewMetricsCollector()}
+	assert.NoError(t, tagger.runResourceTaggers(tagger.resourceTaggers()))
+
+	assert.Len(t, invoked, len(saved))
+	for name, count := range invoked {
+		assert.Equal(t, 1, count, "service %s", name)
+	}
+}
+
+func TestRegisterServiceRejectsDuplicates(t *testing.T) {
+	saved := serviceRegistry
+	defer func() { serviceRegistry = saved }()
+
+	serviceRegistry = map[string]serviceRegistration{}
+	registerService("EC2", func(*AWSResourceTagger) error { return nil })
+	assert.Panics(t, func() { registerService("EC2", func(*AWSResourceTagger) error { return nil }) })
+}
+
+func TestGlobalServicesComeFromTheRegistry(t *testing.T) {
+	var global []string
+	for name, registration := range serviceRegistry {
+		if registration.global {
+			global = append(global, name)
+		}
+	}
+	sort.Strings(global)
+
+	assert.Equal(t, []string{"GlobalAccelerator", "S3Buckets"}, global)
+	assert.True(t, isGlobalService("S3Buckets"))
+	assert.False(t, isGlobalService("EC2"))
+}
@@ -0,0 +1,222 @@
+package tagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// ReportSummary counts results by status
+type ReportSummary struct {
+	Tagged  int `json:"tagged"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	// WouldTag counts resources a dry run would have tagged
+	WouldTag int `json:"would_tag,omitempty"`
+}
+
+// Report is the run report written to --report-file
+type Report struct {
+	AccountID   string        `json:"account_id"`
+	Region      string        `json:"region"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Complete    bool          `json:"complete"`
+	Interrupted string        `json:"interrupted,omitempty"`
+	Summary     ReportSummary `json:"summary"`
+	// Regions breaks Summary down per region with --output-summary-per-region
+	Regions []RegionSummary `json:"regions,omitempty"`
+	// Tags are the tags the run wrote to each tagged resource
+	Tags    map[string]string `json:"tags,omitempty"`
+	Results []TagResult       `json:"results"`
+	// Diff compares the run with the --diff-against report
+	Diff *ReportDiff `json:"diff,omitempty"`
+	// VerifyMismatches counts resources whose tags did not read back as
+	// written with --verify
+	VerifyMismatches int64 `json:"verify_mismatches,omitempty"`
+}
+
+// Output formats accepted by WithOutputFormat
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputCSV  = "csv"
+)
+
+// WithOutputFormat selects how the run report is emitted. OutputJSON and
+// OutputCSV print it to stdout when no report file is set; OutputCSV also
+// writes the report file as CSV.
+func WithOutputFormat(format string) Option {
+	return func(t *AWSResourceTagger) {
+		t.outputFormat = format
+	}
+}
+
+// ValidateOutputFormat reports whether format is a known output format
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case "", OutputText, OutputJSON, OutputCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q; valid formats are %s, %s, %s", format, OutputText, OutputJSON, OutputCSV)
+}
+
+// WithReportFile writes a JSON report of all results to path when the run ends
+func WithReportFile(path string) Option {
+	return func(t *AWSResourceTagger) {
+		t.reportFile = path
+	}
+}
+
+// buildReport assembles a report from the results recorded so far
+func (t *AWSResourceTagger) buildReport() Report {
+	report := Report{
+		AccountID:   t.accountID,
+		Region:      t.region,
+		GeneratedAt: time.Now().UTC(),
+		Complete:    true,
+		Tags:        t.tags,
+		Results:     t.Results(),
+	}
+	if report.Results == nil {
+		report.Results = []TagResult{}
+	}
+	if t.deterministic {
+		sortResults(report.Results)
+	}
+	if t.ctx != nil && t.ctx.Err() != nil {
+		report.Complete = false
+		report.Interrupted = t.ctx.Err().Error()
+	}
+	report.Summary = summarize(report.Results)
+	report.VerifyMismatches = atomic.LoadInt64(&t.verifyMismatches)
+	if t.regionSummary && len(t.regions) > 0 {
+		report.Regions = t.regionSummaries(report.Results)
+	}
+	return report
+}
+
+// flush writes the run outputs with whatever has been accumulated so far.
+// It runs when TagAllResources returns, including after cancellation.
+func (t *AWSResourceTagger) flush() {
+	if err := t.checkpoint.close(); err != nil {
+		log.Printf("Error closing checkpoint file %s: %v", t.checkpointFile, err)
+	}
+	t.checkpoint = nil
+
+	report := t.buildReport()
+	if !report.Complete {
+		log.Printf("Run interrupted (%s): %d tagged, %d failed, %d skipped before shutdown",
+			report.Interrupted, report.Summary.Tagged, report.Summary.Failed, report.Summary.Skipped)
+	}
+
+	if len(report.Regions) > 0 {
+		log.Print(formatRegionSummaries(report.Regions, report.Summary))
+	}
+
+	if t.diffAgainst != "" {
+		report.Diff = t.diffReport(report)
+	}
+
+	paths, err := t.outputPaths(report.GeneratedAt)
+	if err != nil {
+		log.Printf("Error preparing %s: %v", t.outputDir, err)
+	}
+
+	if paths.report != "" {
+		if err := t.writeReportFile(paths.report, report); err != nil {
+			log.Printf("Error writing report file %s: %v", paths.report, err)
+		} else {
+			log.Printf("Wrote report to %s", paths.report)
+		}
+	} else if t.outputFormat == OutputJSON {
+		if err := writeJSON(t.stdout(), report); err != nil {
+			log.Printf("Error writing report: %v", err)
+		}
+	} else if t.outputFormat == OutputCSV {
+		if err := writeCSVReport(t.stdout(), report.Results); err != nil {
+			log.Printf("Error writing report: %v", err)
+		}
+	}
+	if paths.failures != "" {
+		if err := t.writeFailedARNsFile(paths.failures); err != nil {
+			log.Printf("Error writing %s: %v", paths.failures, err)
+		}
+	}
+	summary := t.buildRunSummary(report)
+	if len(summary.AppliedKeys) > 0 {
+		log.Printf("Applied keys: %s", formatAppliedKeys(summary.AppliedKeys))
+	}
+	if len(summary.ResourceTypes) > 0 {
+		log.Printf("Resource types:\n%s", formatResourceTypes(summary.ResourceTypes))
+	}
+	if paths.summary != "" {
+		if err := writeJSONFile(paths.summary, summary); err != nil {
+			log.Printf("Error writing summary file %s: %v", paths.summary, err)
+		} else {
+			log.Printf("Wrote summary to %s", paths.summary)
+		}
+	}
+	if paths.metrics != "" {
+		if err := writeMetricsFile(paths.metrics, summary); err != nil {
+			log.Printf("Error writing %s: %v", paths.metrics, err)
+		}
+	}
+}
+
+// writeReportFile writes report to path as CSV with OutputCSV, JSON otherwise
+func (t *AWSResourceTagger) writeReportFile(path string, report Report) error {
+	if t.outputFormat == OutputCSV {
+		return writeCSVFile(path, report.Results)
+	}
+	return writeJSONFile(path, report)
+}
+
+// writeJSON writes the indented JSON encoding of v to w
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// stdout is where reports without a file go
+func (t *AWSResourceTagger) stdout() io.Writer {
+	if t.out != nil {
+		return t.out
+	}
+	return os.Stdout
+}
+
+// writeJSONFile atomically replaces path with the indented JSON encoding of v
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	return writeFileAtomically(path, append(data, '\n'))
+}
+
+// writeFileAtomically replaces path with data through a temporary file
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,34 @@
+package tagger
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+)
+
+// csvReportHeader names the columns written by writeCSVReport
+var csvReportHeader = []string{"service", "resource_id", "arn", "status", "error"}
+
+// writeCSVReport writes one row per result to w, preceded by a header row
+func writeCSVReport(w io.Writer, results []TagResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvReportHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := cw.Write([]string{r.Service, r.ResourceID, r.ARN, r.Status, r.Error}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeCSVFile atomically replaces path with the CSV report of results
+func writeCSVFile(path string, results []TagResult) error {
+	var buf bytes.Buffer
+	if err := writeCSVReport(&buf, results); err != nil {
+		return err
+	}
+	return writeFileAtomically(path, buf.Bytes())
+}
@@ -0,0 +1,49 @@
+package tagger
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushWritesCSVReport(t *testing.T) {
+	reportFile := filepath.Join(t.TempDir(), "report.csv")
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		results: NewResultCollector(),
+	}
+	WithReportFile(reportFile)(tagger)
+	WithOutputFormat(OutputCSV)(tagger)
+	tagger.recordResult(TagResult{Service: "S3", ResourceType: "bucket", ResourceID: "logs", ARN: "arn:aws:s3:::logs", Status: StatusTagged})
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusFailed, Error: "denied, retry later"})
+
+	tagger.flush()
+
+	f, err := os.Open(reportFile)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"service", "resource_id", "arn", "status", "error"},
+		{"S3", "logs", "arn:aws:s3:::logs", "tagged", ""},
+		{"EC2", "i-1", "", "failed", "denied, retry later"},
+	}, rows)
+}
+
+func TestOutputDirUsesCSVReportName(t *testing.T) {
+	tagger := &AWSResourceTagger{}
+	WithOutputDir(t.TempDir())(tagger)
+	WithOutputFormat(OutputCSV)(tagger)
+
+	paths, err := tagger.outputPaths(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "report.csv", filepath.Base(paths.report))
+}
@@ -0,0 +1,153 @@
+package tagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+)
+
+// ReportDiff classifies the resources of a run against a previous report
+type ReportDiff struct {
+	Against string         `json:"against"`
+	Added   []TagResult    `json:"added"`
+	Removed []TagResult    `json:"removed"`
+	Changed []ResultChange `json:"changed"`
+}
+
+// ResultChange is a resource present in both reports whose outcome or tags differ
+type ResultChange struct {
+	Old TagResult `json:"old"`
+	New TagResult `json:"new"`
+	// TagKeys lists the keys whose value was added, removed or changed
+	TagKeys []string `json:"tag_keys,omitempty"`
+}
+
+// WithDiffAgainst compares the run's report with the report at path when the run ends
+func WithDiffAgainst(path string) Option {
+	return func(t *AWSResourceTagger) {
+		t.diffAgainst = path
+	}
+}
+
+// ReadReport loads a report written by --report-file
+func ReadReport(path string) (Report, error) {
+	var report Report
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// Diff reports which resources of newer are absent from older, which
+// disappeared and which changed status or tags. Resources are matched by ARN,
+// falling back to their ID, and a dry run's would_tag matches tagged.
+func Diff(older, newer Report) ReportDiff {
+	diff := ReportDiff{Added: []TagResult{}, Removed: []TagResult{}, Changed: []ResultChange{}}
+	previous := make(map[string]TagResult, len(older.Results))
+	for _, r := range older.Results {
+		previous[diffKey(r)] = r
+	}
+
+	current := make(map[string]bool, len(newer.Results))
+	for _, r := range newer.Results {
+		key := diffKey(r)
+		current[key] = true
+		old, ok := previous[key]
+		if !ok {
+			diff.Added = append(diff.Added, r)
+			continue
+		}
+		statusChanged := diffStatus(old.Status) != diffStatus(r.Status)
+		var tagKeys []string
+		if older.Tags != nil || old.Tags != nil {
+			tagKeys = changedTagKeys(appliedTags(older, old), appliedTags(newer, r))
+		}
+		if statusChanged || len(tagKeys) > 0 {
+			diff.Changed = append(diff.Changed, ResultChange{Old: old, New: r, TagKeys: tagKeys})
+		}
+	}
+
+	for _, r := range older.Results {
+		if !current[diffKey(r)] {
+			diff.Removed = append(diff.Removed, r)
+		}
+	}
+	return diff
+}
+
+// diffKey identifies a result across reports
+func diffKey(r TagResult) string {
+	return r.Service + "|" + r.ResourceType + "|" + resultIdentifier(r)
+}
+
+// diffStatus treats a dry run's would_tag as tagged
+func diffStatus(status string) string {
+	if status == StatusWouldTag {
+		return StatusTagged
+	}
+	return status
+}
+
+// appliedTags returns the tags a report's run wrote, or would write, to r,
+// falling back to the run's configured tags for reports that predate per-result tags
+func appliedTags(report Report, r TagResult) map[string]string {
+	if diffStatus(r.Status) != StatusTagged {
+		return nil
+	}
+	if r.Tags != nil {
+		return r.Tags
+	}
+	return report.Tags
+}
+
+// changedTagKeys returns the sorted keys whose values differ between old and new
+func changedTagKeys(old, new map[string]string) []string {
+	var keys []string
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || ov != v {
+			keys = append(keys, k)
+		}
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffReport compares report with the --diff-against report and logs the
+// outcome. A combined report of several accounts is narrowed to this account.
+func (t *AWSResourceTagger) diffReport(report Report) *ReportDiff {
+	older, combined, err := readAccountReport(t.diffAgainst, report.AccountID)
+	if err == nil && !combined {
+		older, err = ReadReport(t.diffAgainst)
+	}
+	if err != nil {
+		log.Printf("Error reading %s for --diff-against: %v", t.diffAgainst, err)
+		return nil
+	}
+
+	diff := Diff(older, report)
+	diff.Against = t.diffAgainst
+	for _, r := range diff.Added {
+		log.Printf("Added: %s %s %s (%s)", r.Service, r.ResourceType, resultIdentifier(r), r.Status)
+	}
+	for _, r := range diff.Removed {
+		log.Printf("Removed: %s %s %s", r.Service, r.ResourceType, resultIdentifier(r))
+	}
+	for _, c := range diff.Changed {
+		log.Printf("Changed: %s %s %s: %s -> %s, tag keys %v",
+			c.New.Service, c.New.ResourceType, resultIdentifier(c.New), c.Old.Status, c.New.Status, c.TagKeys)
+	}
+	log.Printf("Drift against %s: %d added, %d removed, %d changed",
+		t.diffAgainst, len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return &diff
+}
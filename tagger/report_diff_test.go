@@ -0,0 +1,119 @@
+package tagger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffClassifiesResources(t *testing.T) {
+	older := Report{
+		Tags: map[string]string{"env": "prod", "team": "data"},
+		Results: []TagResult{
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-kept", Status: StatusTagged},
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-gone", Status: StatusTagged},
+			{Service: "S3", ResourceType: "bucket", ResourceID: "logs", ARN: "arn:aws:s3:::logs", Status: StatusFailed, Error: "denied"},
+			{Service: "RDS", ResourceType: "instance", ResourceID: "db", ARN: "arn:aws:rds:us-west-2:123456789012:db:db", Status: StatusTagged},
+		},
+	}
+	newer := Report{
+		Tags: map[string]string{"env": "prod", "team": "data"},
+		Results: []TagResult{
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-kept", Status: StatusWouldTag},
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-new", Status: StatusWouldTag},
+			{Service: "S3", ResourceType: "bucket", ResourceID: "logs", ARN: "arn:aws:s3:::logs", Status: StatusTagged},
+			{Service: "RDS", ResourceType: "instance", ResourceID: "db", ARN: "arn:aws:rds:us-west-2:123456789012:db:db", Status: StatusSkipped},
+		},
+	}
+
+	diff := Diff(older, newer)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "i-new", diff.Added[0].ResourceID)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "i-gone", diff.Removed[0].ResourceID)
+	require.Len(t, diff.Changed, 2)
+	assert.Equal(t, "logs", diff.Changed[0].New.ResourceID)
+	assert.Equal(t, []string{"env", "team"}, diff.Changed[0].TagKeys)
+	assert.Equal(t, "db", diff.Changed[1].New.ResourceID)
+	assert.Equal(t, StatusSkipped, diff.Changed[1].New.Status)
+}
+
+func TestDiffReportsTagValueChanges(t *testing.T) {
+	older := Report{
+		Tags:    map[string]string{"env": "prod", "owner": "alice"},
+		Results: []TagResult{{Service: "SNS", ResourceType: "topic", ResourceID: "alerts", Status: StatusTagged}},
+	}
+	newer := Report{
+		Tags:    map[string]string{"env": "staging", "cost-center": "42"},
+		Results: []TagResult{{Service: "SNS", ResourceType: "topic", ResourceID: "alerts", Status: StatusTagged}},
+	}
+
+	diff := Diff(older, newer)
+
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, []string{"cost-center", "env", "owner"}, diff.Changed[0].TagKeys)
+}
+
+func TestDiffUsesTheTagsEachResourceWasWritten(t *testing.T) {
+	older := Report{
+		Tags: map[string]string{"env": "prod"},
+		Results: []TagResult{
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged,
+				Tags: map[string]string{"env": "prod", "owner": "platform"}},
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-2", Status: StatusTagged,
+				Tags: map[string]string{"env": "prod"}},
+		},
+	}
+	newer := Report{
+		Tags: map[string]string{"env": "prod"},
+		Results: []TagResult{
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged,
+				Tags: map[string]string{"env": "prod"}},
+			{Service: "EC2", ResourceType: "instance", ResourceID: "i-2", Status: StatusWouldTag,
+				Tags: map[string]string{"env": "prod"}},
+		},
+	}
+
+	diff := Diff(older, newer)
+
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, "i-1", diff.Changed[0].New.ResourceID)
+	assert.Equal(t, []string{"owner"}, diff.Changed[0].TagKeys)
+}
+
+func TestFlushAddsDiffAgainstPreviousReport(t *testing.T) {
+	dir := t.TempDir()
+	previous := filepath.Join(dir, "previous.json")
+	require.NoError(t, writeJSONFile(previous, Report{
+		Tags:    map[string]string{"env": "prod"},
+		Results: []TagResult{{Service: "SQS", ResourceType: "queue", ResourceID: "old-queue", Status: StatusTagged}},
+	}))
+
+	reportFile := filepath.Join(dir, "report.json")
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	WithReportFile(reportFile)(tagger)
+	WithDiffAgainst(previous)(tagger)
+	tagger.recordResult(TagResult{Service: "SQS", ResourceType: "queue", ResourceID: "new-queue", Status: StatusWouldTag})
+
+	tagger.flush()
+
+	report, err := ReadReport(reportFile)
+	require.NoError(t, err)
+	require.NotNil(t, report.Diff)
+	assert.Equal(t, previous, report.Diff.Against)
+	require.Len(t, report.Diff.Added, 1)
+	assert.Equal(t, "new-queue", report.Diff.Added[0].ResourceID)
+	require.Len(t, report.Diff.Removed, 1)
+	assert.Equal(t, "old-queue", report.Diff.Removed[0].ResourceID)
+	assert.Empty(t, report.Diff.Changed)
+}
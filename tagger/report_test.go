@@ -0,0 +1,272 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushWritesPartialReportOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	tagger := &AWSResourceTagger{
+		ctx:       ctx,
+		cancel:    cancel,
+		accountID: "123456789012",
+		region:    "us-west-2",
+		results:   NewResultCollector(),
+		metrics:   NewMetricsCollector(),
+	}
+	WithReportFile(reportFile)(tagger)
+
+	resourceTaggers := map[string]func() error{
+		"EC2": func() error {
+			tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged})
+			tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-2", Status: StatusFailed, Error: "denied"})
+			// Simulate a signal arriving mid-run
+			cancel()
+			for _, id := range []string{"i-3", "i-4"} {
+				if tagger.ctx.Err() != nil {
+					return nil
+				}
+				tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: id, Status: StatusTagged})
+			}
+			return nil
+		},
+	}
+
+	err := tagger.runResourceTaggers(resourceTaggers)
+	tagger.flush()
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	data, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.False(t, report.Complete)
+	assert.Equal(t, context.Canceled.Error(), report.Interrupted)
+	assert.Equal(t, "123456789012", report.AccountID)
+	assert.Equal(t, ReportSummary{Tagged: 1, Failed: 1}, report.Summary)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "i-1", report.Results[0].ResourceID)
+	assert.Equal(t, "denied", report.Results[1].Error)
+}
+
+func TestFlushWritesCompleteReport(t *testing.T) {
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		results: NewResultCollector(),
+	}
+	WithReportFile(reportFile)(tagger)
+
+	tagger.flush()
+
+	data, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.True(t, report.Complete)
+	assert.Empty(t, report.Interrupted)
+	assert.NotNil(t, report.Results)
+	assert.Empty(t, report.Results)
+}
+
+func TestFlushWritesAllArtifactsToOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+	tagger := &AWSResourceTagger{
+		ctx:       context.Background(),
+		accountID: "123456789012",
+		region:    "us-west-2",
+		results:   NewResultCollector(),
+		metrics:   NewMetricsCollector(),
+	}
+	WithOutputDir(outputDir)(tagger)
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged})
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-2",
+		ARN: "arn:aws:ec2:us-west-2:123456789012:instance/i-2", Status: StatusFailed, Error: "denied"})
+
+	tagger.flush()
+
+	runDirs, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Len(t, runDirs, 1, "artifacts go into one timestamped subfolder")
+	runDir := filepath.Join(outputDir, runDirs[0].Name())
+
+	for _, name := range []string{reportFileName, failuresFileName, summaryFileName, metricsFileName} {
+		assert.FileExists(t, filepath.Join(runDir, name))
+	}
+
+	failures, err := os.ReadFile(filepath.Join(runDir, failuresFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:ec2:us-west-2:123456789012:instance/i-2\n", string(failures))
+
+	data, err := os.ReadFile(filepath.Join(runDir, summaryFileName))
+	require.NoError(t, err)
+	var summary RunSummary
+	require.NoError(t, json.Unmarshal(data, &summary))
+	assert.Equal(t, ReportSummary{Tagged: 1, Failed: 1}, summary.Summary)
+
+	metrics, err := os.ReadFile(filepath.Join(runDir, metricsFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(metrics), `aws_tagger_resources{account_id="123456789012",region="us-west-2",status="failed"} 1`)
+}
+
+func TestOutputDirIndividualFileOverrides(t *testing.T) {
+	outputDir := t.TempDir()
+	reportFile := filepath.Join(t.TempDir(), "custom-report.json")
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		results: NewResultCollector(),
+	}
+	WithOutputDir(outputDir)(tagger)
+	WithReportFile(reportFile)(tagger)
+
+	tagger.flush()
+
+	assert.FileExists(t, reportFile)
+	runDirs, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Len(t, runDirs, 1)
+	assert.NoFileExists(t, filepath.Join(outputDir, runDirs[0].Name(), reportFileName))
+	assert.FileExists(t, filepath.Join(outputDir, runDirs[0].Name(), summaryFileName))
+}
+
+func TestAppliedKeysCountSuccessfullyTaggedResources(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"map-migrated": "mig12345", "env": "prod"},
+		results: NewResultCollector(),
+		metrics: NewMetricsCollector(),
+	}
+	for _, id := range []string{"i-1", "i-2", "i-3"} {
+		tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: id, Status: StatusTagged})
+	}
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-4", Status: StatusFailed})
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-5", Status: StatusSkipped})
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-6", Status: StatusWouldTag})
+
+	summary := tagger.buildRunSummary(tagger.buildReport())
+	assert.Equal(t, map[string]int64{"map-migrated": 3, "env": 3}, summary.AppliedKeys)
+	for _, n := range summary.AppliedKeys {
+		assert.Equal(t, int64(summary.Summary.Tagged), n)
+	}
+
+	tagger.flush()
+	assert.Contains(t, logBuffer.String(), "Applied keys: env (3), map-migrated (3)")
+}
+
+func TestFormatAppliedKeysOrdersByCount(t *testing.T) {
+	counts := map[string]int64{"env": 2, "map-migrated": 1200, "team": 2}
+
+	assert.Equal(t, "map-migrated (1200), env (2), team (2)", formatAppliedKeys(counts))
+}
+
+func TestResourceTypeSummaryCountsPerType(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+		metrics: NewMetricsCollector(),
+	}
+	WithResourceTypeSummary(true)(tagger)
+	for _, r := range []TagResult{
+		{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "instance", ResourceID: "i-2", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "volume", ResourceID: "vol-1", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "snapshot", ResourceID: "snap-1", Status: StatusTagged},
+		{Service: "EC2", ResourceType: "snapshot", ResourceID: "snap-2", Status: StatusFailed},
+		{Service: "Glue", ResourceType: "database", ResourceID: "db1", Status: StatusSkipped},
+	} {
+		tagger.recordResult(r)
+	}
+
+	summary := tagger.buildRunSummary(tagger.buildReport())
+	assert.Equal(t, []ResourceTypeSummary{
+		{Service: "EC2", ResourceType: "instance", ReportSummary: ReportSummary{Tagged: 2}},
+		{Service: "EC2", ResourceType: "snapshot", ReportSummary: ReportSummary{Tagged: 1, Failed: 1}},
+		{Service: "EC2", ResourceType: "volume", ReportSummary: ReportSummary{Tagged: 1}},
+		{Service: "Glue", ResourceType: "database", ReportSummary: ReportSummary{Skipped: 1}},
+	}, summary.ResourceTypes)
+
+	tagger.flush()
+	assert.Contains(t, logBuffer.String(), "EC2: instance 2/2, snapshot 1/2, volume 1/1\nGlue: database 0/1\n")
+}
+
+func TestResourceTypeSummaryOmittedByDefault(t *testing.T) {
+	tagger := &AWSResourceTagger{ctx: context.Background(), results: NewResultCollector(), metrics: NewMetricsCollector()}
+	tagger.recordResult(TagResult{Service: "EC2", ResourceType: "instance", ResourceID: "i-1", Status: StatusTagged})
+
+	assert.Nil(t, tagger.buildRunSummary(tagger.buildReport()).ResourceTypes)
+}
+
+func TestFlushPrintsJSONReportSchema(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetJobs", mock.Anything, mock.Anything).Return(&glue.GetJobsOutput{
+		Jobs: []gluetypes.Job{{Name: aws.String("etl")}, {Name: aws.String("locked")}},
+	}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == "arn:aws:glue:us-west-2:123456789012:job/locked"
+	})).Return(nil, &mockAPIError{code: "AccessDeniedException", message: "denied"})
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil)
+
+	var out bytes.Buffer
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.out = &out
+	WithOutputFormat(OutputJSON)(tagger)
+
+	tagger.tagGlueJobs(mockClient, &GlueMetrics{})
+	tagger.flush()
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	for _, key := range []string{"account_id", "region", "generated_at", "complete", "summary", "results"} {
+		assert.Contains(t, report, key)
+	}
+	assert.Equal(t, map[string]interface{}{"tagged": 1.0, "failed": 1.0, "skipped": 0.0}, report["summary"])
+
+	results := report["results"].([]interface{})
+	require.Len(t, results, 2)
+	assert.Equal(t, map[string]interface{}{
+		"service":       "Glue",
+		"resource_type": "job",
+		"resource_id":   "etl",
+		"arn":           "arn:aws:glue:us-west-2:123456789012:job/etl",
+		"status":        StatusTagged,
+		"tags":          map[string]interface{}{"Environment": "Test", "Project": "UnitTest"},
+	}, results[0])
+	failed := results[1].(map[string]interface{})
+	assert.Equal(t, StatusFailed, failed["status"])
+	assert.Contains(t, failed["error"], "denied")
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	assert.NoError(t, ValidateOutputFormat(OutputText))
+	assert.NoError(t, ValidateOutputFormat(OutputJSON))
+	assert.Error(t, ValidateOutputFormat("yaml"))
+}
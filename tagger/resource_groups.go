@@ -0,0 +1,162 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+)
+
+// tagResourcesBatchSize is the maximum number of ARNs accepted by a single TagResources call
+const tagResourcesBatchSize = 20
+
+// ResourceGroupsTaggingAPI interface for Resource Groups Tagging API client operations
+type ResourceGroupsTaggingAPI interface {
+	GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+	TagResources(ctx context.Context, params *resourcegroupstaggingapi.TagResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.TagResourcesOutput, error)
+}
+
+// TagResourceType tags every resource of one service resource type, such as
+// comprehend:document-classifier, through the Resource Groups Tagging API.
+// It covers services without a dedicated tagger.
+func (t *AWSResourceTagger) TagResourceType(service, resourceType string) error {
+	if service == "" || resourceType == "" {
+		return fmt.Errorf("both --service and --resource-type are required")
+	}
+	if err := t.validateSSOSession(); err != nil {
+		return fmt.Errorf("SSO session validation failed: %w", err)
+	}
+	defer t.flush()
+
+	client := resourcegroupstaggingapi.NewFromConfig(t.cfg)
+	return t.tagResourceTypeWithClient(client, service, resourceType)
+}
+
+// tagResourceTypeWithClient discovers resources with a type filter and tags them in batches
+func (t *AWSResourceTagger) tagResourceTypeWithClient(client ResourceGroupsTaggingAPI, service, resourceType string) error {
+	typeFilter := service + ":" + resourceType
+	log.Printf("Tagging %s resources via the Resource Groups Tagging API...", typeFilter)
+
+	arns, err := cachedDiscovery(t, typeFilter, func() ([]string, error) {
+		var arns []string
+		input := &resourcegroupstaggingapi.GetResourcesInput{
+			ResourceTypeFilters: []string{typeFilter},
+		}
+		for {
+			output, err := client.GetResources(t.ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			for _, mapping := range output.ResourceTagMappingList {
+				arns = append(arns, aws.ToString(mapping.ResourceARN))
+			}
+			if aws.ToString(output.PaginationToken) == "" {
+				return arns, nil
+			}
+			input.PaginationToken = output.PaginationToken
+		}
+	})
+	if err != nil {
+		t.handleError(err, "all", typeFilter)
+		return fmt.Errorf("failed to list %s resources: %w", typeFilter, err)
+	}
+	log.Printf("Found %d %s resources to tag", len(arns), typeFilter)
+
+	t.tagARNsWithClient(client, arns)
+
+	log.Printf("Completed tagging %s resources", typeFilter)
+	return nil
+}
+
+// tagARNsWithClient tags ARNs of any service in batches of tagResourcesBatchSize;
+// ARNs of untaggable resource types and checkpointed ARNs are recorded as
+// skipped and left out of the batches
+func (t *AWSResourceTagger) tagARNsWithClient(client ResourceGroupsTaggingAPI, arns []string) {
+	pending := make([]string, 0, len(arns))
+	for _, arn := range arns {
+		target := arnTarget(arn)
+		if t.skipUntaggable(target.Service, target.ResourceType, arn, arn) || t.checkpointed(target) {
+			continue
+		}
+		pending = append(pending, arn)
+	}
+	arns = pending
+
+	for start := 0; start < len(arns); start += tagResourcesBatchSize {
+		end := start + tagResourcesBatchSize
+		if end > len(arns) {
+			end = len(arns)
+		}
+		t.tagResourceBatch(client, arns[start:end])
+	}
+}
+
+// tagResourceBatch tags up to tagResourcesBatchSize ARNs and records a result for each
+func (t *AWSResourceTagger) tagResourceBatch(client ResourceGroupsTaggingAPI, arns []string) {
+	if t.dryRun {
+		for _, arn := range arns {
+			t.dryRunSkip(arn)
+			result := arnTarget(arn)
+			result.Status = StatusWouldTag
+			t.recordResult(result)
+		}
+		return
+	}
+
+	var output *resourcegroupstaggingapi.TagResourcesOutput
+	err := t.retryWithBackoff(func() error {
+		var err error
+		output, err = client.TagResources(t.ctx, &resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: arns,
+			Tags:            t.tags,
+		})
+		return err
+	})
+	if err != nil {
+		t.handleBatchError(err, arns)
+	}
+
+	for _, arn := range arns {
+		result := arnTarget(arn)
+		result.Status = StatusTagged
+		service := result.Service
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err.Error()
+		} else if failure, failed := output.FailedResourcesMap[arn]; failed {
+			log.Printf("Error tagging %s resource %s: %s: %s", service, arn, failure.ErrorCode, aws.ToString(failure.ErrorMessage))
+			t.recordAPIError()
+			result.Status = StatusFailed
+			result.Error = fmt.Sprintf("%s: %s", failure.ErrorCode, aws.ToString(failure.ErrorMessage))
+		} else {
+			log.Printf("Successfully tagged %s %s: %s", service, result.ResourceType, arn)
+		}
+		t.recordResult(result)
+	}
+}
+
+// handleBatchError logs a failed TagResources call once and counts an API
+// error for every ARN in the batch, since each of them was left untagged.
+// A throttled call is counted as one throttle, as handleError does.
+func (t *AWSResourceTagger) handleBatchError(err error, arns []string) {
+	if t.ctx != nil && t.ctx.Err() != nil {
+		log.Printf("Skipping %d resources: %v", len(arns), t.ctx.Err())
+		return
+	}
+	log.Printf("Error tagging %d resources with TagResources: %v", len(arns), err)
+	if isThrottlingError(err) {
+		t.metrics.RecordThrottle()
+		return
+	}
+	for range arns {
+		t.recordAPIError()
+	}
+}
+
+// arnTarget describes a resource known only by its ARN for results
+func arnTarget(arn string) TagResult {
+	service, resourceType := arnServiceAndType(arn)
+	return TagResult{Service: service, ResourceType: resourceType, ResourceID: arn, ARN: arn}
+}
@@ -0,0 +1,230 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockResourceGroupsTaggingClient is a mock implementation of ResourceGroupsTaggingAPI
+type MockResourceGroupsTaggingClient struct {
+	mock.Mock
+}
+
+func (m *MockResourceGroupsTaggingClient) GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*resourcegroupstaggingapi.GetResourcesOutput), args.Error(1)
+}
+
+func (m *MockResourceGroupsTaggingClient) TagResources(ctx context.Context, params *resourcegroupstaggingapi.TagResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*resourcegroupstaggingapi.TagResourcesOutput), args.Error(1)
+}
+
+// classifierMappings builds n resource mappings starting at index offset
+func classifierMappings(offset, n int) []rgtypes.ResourceTagMapping {
+	mappings := make([]rgtypes.ResourceTagMapping, 0, n)
+	for i := offset; i < offset+n; i++ {
+		mappings = append(mappings, rgtypes.ResourceTagMapping{
+			ResourceARN: aws.String(fmt.Sprintf("arn:aws:comprehend:us-west-2:123456789012:document-classifier/c%d", i)),
+		})
+	}
+	return mappings
+}
+
+func TestTagResourceTypeWithClient(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	mockClient := new(MockResourceGroupsTaggingClient)
+
+	// Two pages totalling 25 classifiers
+	mockClient.On("GetResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return input.PaginationToken == nil
+	})).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: classifierMappings(0, 15),
+		PaginationToken:        aws.String("page-2"),
+	}, nil).Once()
+	mockClient.On("GetResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return aws.ToString(input.PaginationToken) == "page-2"
+	})).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: classifierMappings(15, 10),
+		PaginationToken:        aws.String(""),
+	}, nil).Once()
+
+	failedARN := "arn:aws:comprehend:us-west-2:123456789012:document-classifier/c22"
+	mockClient.On("TagResources", mock.Anything, mock.Anything).
+		Return(&resourcegroupstaggingapi.TagResourcesOutput{
+			FailedResourcesMap: map[string]rgtypes.FailureInfo{
+				failedARN: {ErrorCode: rgtypes.ErrorCodeInvalidParameterException, ErrorMessage: aws.String("bad tag")},
+			},
+		}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"map-migrated": "mig12345"},
+		results: NewResultCollector(),
+		metrics: NewMetricsCollector(),
+	}
+
+	err := tagger.tagResourceTypeWithClient(mockClient, "comprehend", "document-classifier")
+	assert.NoError(t, err)
+
+	// Every GetResources call carries the service:resource-type filter
+	for _, call := range mockClient.Calls {
+		if call.Method == "GetResources" {
+			input := call.Arguments.Get(1).(*resourcegroupstaggingapi.GetResourcesInput)
+			assert.Equal(t, []string{"comprehend:document-classifier"}, input.ResourceTypeFilters)
+		}
+	}
+
+	// 25 ARNs are split into batches of 20 and 5
+	var batchSizes []int
+	for _, call := range mockClient.Calls {
+		if call.Method == "TagResources" {
+			input := call.Arguments.Get(1).(*resourcegroupstaggingapi.TagResourcesInput)
+			batchSizes = append(batchSizes, len(input.ResourceARNList))
+			assert.Equal(t, map[string]string{"map-migrated": "mig12345"}, input.Tags)
+		}
+	}
+	assert.Equal(t, []int{20, 5}, batchSizes)
+
+	results := tagger.Results()
+	assert.Len(t, results, 25)
+	for _, r := range results {
+		assert.Equal(t, "comprehend", r.Service)
+		assert.Equal(t, "document-classifier", r.ResourceType)
+		if r.ARN == failedARN {
+			assert.Equal(t, StatusFailed, r.Status)
+			assert.Contains(t, r.Error, "bad tag")
+		} else {
+			assert.Equal(t, StatusTagged, r.Status)
+		}
+	}
+	assert.Equal(t, int64(1), tagger.metrics.APIErrors())
+	mockClient.AssertExpectations(t)
+}
+
+func TestTagResourceTypeWithClient_Errors(t *testing.T) {
+	t.Run("GetResources failure", func(t *testing.T) {
+		mockClient := new(MockResourceGroupsTaggingClient)
+		mockClient.On("GetResources", mock.Anything, mock.Anything).
+			Return(nil, errors.New("API error"))
+
+		tagger := &AWSResourceTagger{ctx: context.Background(), results: NewResultCollector()}
+		err := tagger.tagResourceTypeWithClient(mockClient, "rekognition", "project")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "rekognition:project")
+		mockClient.AssertNotCalled(t, "TagResources", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TagResources failure marks the whole batch failed", func(t *testing.T) {
+		mockClient := new(MockResourceGroupsTaggingClient)
+		mockClient.On("GetResources", mock.Anything, mock.Anything).
+			Return(&resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: classifierMappings(0, 3)}, nil)
+		mockClient.On("TagResources", mock.Anything, mock.Anything).
+			Return(nil, errors.New("API error"))
+
+		tagger := &AWSResourceTagger{ctx: context.Background(), results: NewResultCollector(), metrics: NewMetricsCollector()}
+		err := tagger.tagResourceTypeWithClient(mockClient, "comprehend", "document-classifier")
+
+		assert.NoError(t, err)
+		results := tagger.Results()
+		assert.Len(t, results, 3)
+		for _, r := range results {
+			assert.Equal(t, StatusFailed, r.Status)
+		}
+		assert.Equal(t, int64(3), tagger.metrics.APIErrors(), "one error per ARN left untagged")
+	})
+
+	t.Run("TagResources throttled on every attempt fails the batch", func(t *testing.T) {
+		mockClient := new(MockResourceGroupsTaggingClient)
+		mockClient.On("GetResources", mock.Anything, mock.Anything).
+			Return(&resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: classifierMappings(0, 3)}, nil)
+		mockClient.On("TagResources", mock.Anything, mock.Anything).
+			Return(nil, &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"})
+
+		tagger := &AWSResourceTagger{
+			ctx:            context.Background(),
+			results:        NewResultCollector(),
+			metrics:        NewMetricsCollector(),
+			retryAttempts:  2,
+			retryBaseDelay: time.Millisecond,
+		}
+		err := tagger.tagResourceTypeWithClient(mockClient, "comprehend", "document-classifier")
+
+		assert.NoError(t, err)
+		mockClient.AssertNumberOfCalls(t, "TagResources", 2)
+		assert.Len(t, tagger.Results(), 3)
+		assert.Equal(t, int64(2), tagger.metrics.Throttles(), "one per throttled call, not per ARN")
+		assert.Equal(t, int64(0), tagger.metrics.APIErrors())
+	})
+}
+
+// throttleOnceHTTPClient throttles the first JSON request and accepts the rest
+type throttleOnceHTTPClient struct {
+	calls int
+}
+
+func (c *throttleOnceHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	status, body := http.StatusOK, `{}`
+	if c.calls == 1 {
+		status, body = http.StatusBadRequest, `{"__type":"ThrottlingException","message":"Rate exceeded"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestThrottledTagResourcesBatchIsRetriedByTheTagger(t *testing.T) {
+	httpClient := &throttleOnceHTTPClient{}
+	client := resourcegroupstaggingapi.NewFromConfig(aws.Config{
+		Region:      "us-west-2",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  httpClient,
+		APIOptions:  []func(*middleware.Stack) error{skipSDKRetries},
+	})
+	tagger := &AWSResourceTagger{
+		ctx:            context.Background(),
+		tags:           map[string]string{"env": "prod"},
+		results:        NewResultCollector(),
+		metrics:        NewMetricsCollector(),
+		retryBaseDelay: time.Millisecond,
+	}
+
+	tagger.tagARNsWithClient(client, []string{"arn:aws:comprehend:us-west-2:123456789012:document-classifier/c1"})
+
+	assert.Equal(t, 2, httpClient.calls, "the SDK does not retry, the tagger does")
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Equal(t, int64(1), tagger.metrics.Throttles())
+}
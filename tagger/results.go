@@ -0,0 +1,144 @@
+package tagger
+
+import (
+	"log"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Result statuses recorded for each processed resource
+const (
+	StatusTagged  = "tagged"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+	// StatusWouldTag marks resources a dry run would have tagged
+	StatusWouldTag = "would_tag"
+)
+
+// TagResult describes the outcome of tagging a single resource
+type TagResult struct {
+	Service      string `json:"service"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	ARN          string `json:"arn,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	// Related lists resources the tagged resource depends on, e.g. the
+	// databases and connections a Glue crawler targets
+	Related []string `json:"related,omitempty"`
+	// Retention is a log group's retention in days; nil means never expire
+	Retention *int32 `json:"retention_in_days,omitempty"`
+	// Region is the region the resource was tagged in; set in multi-region runs
+	Region string `json:"region,omitempty"`
+	// Tags are the tags written, or that a dry run would write, to the
+	// resource; recordResult fills in the configured tags when unset
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// ResultCollector accumulates tag results from concurrently running service taggers
+type ResultCollector struct {
+	mu      sync.Mutex
+	results []TagResult
+}
+
+// NewResultCollector creates an empty result collector
+func NewResultCollector() *ResultCollector {
+	return &ResultCollector{}
+}
+
+// Add records a single result. It is a no-op on a nil collector.
+func (c *ResultCollector) Add(result TagResult) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = append(c.results, result)
+}
+
+// Results returns a copy of all recorded results
+func (c *ResultCollector) Results() []TagResult {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := make([]TagResult, len(c.results))
+	copy(results, c.results)
+	return results
+}
+
+// Results returns the results recorded so far by the tagger
+func (t *AWSResourceTagger) Results() []TagResult {
+	return t.results.Results()
+}
+
+// recordResult stores a result on the tagger's collector
+func (t *AWSResourceTagger) recordResult(result TagResult) {
+	if result.Region == "" && len(t.regions) > 0 {
+		result.Region = t.region
+	}
+	if result.Tags == nil && (result.Status == StatusTagged || result.Status == StatusWouldTag) {
+		result.Tags = t.tags
+	}
+	t.results.Add(result)
+	t.metrics.RecordResourceResult(result.Service, result.ResourceType, result.Status)
+	if result.Status == StatusTagged {
+		t.checkpoint.add(resultIdentifier(result))
+		t.metrics.RecordAppliedKeys(sortedTagKeys(result.Tags))
+	}
+}
+
+// applyAndRecord performs a single tag call and records its outcome: successes
+// are logged, failures go through handleError, and either way a TagResult is
+// appended. target supplies the service, resource type, ID and ARN.
+func (t *AWSResourceTagger) applyAndRecord(target TagResult, apply func() error) error {
+	result := target
+	if t.checkpointed(result) {
+		return nil
+	}
+	if t.dryRunSkip(resultIdentifier(result)) {
+		result.Status = StatusWouldTag
+		t.recordResult(result)
+		return nil
+	}
+	if err := t.retryWithBackoff(apply); err != nil {
+		t.handleError(err, resultIdentifier(result), result.Service+" "+result.ResourceType)
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		t.recordResult(result)
+		return err
+	}
+
+	log.Printf("Successfully tagged %s %s: %s", result.Service, result.ResourceType, result.ResourceID)
+	result.Status = StatusTagged
+	t.recordResult(result)
+	return nil
+}
+
+// resultIdentifier names a result by its ARN, falling back to its ID
+func resultIdentifier(result TagResult) string {
+	if result.ARN != "" {
+		return result.ARN
+	}
+	return result.ResourceID
+}
+
+// skipResource logs and records target as skipped for reason
+func (t *AWSResourceTagger) skipResource(target TagResult, reason string) {
+	log.Printf("Skipping %s %s %s: %s", target.Service, target.ResourceType, resultIdentifier(target), reason)
+	target.Status = StatusSkipped
+	target.Error = reason
+	t.recordResult(target)
+}
+
+// skipUnnamed records a skip for a listed resource that came back without a
+// name, which would otherwise produce a malformed ARN
+func (t *AWSResourceTagger) skipUnnamed(target TagResult, name *string) bool {
+	if aws.ToString(name) != "" {
+		return false
+	}
+	t.skipResource(target, "resource has no name")
+	return true
+}
@@ -0,0 +1,53 @@
+package tagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyAndRecord(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		results: NewResultCollector(),
+		metrics: NewMetricsCollector(),
+	}
+	target := TagResult{
+		Service:      "RDS",
+		ResourceType: "instance",
+		ResourceID:   "db-1",
+		ARN:          "arn:aws:rds:us-west-2:123456789012:db:db-1",
+	}
+
+	err := tagger.applyAndRecord(target, func() error { return nil })
+	assert.NoError(t, err)
+
+	apiErr := &mockAPIError{code: "AccessDenied", message: "denied"}
+	err = tagger.applyAndRecord(target, func() error { return apiErr })
+	assert.True(t, errors.Is(err, apiErr))
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+
+	assert.Equal(t, StatusTagged, results[0].Status)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, "db-1", results[0].ResourceID)
+	assert.Equal(t, target.ARN, results[0].ARN)
+
+	assert.Equal(t, StatusFailed, results[1].Status)
+	assert.Equal(t, "denied", results[1].Error)
+	assert.Equal(t, int64(1), tagger.metrics.APIErrors())
+
+	logs := logBuffer.String()
+	assert.Contains(t, logs, "Successfully tagged RDS instance: db-1")
+	assert.Contains(t, logs, "Access denied while tagging RDS instance resource arn:aws:rds:us-west-2:123456789012:db:db-1")
+}
@@ -3,18 +3,24 @@ package tagger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 // S3API interface for S3 client operations
 type S3API interface {
 	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
 	PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error)
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+	ListDirectoryBuckets(ctx context.Context, params *s3.ListDirectoryBucketsInput, optFns ...func(*s3.Options)) (*s3.ListDirectoryBucketsOutput, error)
 }
 
 // S3Metrics tracks the success/failure metrics for S3 tagging operations
@@ -22,15 +28,25 @@ type S3Metrics struct {
 	BucketsFound  int
 	BucketsTagged int
 	BucketsFailed int
+	// BucketsWouldTag counts buckets a dry run would have tagged
+	BucketsWouldTag int
+	// BucketsSkipped counts buckets skipped because they already carry every tag
+	BucketsSkipped int
+}
+
+func init() {
+	registerGlobalService("S3Buckets", (*AWSResourceTagger).tagS3Buckets)
+	registerService("S3DirectoryBuckets", (*AWSResourceTagger).tagS3DirectoryBuckets)
 }
 
 // tagS3Buckets is the main entry point that creates and uses the client
-func (t *AWSResourceTagger) tagS3Buckets() {
+func (t *AWSResourceTagger) tagS3Buckets() error {
 	client := s3.NewFromConfig(t.cfg)
 	metrics := t.tagS3BucketsWithClient(client)
 
-	log.Printf("S3 Tagging Summary - Found: %d, Tagged: %d, Failed: %d",
-		metrics.BucketsFound, metrics.BucketsTagged, metrics.BucketsFailed)
+	log.Printf("S3 Tagging Summary - Found: %d, Tagged: %d, Failed: %d, Would tag: %d, Skipped: %d",
+		metrics.BucketsFound, metrics.BucketsTagged, metrics.BucketsFailed, metrics.BucketsWouldTag, metrics.BucketsSkipped)
+	return nil
 }
 
 // tagS3BucketsWithClient handles the actual tagging logic with a provided client
@@ -42,7 +58,9 @@ func (t *AWSResourceTagger) tagS3BucketsWithClient(client S3API) *S3Metrics {
 		return metrics
 	}
 
-	result, err := client.ListBuckets(t.ctx, &s3.ListBucketsInput{})
+	result, err := cachedDiscovery(t, "s3/buckets", func() (*s3.ListBucketsOutput, error) {
+		return client.ListBuckets(t.ctx, &s3.ListBucketsInput{})
+	})
 	if err != nil {
 		t.handleError(err, "all", "S3")
 		return metrics
@@ -53,34 +71,156 @@ func (t *AWSResourceTagger) tagS3BucketsWithClient(client S3API) *S3Metrics {
 
 	for _, bucket := range result.Buckets {
 		bucketName := aws.ToString(bucket.Name)
-		if err := t.tagBucket(client, bucketName); err != nil {
+		target := TagResult{
+			Service:      "S3",
+			ResourceType: "bucket",
+			ResourceID:   bucketName,
+			ARN:          "arn:aws:s3:::" + bucketName,
+		}
+		if t.skipCreatedBefore(target, bucket.CreationDate) {
+			continue
+		}
+		if t.bucketAlreadyTagged(client, target) {
+			metrics.BucketsSkipped++
+			continue
+		}
+		if err := t.applyAndRecord(target, func() error {
+			return t.tagBucket(client, bucketName)
+		}); err != nil {
 			metrics.BucketsFailed++
-			t.handleError(err, bucketName, "S3")
+			continue
+		}
+		if t.dryRun {
+			metrics.BucketsWouldTag++
 			continue
 		}
 		metrics.BucketsTagged++
-		log.Printf("Successfully tagged S3 bucket: %s", bucketName)
 	}
 
 	return metrics
 }
 
-// tagBucket tags a single S3 bucket with the configured tags
+// tagS3DirectoryBuckets tags the region's S3 Express One Zone directory buckets
+func (t *AWSResourceTagger) tagS3DirectoryBuckets() error {
+	t.tagS3DirectoryBucketsWithClients(s3.NewFromConfig(t.cfg), resourcegroupstaggingapi.NewFromConfig(t.cfg))
+	return nil
+}
+
+// tagS3DirectoryBucketsWithClients lists directory buckets, which ListBuckets
+// leaves out, and tags them by ARN since PutBucketTagging does not support
+// them. Regions without S3 Express One Zone have no endpoint and are skipped.
+func (t *AWSResourceTagger) tagS3DirectoryBucketsWithClients(client S3API, tagClient ResourceGroupsTaggingAPI) {
+	var arns []string
+	input := &s3.ListDirectoryBucketsInput{}
+	for {
+		page, err := client.ListDirectoryBuckets(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "S3 directory buckets")
+			return
+		}
+		for _, bucket := range page.Buckets {
+			arns = append(arns, t.buildARN(S3DirectoryBucket, aws.ToString(bucket.Name)))
+		}
+		if aws.ToString(page.ContinuationToken) == "" {
+			break
+		}
+		input.ContinuationToken = page.ContinuationToken
+	}
+
+	log.Printf("Found %d S3 directory buckets to tag", len(arns))
+	t.tagARNsWithClient(tagClient, arns)
+}
+
+// tagBucket tags a single S3 bucket with the configured tags. ListBuckets
+// returns buckets from every region, so the requests are sent to the bucket's
+// own region to avoid redirect and authorization errors. PutBucketTagging
+// replaces the whole tag set, so existing tags are merged in first.
 func (t *AWSResourceTagger) tagBucket(client S3API, bucketName string) error {
 	if bucketName == "" {
 		return fmt.Errorf("bucket name cannot be empty")
 	}
 
-	_, err := client.PutBucketTagging(t.ctx, &s3.PutBucketTaggingInput{
+	region, err := t.bucketRegion(client, bucketName)
+	if err != nil {
+		log.Printf("Could not determine region of S3 bucket %s, using %s: %v", bucketName, t.region, err)
+		region = t.region
+	}
+
+	inRegion := func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	}
+
+	existing, err := t.bucketTags(client, bucketName, inRegion)
+	if err != nil {
+		return fmt.Errorf("failed to read existing tags: %w", err)
+	}
+	keys := make([]string, 0, len(existing))
+	for k := range existing {
+		keys = append(keys, k)
+	}
+	for _, k := range t.missingEnsureKeys(keys) {
+		existing[k] = t.ensureKeys[k]
+	}
+	for k, v := range t.tags {
+		existing[k] = v
+	}
+
+	_, err = client.PutBucketTagging(t.ctx, &s3.PutBucketTaggingInput{
 		Bucket: aws.String(bucketName),
 		Tagging: &s3types.Tagging{
-			TagSet: convertToS3Tags(t.tags),
+			TagSet: convertToS3Tags(existing),
 		},
-	})
+	}, inRegion)
 
 	return err
 }
 
+// bucketTags returns a bucket's current tags; a bucket without a tag set has none
+func (t *AWSResourceTagger) bucketTags(client S3API, bucketName string, optFns ...func(*s3.Options)) (map[string]string, error) {
+	tags := make(map[string]string)
+	output, err := client.GetBucketTagging(t.ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+	}, optFns...)
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchTagSet" {
+			return tags, nil
+		}
+		return nil, err
+	}
+
+	for _, tag := range output.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// bucketRegion looks up the region a bucket lives in
+func (t *AWSResourceTagger) bucketRegion(client S3API, bucketName string) (string, error) {
+	output, err := client.GetBucketLocation(t.ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return "", err
+	}
+	return bucketLocationRegion(output.LocationConstraint), nil
+}
+
+// bucketLocationRegion maps a bucket location constraint to its region name
+func bucketLocationRegion(constraint s3types.BucketLocationConstraint) string {
+	switch constraint {
+	case "":
+		// Buckets in us-east-1 report no location constraint
+		return "us-east-1"
+	case s3types.BucketLocationConstraintEu:
+		// Legacy constraint for buckets created in eu-west-1
+		return "eu-west-1"
+	}
+	return string(constraint)
+}
+
 // convertToS3Tags converts generic tags to S3-specific tag format
 func convertToS3Tags(tags map[string]string) []s3types.Tag {
 	if tags == nil {
@@ -88,11 +228,11 @@ func convertToS3Tags(tags map[string]string) []s3types.Tag {
 	}
 
 	s3Tags := make([]s3types.Tag, 0, len(tags))
-	for k, v := range tags {
+	for _, k := range sortedTagKeys(tags) {
 		if k != "" { // Skip empty keys
 			s3Tags = append(s3Tags, s3types.Tag{
 				Key:   aws.String(k),
-				Value: aws.String(v),
+				Value: aws.String(tags[k]),
 			})
 		}
 	}
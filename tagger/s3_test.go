@@ -5,12 +5,14 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net"
 	"os"
 	"reflect"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +22,8 @@ import (
 // MockS3Client is a mock implementation of S3API
 type MockS3Client struct {
 	mock.Mock
+	// regions records the region each PutBucketTagging call was sent to, by bucket
+	regions map[string]string
 }
 
 func (m *MockS3Client) ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
@@ -31,6 +35,15 @@ func (m *MockS3Client) ListBuckets(ctx context.Context, params *s3.ListBucketsIn
 }
 
 func (m *MockS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	var options s3.Options
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if m.regions == nil {
+		m.regions = make(map[string]string)
+	}
+	m.regions[aws.ToString(params.Bucket)] = options.Region
+
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -38,6 +51,30 @@ func (m *MockS3Client) PutBucketTagging(ctx context.Context, params *s3.PutBucke
 	return args.Get(0).(*s3.PutBucketTaggingOutput), args.Error(1)
 }
 
+func (m *MockS3Client) GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.GetBucketLocationOutput), args.Error(1)
+}
+
+func (m *MockS3Client) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.GetBucketTaggingOutput), args.Error(1)
+}
+
+func (m *MockS3Client) ListDirectoryBuckets(ctx context.Context, params *s3.ListDirectoryBucketsInput, optFns ...func(*s3.Options)) (*s3.ListDirectoryBucketsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.ListDirectoryBucketsOutput), args.Error(1)
+}
+
 // Helper function to match S3 PutBucketTaggingInput regardless of tag order
 func matchS3TagsInput(expected *s3.PutBucketTaggingInput) func(*s3.PutBucketTaggingInput) bool {
 	return func(actual *s3.PutBucketTaggingInput) bool {
@@ -143,6 +180,11 @@ func TestTagS3BucketsWithClient(t *testing.T) {
 				mockClient.On("ListBuckets", mock.Anything, mock.Anything).
 					Return(&s3.ListBucketsOutput{Buckets: tt.buckets}, tt.listError)
 
+				mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).
+					Return(&s3.GetBucketLocationOutput{}, nil).Maybe()
+				mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).
+					Return(&s3.GetBucketTaggingOutput{}, nil).Maybe()
+
 				// Setup PutBucketTagging mocks
 				for _, bucket := range tt.buckets {
 					bucketName := aws.ToString(bucket.Name)
@@ -190,6 +232,8 @@ func TestTagBucket(t *testing.T) {
 			mockClient := new(MockS3Client)
 
 			if tt.bucketName != "" {
+				mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).Return(&s3.GetBucketLocationOutput{}, nil)
+				mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).Return(&s3.GetBucketTaggingOutput{}, nil)
 				mockClient.On("PutBucketTagging", mock.Anything, mock.MatchedBy(func(input *s3.PutBucketTaggingInput) bool {
 					return aws.ToString(input.Bucket) == tt.bucketName
 				})).Return(&s3.PutBucketTaggingOutput{}, nil)
@@ -279,3 +323,179 @@ func TestTagS3BucketsWithClient_EmptyTags_Logging(t *testing.T) {
 	mockClient.AssertNotCalled(t, "ListBuckets")
 	mockClient.AssertNotCalled(t, "PutBucketTagging")
 }
+
+func TestTagBucketUsesBucketRegion(t *testing.T) {
+	tests := []struct {
+		name           string
+		constraint     s3types.BucketLocationConstraint
+		locationErr    error
+		expectedRegion string
+	}{
+		{name: "Bucket in eu-west-1", constraint: s3types.BucketLocationConstraintEuWest1, expectedRegion: "eu-west-1"},
+		{name: "Legacy EU constraint", constraint: s3types.BucketLocationConstraintEu, expectedRegion: "eu-west-1"},
+		{name: "No constraint means us-east-1", constraint: "", expectedRegion: "us-east-1"},
+		{name: "Lookup failure falls back to the configured region", locationErr: errors.New("AccessDenied"), expectedRegion: "us-west-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockS3Client)
+			mockClient.On("GetBucketLocation", mock.Anything, &s3.GetBucketLocationInput{Bucket: aws.String("eu-logs")}).
+				Return(&s3.GetBucketLocationOutput{LocationConstraint: tt.constraint}, tt.locationErr)
+			mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).Return(&s3.GetBucketTaggingOutput{}, nil)
+			mockClient.On("PutBucketTagging", mock.Anything, mock.Anything).Return(&s3.PutBucketTaggingOutput{}, nil)
+
+			tagger := &AWSResourceTagger{
+				ctx:    context.Background(),
+				region: "us-west-2",
+				tags:   map[string]string{"env": "prod"},
+			}
+
+			assert.NoError(t, tagger.tagBucket(mockClient, "eu-logs"))
+			mockClient.AssertExpectations(t)
+			assert.Equal(t, tt.expectedRegion, mockClient.regions["eu-logs"])
+		})
+	}
+}
+
+func TestTagBucketMergesExistingTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    *s3.GetBucketTaggingOutput
+		getErr      error
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "Existing tags are kept",
+			existing: &s3.GetBucketTaggingOutput{TagSet: []s3types.Tag{{Key: aws.String("owner"), Value: aws.String("x")}}},
+			expected: map[string]string{"owner": "x", "env": "prod"},
+		},
+		{
+			name:     "Our tags win on key conflicts",
+			existing: &s3.GetBucketTaggingOutput{TagSet: []s3types.Tag{{Key: aws.String("env"), Value: aws.String("dev")}}},
+			expected: map[string]string{"env": "prod"},
+		},
+		{
+			name:     "NoSuchTagSet means no existing tags",
+			getErr:   &mockAPIError{code: "NoSuchTagSet", message: "The TagSet does not exist"},
+			expected: map[string]string{"env": "prod"},
+		},
+		{
+			name:        "Other read errors abort without overwriting",
+			getErr:      &mockAPIError{code: "AccessDenied", message: "Access Denied"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockS3Client)
+			mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).Return(&s3.GetBucketLocationOutput{}, nil)
+			mockClient.On("GetBucketTagging", mock.Anything, &s3.GetBucketTaggingInput{Bucket: aws.String("team-bucket")}).
+				Return(tt.existing, tt.getErr)
+			if !tt.expectError {
+				mockClient.On("PutBucketTagging", mock.Anything, mock.MatchedBy(matchS3TagsInput(&s3.PutBucketTaggingInput{
+					Bucket:  aws.String("team-bucket"),
+					Tagging: &s3types.Tagging{TagSet: convertToS3Tags(tt.expected)},
+				}))).Return(&s3.PutBucketTaggingOutput{}, nil)
+			}
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"env": "prod"},
+			}
+
+			err := tagger.tagBucket(mockClient, "team-bucket")
+			if tt.expectError {
+				assert.Error(t, err)
+				mockClient.AssertNotCalled(t, "PutBucketTagging", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagS3BucketsWithClientRoutesEachBucketToItsRegion(t *testing.T) {
+	mockClient := new(MockS3Client)
+	mockClient.On("ListBuckets", mock.Anything, mock.Anything).Return(&s3.ListBucketsOutput{
+		Buckets: []s3types.Bucket{{Name: aws.String("local-logs")}, {Name: aws.String("eu-logs")}, {Name: aws.String("legacy")}},
+	}, nil)
+	mockClient.On("GetBucketLocation", mock.Anything, &s3.GetBucketLocationInput{Bucket: aws.String("local-logs")}).
+		Return(&s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintUsWest2}, nil)
+	mockClient.On("GetBucketLocation", mock.Anything, &s3.GetBucketLocationInput{Bucket: aws.String("eu-logs")}).
+		Return(&s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintEuWest1}, nil)
+	mockClient.On("GetBucketLocation", mock.Anything, &s3.GetBucketLocationInput{Bucket: aws.String("legacy")}).
+		Return(&s3.GetBucketLocationOutput{}, nil)
+	mockClient.On("GetBucketTagging", mock.Anything, mock.Anything).Return(&s3.GetBucketTaggingOutput{}, nil)
+	mockClient.On("PutBucketTagging", mock.Anything, mock.Anything).Return(&s3.PutBucketTaggingOutput{}, nil)
+
+	tagger := &AWSResourceTagger{
+		ctx:    context.Background(),
+		region: "us-west-2",
+		tags:   map[string]string{"env": "prod"},
+	}
+
+	metrics := tagger.tagS3BucketsWithClient(mockClient)
+
+	assert.Equal(t, 3, metrics.BucketsTagged)
+	assert.Equal(t, map[string]string{
+		"local-logs": "us-west-2",
+		"eu-logs":    "eu-west-1",
+		"legacy":     "us-east-1",
+	}, mockClient.regions)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTagS3DirectoryBuckets(t *testing.T) {
+	mockClient := new(MockS3Client)
+	mockClient.On("ListDirectoryBuckets", mock.Anything, &s3.ListDirectoryBucketsInput{}).
+		Return(&s3.ListDirectoryBucketsOutput{
+			Buckets:           []s3types.Bucket{{Name: aws.String("logs--usw2-az1--x-s3")}},
+			ContinuationToken: aws.String("page-2"),
+		}, nil).Once()
+	mockClient.On("ListDirectoryBuckets", mock.Anything, &s3.ListDirectoryBucketsInput{ContinuationToken: aws.String("page-2")}).
+		Return(&s3.ListDirectoryBucketsOutput{
+			Buckets: []s3types.Bucket{{Name: aws.String("cache--usw2-az2--x-s3")}},
+		}, nil).Once()
+
+	tagClient := new(MockResourceGroupsTaggingClient)
+	tagClient.On("TagResources", mock.Anything, &resourcegroupstaggingapi.TagResourcesInput{
+		ResourceARNList: []string{
+			"arn:aws:s3express:us-west-2:123456789012:bucket/logs--usw2-az1--x-s3",
+			"arn:aws:s3express:us-west-2:123456789012:bucket/cache--usw2-az2--x-s3",
+		},
+		Tags: map[string]string{"Environment": "Test", "Project": "UnitTest"},
+	}).Return(&resourcegroupstaggingapi.TagResourcesOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagS3DirectoryBucketsWithClients(mockClient, tagClient)
+
+	mockClient.AssertExpectations(t)
+	tagClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "PutBucketTagging", mock.Anything, mock.Anything)
+	for _, result := range tagger.Results() {
+		assert.Equal(t, "s3express", result.Service)
+		assert.Equal(t, StatusTagged, result.Status)
+	}
+	assert.Len(t, tagger.Results(), 2)
+}
+
+func TestTagS3DirectoryBucketsUnsupportedRegion(t *testing.T) {
+	mockClient := new(MockS3Client)
+	mockClient.On("ListDirectoryBuckets", mock.Anything, mock.Anything).
+		Return(nil, &net.DNSError{Err: "no such host", Name: "s3express-control.sa-east-1.amazonaws.com", IsNotFound: true})
+	tagClient := new(MockResourceGroupsTaggingClient)
+
+	tagger := createTestTagger()
+	tagger.region = "sa-east-1"
+	tagger.metrics = NewMetricsCollector()
+	tagger.tagS3DirectoryBucketsWithClients(mockClient, tagClient)
+
+	tagClient.AssertNotCalled(t, "TagResources", mock.Anything, mock.Anything)
+	assert.Equal(t, int64(1), tagger.metrics.Unavailable())
+	assert.Equal(t, int64(0), tagger.metrics.APIErrors())
+}
@@ -0,0 +1,109 @@
+package tagger
+
+import (
+	"errors"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// newV1Session builds an aws-sdk-go v1 session for services that have no v2
+// module in this build. It gives the v1 clients what t.cfg gives the v2 ones:
+// the same credentials and FIPS setting, tag write counting, the shared
+// concurrency limiter and retryWithBackoff as the only retry policy for tag writes.
+func (t *AWSResourceTagger) newV1Session(region string) (*session.Session, error) {
+	cfg := &aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewCredentials(&v2CredentialsProvider{tagger: t}),
+	}
+	if t.useFIPS {
+		cfg.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := t.metrics
+	sess.Handlers.Validate.PushBackNamed(request.NamedHandler{
+		Name: "aws-tagger.V1TagWrites",
+		Fn: func(r *request.Request) {
+			if isTagWriteOperation(r.Operation.Name) {
+				metrics.RecordWrite()
+			}
+			if retriedByTagger(r.Operation.Name) {
+				r.Retryer = client.NoOpRetryer{}
+			}
+		},
+	})
+	if limiter := t.limiter; limiter != nil {
+		// Signing is the last step before each attempt is sent, and every
+		// attempt that gets past it completes, so the slot is always released
+		sess.Handlers.Sign.PushBackNamed(request.NamedHandler{
+			Name: "aws-tagger.V1AcquireSlot",
+			Fn: func(r *request.Request) {
+				if r.Error == nil {
+					r.Error = limiter.Acquire(r.Context())
+				}
+			},
+		})
+		sess.Handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+			Name: "aws-tagger.V1ReleaseSlot",
+			Fn: func(r *request.Request) {
+				limiter.Release(isThrottlingError(r.Error))
+			},
+		})
+	}
+	return sess, nil
+}
+
+// isV1RetryableError reports whether err is a v1 SDK error the v1 retryer
+// would repeat, such as a 5xx response or a connection reset
+func isV1RetryableError(err error) bool {
+	var ae awserr.Error
+	return errors.As(err, &ae) && request.IsErrorRetryable(ae)
+}
+
+// v1ErrorCode returns the error code of a v1 SDK error
+func v1ErrorCode(err error) (string, bool) {
+	var ae awserr.Error
+	if !errors.As(err, &ae) {
+		return "", false
+	}
+	return ae.Code(), true
+}
+
+// v2CredentialsProvider lets v1 SDK clients reuse the tagger's v2 credentials
+type v2CredentialsProvider struct {
+	tagger *AWSResourceTagger
+	creds  awsv2.Credentials
+}
+
+// Retrieve fetches credentials from the v2 config's provider
+func (p *v2CredentialsProvider) Retrieve() (credentials.Value, error) {
+	if p.tagger.cfg.Credentials == nil {
+		return credentials.Value{}, credentials.ErrNoValidProvidersFoundInChain
+	}
+	creds, err := p.tagger.cfg.Credentials.Retrieve(p.tagger.ctx)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	p.creds = creds
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    creds.Source,
+	}, nil
+}
+
+// IsExpired reports whether the last retrieved credentials have expired
+func (p *v2CredentialsProvider) IsExpired() bool {
+	return p.creds.Expired()
+}
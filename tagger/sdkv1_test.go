@@ -0,0 +1,80 @@
+package tagger
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go/aws"
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// throttlingRoundTripper throttles every REST-JSON request and counts them by path
+type throttlingRoundTripper struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (rt *throttlingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.calls[req.Method+" "+req.URL.Path]++
+	rt.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Amzn-Errortype": []string{"ThrottlingException"}},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Rate exceeded"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestV1SessionSharesMetricsLimiterAndRetryPolicy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tagger := &AWSResourceTagger{
+		ctx:            ctx,
+		metrics:        NewMetricsCollector(),
+		limiter:        newConcurrencyLimiter(1, false),
+		retryAttempts:  3,
+		retryBaseDelay: time.Millisecond,
+	}
+	tagger.cfg.Credentials = awsv2.CredentialsProviderFunc(func(context.Context) (awsv2.Credentials, error) {
+		return awsv2.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+	})
+
+	sess, err := tagger.newV1Session("us-west-2")
+	require.NoError(t, err)
+	rt := &throttlingRoundTripper{calls: map[string]int{}}
+	sess.Config.HTTPClient = &http.Client{Transport: rt}
+	client := efs.New(sess, &aws.Config{Retryer: awsclient.DefaultRetryer{
+		NumMaxRetries:    2,
+		MinThrottleDelay: time.Millisecond,
+		MaxThrottleDelay: time.Millisecond,
+	}})
+
+	err = tagger.retryWithBackoff(func() error {
+		_, err := client.TagResourceWithContext(ctx, &efs.TagResourceInput{
+			ResourceId: aws.String("fs-1"),
+			Tags:       []*efs.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		})
+		return err
+	})
+	assert.True(t, isThrottlingError(err))
+	assert.Equal(t, 3, rt.calls["POST /2015-02-01/resource-tags/fs-1"], "one request per tagger attempt")
+	assert.Equal(t, int64(3), tagger.metrics.Writes())
+	assert.Equal(t, int64(2), tagger.metrics.Throttles())
+
+	// The single limiter slot was released after every attempt
+	_, err = client.DescribeFileSystemsWithContext(ctx, &efs.DescribeFileSystemsInput{})
+	assert.Error(t, err)
+	assert.NoError(t, ctx.Err())
+	assert.Equal(t, 3, rt.calls["GET /2015-02-01/file-systems"], "other calls keep the SDK retryer")
+}
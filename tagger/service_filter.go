@@ -0,0 +1,88 @@
+package tagger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// serviceGroups are short names that select several services at once
+var serviceGroups = map[string][]string{
+	"s3": {"S3Buckets", "S3DirectoryBuckets"},
+}
+
+// WithServiceFilter limits TagAllResources to the only services, when any are
+// given, minus the excluded ones. Names match TagAllResources keys or service
+// groups case-insensitively.
+func WithServiceFilter(only, exclude []string) Option {
+	return func(t *AWSResourceTagger) {
+		t.onlyServices = lowerSet(expandServiceGroups(only))
+		t.excludeServices = lowerSet(expandServiceGroups(exclude))
+	}
+}
+
+// expandServiceGroups replaces service group names with their services
+func expandServiceGroups(names []string) []string {
+	expanded := make([]string, 0, len(names))
+	for _, name := range names {
+		if group, ok := serviceGroups[strings.ToLower(name)]; ok {
+			expanded = append(expanded, group...)
+			continue
+		}
+		expanded = append(expanded, name)
+	}
+	return expanded
+}
+
+// ServiceNames lists the service names accepted by --only-services and --exclude-services
+func ServiceNames() []string {
+	taggers := (&AWSResourceTagger{}).resourceTaggers()
+	names := make([]string, 0, len(taggers))
+	for name := range taggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateServiceNames reports the first name that matches no service
+func ValidateServiceNames(names []string) error {
+	known := lowerSet(ServiceNames())
+	for _, name := range expandServiceGroups(names) {
+		if !known[strings.ToLower(name)] {
+			return fmt.Errorf("unknown service %q; valid services are %s", name, strings.Join(ServiceNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// filterResourceTaggers applies the configured service filter to resourceTaggers
+func (t *AWSResourceTagger) filterResourceTaggers(resourceTaggers map[string]func() error) map[string]func() error {
+	if len(t.onlyServices) == 0 && len(t.excludeServices) == 0 {
+		return resourceTaggers
+	}
+	filtered := make(map[string]func() error, len(resourceTaggers))
+	for name, tagger := range resourceTaggers {
+		key := strings.ToLower(name)
+		if len(t.onlyServices) > 0 && !t.onlyServices[key] {
+			continue
+		}
+		if t.excludeServices[key] {
+			continue
+		}
+		filtered[name] = tagger
+	}
+	return filtered
+}
+
+// lowerSet returns the lower-cased names as a set
+func lowerSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
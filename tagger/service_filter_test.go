@@ -0,0 +1,61 @@
+package tagger
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterResourceTaggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		only     []string
+		exclude  []string
+		expected []string
+	}{
+		{name: "No filter keeps every service", expected: ServiceNames()},
+		{name: "Only services, case-insensitive", only: []string{"ec2", "RDS"}, expected: []string{"EC2", "RDS"}},
+		{
+			name:    "Exclude services",
+			exclude: []string{"athena", "GLUE"},
+			expected: func() []string {
+				var names []string
+				for _, name := range ServiceNames() {
+					if name != "Athena" && name != "Glue" {
+						names = append(names, name)
+					}
+				}
+				return names
+			}(),
+		},
+		{name: "Exclude wins over only", only: []string{"ec2", "s3buckets"}, exclude: []string{"s3buckets"}, expected: []string{"EC2"}},
+		{name: "Service group", only: []string{"rds", "S3"}, expected: []string{"RDS", "S3Buckets", "S3DirectoryBuckets"}},
+		{name: "Exclude part of a group", only: []string{"s3"}, exclude: []string{"s3directorybuckets"}, expected: []string{"S3Buckets"}},
+		{name: "Excluding every included service leaves none", only: []string{"glue"}, exclude: []string{"Glue"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &AWSResourceTagger{}
+			WithServiceFilter(tt.only, tt.exclude)(tagger)
+
+			filtered := tagger.filterResourceTaggers(tagger.resourceTaggers())
+
+			names := make([]string, 0, len(filtered))
+			for name := range filtered {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestValidateServiceNames(t *testing.T) {
+	assert.NoError(t, ValidateServiceNames([]string{"athena", "Glue", "S3BUCKETS", "s3"}))
+
+	err := ValidateServiceNames([]string{"ec2", "glacier"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown service "glacier"`)
+}
@@ -0,0 +1,102 @@
+package tagger
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// WithSkipTagged reads each resource's existing tags before tagging and skips
+// resources that already carry every desired tag. It applies to Glue, RDS,
+// CodeBuild and S3 buckets; Glue and S3 pay one extra read call per resource.
+func WithSkipTagged(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.skipTagged = enabled
+	}
+}
+
+// tagsAlreadyApplied reports whether existing carries every desired key with the desired value
+func tagsAlreadyApplied(existing, desired map[string]string) bool {
+	for k, v := range desired {
+		if current, ok := existing[k]; !ok || current != v {
+			return false
+		}
+	}
+	return true
+}
+
+// hasDesiredTags reports whether existing needs no tag write, including ensured keys
+func (t *AWSResourceTagger) hasDesiredTags(existing map[string]string) bool {
+	if !tagsAlreadyApplied(existing, t.tags) {
+		return false
+	}
+	keys := make([]string, 0, len(existing))
+	for k := range existing {
+		keys = append(keys, k)
+	}
+	return len(t.missingEnsureKeys(keys)) == 0
+}
+
+// skipAlreadyApplied records target as skipped when --skip-tagged is set and
+// existing already carries every desired tag
+func (t *AWSResourceTagger) skipAlreadyApplied(target TagResult, existing map[string]string) bool {
+	if !t.skipTagged || !t.hasDesiredTags(existing) {
+		return false
+	}
+	t.metrics.RecordAlreadyTagged()
+	t.skipResource(target, "already has all tags")
+	return true
+}
+
+// glueAlreadyTagged reports whether a Glue resource can be skipped, reading its
+// tags with GetTags when --skip-tagged is set; a failed read falls back to tagging
+func (t *AWSResourceTagger) glueAlreadyTagged(client GlueAPI, arn string) bool {
+	if t.skipTagged && !t.hasAllTags(arn) {
+		var output *glue.GetTagsOutput
+		err := t.retryWithBackoff(func() error {
+			var err error
+			output, err = client.GetTags(t.ctx, &glue.GetTagsInput{ResourceArn: aws.String(arn)})
+			return err
+		})
+		if err != nil {
+			log.Printf("Could not read existing tags of %s, tagging anyway: %v", arn, err)
+		} else if tagsAlreadyApplied(output.Tags, t.tags) {
+			t.markTagged(arn)
+		}
+	}
+	return t.alreadyTagged(arn)
+}
+
+// rdsTagMap converts RDS tags to a key/value map
+func rdsTagMap(tags []rdstypes.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return m
+}
+
+// bucketAlreadyTagged skips a bucket whose existing tags already include every
+// desired tag; it only reads tags when --skip-tagged is set
+func (t *AWSResourceTagger) bucketAlreadyTagged(client S3API, target TagResult) bool {
+	if !t.skipTagged {
+		return false
+	}
+	region, err := t.bucketRegion(client, target.ResourceID)
+	if err != nil {
+		region = t.region
+	}
+	existing, err := t.bucketTags(client, target.ResourceID, func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	})
+	if err != nil {
+		log.Printf("Could not read existing tags of S3 bucket %s, tagging anyway: %v", target.ResourceID, err)
+		return false
+	}
+	return t.skipAlreadyApplied(target, existing)
+}
@@ -0,0 +1,167 @@
+package tagger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagsAlreadyApplied(t *testing.T) {
+	desired := map[string]string{"Environment": "Test", "Project": "UnitTest"}
+	tests := []struct {
+		name     string
+		existing map[string]string
+		want     bool
+	}{
+		{"all tags with extras", map[string]string{"Environment": "Test", "Project": "UnitTest", "Owner": "ops"}, true},
+		{"missing key", map[string]string{"Environment": "Test"}, false},
+		{"different value", map[string]string{"Environment": "Prod", "Project": "UnitTest"}, false},
+		{"no tags", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tagsAlreadyApplied(tt.existing, desired))
+		})
+	}
+}
+
+func TestSkipTaggedRDSInstances(t *testing.T) {
+	mockClient := new(MockRDSClient)
+	mockClient.On("DescribeDBInstances", mock.Anything, mock.Anything).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db-full"),
+				DBInstanceArn:        aws.String("arn:aws:rds:us-west-2:123456789012:db:db-full"),
+				TagList:              convertToRDSTags(map[string]string{"Environment": "Test", "Project": "UnitTest"}),
+			},
+			{
+				DBInstanceIdentifier: aws.String("db-partial"),
+				DBInstanceArn:        aws.String("arn:aws:rds:us-west-2:123456789012:db:db-partial"),
+				TagList:              convertToRDSTags(map[string]string{"Environment": "Test"}),
+			},
+		},
+	}, nil)
+	mockClient.On("AddTagsToResource", mock.Anything, mock.MatchedBy(func(input *rds.AddTagsToResourceInput) bool {
+		return aws.ToString(input.ResourceName) == "arn:aws:rds:us-west-2:123456789012:db:db-partial"
+	})).Return(&rds.AddTagsToResourceOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	WithSkipTagged(true)(tagger)
+
+	tagger.tagDBInstancesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "AddTagsToResource", 1)
+	assert.Equal(t, int64(1), tagger.metrics.AlreadyTagged())
+	statuses := map[string]string{}
+	for _, result := range tagger.Results() {
+		statuses[result.ResourceID] = result.Status
+	}
+	assert.Equal(t, map[string]string{"db-full": StatusSkipped, "db-partial": StatusTagged}, statuses)
+}
+
+func TestSkipTaggedS3Buckets(t *testing.T) {
+	mockClient := new(MockS3Client)
+	mockClient.On("ListBuckets", mock.Anything, mock.Anything).Return(&s3.ListBucketsOutput{
+		Buckets: []s3types.Bucket{{Name: aws.String("full")}, {Name: aws.String("partial")}},
+	}, nil)
+	mockClient.On("GetBucketLocation", mock.Anything, mock.Anything).
+		Return(&s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintUsWest2}, nil)
+	mockClient.On("GetBucketTagging", mock.Anything, &s3.GetBucketTaggingInput{Bucket: aws.String("full")}).
+		Return(&s3.GetBucketTaggingOutput{TagSet: convertToS3Tags(map[string]string{"Environment": "Test", "Project": "UnitTest"})}, nil)
+	mockClient.On("GetBucketTagging", mock.Anything, &s3.GetBucketTaggingInput{Bucket: aws.String("partial")}).
+		Return(&s3.GetBucketTaggingOutput{TagSet: convertToS3Tags(map[string]string{"Project": "UnitTest"})}, nil)
+	mockClient.On("PutBucketTagging", mock.Anything, mock.MatchedBy(func(input *s3.PutBucketTaggingInput) bool {
+		return aws.ToString(input.Bucket) == "partial"
+	})).Return(&s3.PutBucketTaggingOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.metrics = NewMetricsCollector()
+	WithSkipTagged(true)(tagger)
+
+	metrics := tagger.tagS3BucketsWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "PutBucketTagging", 1)
+	assert.Equal(t, 1, metrics.BucketsSkipped)
+	assert.Equal(t, 1, metrics.BucketsTagged)
+	assert.Equal(t, int64(1), tagger.metrics.AlreadyTagged())
+}
+
+func TestSkipTaggedGlueDatabases(t *testing.T) {
+	fullARN := "arn:aws:glue:us-west-2:123456789012:database/full"
+	partialARN := "arn:aws:glue:us-west-2:123456789012:database/partial"
+
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("full")}, {Name: aws.String("partial")}},
+	}, nil)
+	mockClient.On("GetTags", mock.Anything, &glue.GetTagsInput{ResourceArn: aws.String(fullARN)}).
+		Return(&glue.GetTagsOutput{Tags: map[string]string{"Environment": "Test", "Project": "UnitTest", "Owner": "data"}}, nil)
+	mockClient.On("GetTags", mock.Anything, &glue.GetTagsInput{ResourceArn: aws.String(partialARN)}).
+		Return(&glue.GetTagsOutput{Tags: map[string]string{"Environment": "Prod"}}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.MatchedBy(func(input *glue.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == partialARN
+	})).Return(&glue.TagResourceOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	WithSkipTagged(true)(tagger)
+
+	metrics := &GlueMetrics{}
+	tagger.tagGlueDatabases(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TagResource", 1)
+	assert.Equal(t, int64(1), tagger.metrics.AlreadyTagged())
+	assert.Equal(t, int32(1), metrics.DatabasesSkipped)
+	assert.Equal(t, int32(1), metrics.DatabasesTagged)
+	require.Len(t, tagger.Results(), 2)
+	statuses := map[string]string{}
+	for _, result := range tagger.Results() {
+		statuses[result.ARN] = result.Status
+	}
+	assert.Equal(t, map[string]string{fullARN: StatusSkipped, partialARN: StatusTagged}, statuses)
+}
+
+func TestSkipTaggedGlueCrawlerIsRecordedOnceAsSkipped(t *testing.T) {
+	crawlerARN := "arn:aws:glue:us-west-2:123456789012:crawler/nightly"
+
+	mockClient := new(MockGlueClient)
+	mockClient.On("GetCrawlers", mock.Anything, mock.Anything).Return(&glue.GetCrawlersOutput{
+		Crawlers: []gluetypes.Crawler{{Name: aws.String("nightly")}},
+	}, nil)
+	mockClient.On("GetTags", mock.Anything, &glue.GetTagsInput{ResourceArn: aws.String(crawlerARN)}).
+		Return(nil, &mockAPIError{code: "ThrottlingException", message: "Rate exceeded"}).Once()
+	mockClient.On("GetTags", mock.Anything, &glue.GetTagsInput{ResourceArn: aws.String(crawlerARN)}).
+		Return(&glue.GetTagsOutput{Tags: map[string]string{"Environment": "Test", "Project": "UnitTest"}}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.metrics = NewMetricsCollector()
+	tagger.retryBaseDelay = time.Millisecond
+	WithSkipTagged(true)(tagger)
+
+	metrics := &GlueMetrics{}
+	tagger.tagGlueCrawlers(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TagResource", mock.Anything, mock.Anything)
+	assert.Equal(t, int32(1), metrics.CrawlersSkipped)
+	assert.Zero(t, metrics.CrawlersTagged)
+	require.Len(t, tagger.Results(), 1)
+	assert.Equal(t, StatusSkipped, tagger.Results()[0].Status)
+}
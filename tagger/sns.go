@@ -0,0 +1,83 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// SNSAPI interface for SNS client operations
+type SNSAPI interface {
+	ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error)
+	TagResource(ctx context.Context, params *sns.TagResourceInput, optFns ...func(*sns.Options)) (*sns.TagResourceOutput, error)
+}
+
+func init() {
+	registerService("SNS", (*AWSResourceTagger).tagSNSResources)
+}
+
+// tagSNSResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagSNSResources() error {
+	client := sns.NewFromConfig(t.cfg)
+	t.tagSNSResourcesWithClient(client)
+	return nil
+}
+
+// tagSNSResourcesWithClient tags every SNS topic in the region
+func (t *AWSResourceTagger) tagSNSResourcesWithClient(client SNSAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging SNS topics...")
+	defer log.Println("Completed tagging SNS topics")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping SNS topic tagging")
+		return
+	}
+
+	input := &sns.ListTopicsInput{}
+	for {
+		output, err := client.ListTopics(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "SNS Topics")
+			return
+		}
+
+		for _, topic := range output.Topics {
+			arn := aws.ToString(topic.TopicArn)
+			t.applyAndRecord(TagResult{
+				Service:      "SNS",
+				ResourceType: "topic",
+				ResourceID:   arn[strings.LastIndex(arn, ":")+1:],
+				ARN:          arn,
+			}, func() error {
+				_, err := client.TagResource(t.ctx, &sns.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        t.snsTags(),
+				})
+				return err
+			})
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// snsTags converts the configured tags to SNS tags
+func (t *AWSResourceTagger) snsTags() []snstypes.Tag {
+	tags := make([]snstypes.Tag, 0, len(t.tags))
+	for _, k := range t.orderedTagKeys() {
+		tags = append(tags, snstypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(t.tags[k]),
+		})
+	}
+	return tags
+}
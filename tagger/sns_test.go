@@ -0,0 +1,116 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSNSClient is a mock implementation of SNSAPI
+type MockSNSClient struct {
+	mock.Mock
+}
+
+func (m *MockSNSClient) ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sns.ListTopicsOutput), args.Error(1)
+}
+
+func (m *MockSNSClient) TagResource(ctx context.Context, params *sns.TagResourceInput, optFns ...func(*sns.Options)) (*sns.TagResourceOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sns.TagResourceOutput), args.Error(1)
+}
+
+func snsTopicARN(name string) string {
+	return "arn:aws:sns:us-west-2:123456789012:" + name
+}
+
+// snsTopic matches a TagResource call for the named topic
+func snsTopic(name string) interface{} {
+	return mock.MatchedBy(func(input *sns.TagResourceInput) bool {
+		return aws.ToString(input.ResourceArn) == snsTopicARN(name)
+	})
+}
+
+func TestTagSNSResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		tags           map[string]string
+		setupMock      func(*MockSNSClient)
+		expectedStatus []string
+	}{
+		{
+			name: "paginates and continues after a failing topic",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockSNSClient) {
+				m.On("ListTopics", mock.Anything, &sns.ListTopicsInput{}).
+					Return(&sns.ListTopicsOutput{
+						Topics: []snstypes.Topic{
+							{TopicArn: aws.String(snsTopicARN("alerts"))},
+							{TopicArn: aws.String(snsTopicARN("locked"))},
+						},
+						NextToken: aws.String("page-2"),
+					}, nil).Once()
+				m.On("ListTopics", mock.Anything, &sns.ListTopicsInput{NextToken: aws.String("page-2")}).
+					Return(&sns.ListTopicsOutput{
+						Topics: []snstypes.Topic{{TopicArn: aws.String(snsTopicARN("orders"))}},
+					}, nil).Once()
+				m.On("TagResource", mock.Anything, snsTopic("alerts")).Return(&sns.TagResourceOutput{}, nil).Once()
+				m.On("TagResource", mock.Anything, snsTopic("locked")).Return(nil, errors.New("AuthorizationError")).Once()
+				m.On("TagResource", mock.Anything, snsTopic("orders")).Return(&sns.TagResourceOutput{}, nil).Once()
+			},
+			expectedStatus: []string{StatusTagged, StatusFailed, StatusTagged},
+		},
+		{
+			name:           "skips when no tags are configured",
+			tags:           map[string]string{},
+			setupMock:      func(m *MockSNSClient) {},
+			expectedStatus: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockSNSClient)
+			tt.setupMock(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:     context.Background(),
+				tags:    tt.tags,
+				results: NewResultCollector(),
+			}
+			tagger.tagSNSResourcesWithClient(mockClient)
+
+			mockClient.AssertExpectations(t)
+			if len(tt.tags) == 0 {
+				mockClient.AssertNotCalled(t, "ListTopics", mock.Anything, mock.Anything)
+			}
+
+			var statuses []string
+			for _, result := range tagger.Results() {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.expectedStatus, statuses)
+		})
+	}
+}
+
+func TestSNSTagsAreConverted(t *testing.T) {
+	tagger := &AWSResourceTagger{tags: map[string]string{"map-migrated": "mig12345"}}
+
+	tags := tagger.snsTags()
+
+	assert.Equal(t, []snstypes.Tag{{Key: aws.String("map-migrated"), Value: aws.String("mig12345")}}, tags)
+}
@@ -0,0 +1,71 @@
+package tagger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// SQSAPI interface for SQS client operations
+type SQSAPI interface {
+	ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error)
+	TagQueue(ctx context.Context, params *sqs.TagQueueInput, optFns ...func(*sqs.Options)) (*sqs.TagQueueOutput, error)
+}
+
+func init() {
+	registerService("SQS", (*AWSResourceTagger).tagSQSResources)
+}
+
+// tagSQSResources is the main entry point that creates and uses the client
+func (t *AWSResourceTagger) tagSQSResources() error {
+	client := sqs.NewFromConfig(t.cfg)
+	t.tagSQSResourcesWithClient(client)
+	return nil
+}
+
+// tagSQSResourcesWithClient tags every SQS queue in the region
+func (t *AWSResourceTagger) tagSQSResourcesWithClient(client SQSAPI) {
+	fmt.Println("=====================================")
+	log.Println("Tagging SQS queues...")
+	defer log.Println("Completed tagging SQS queues")
+
+	if len(t.tags) == 0 {
+		log.Println("No tags provided, skipping SQS queue tagging")
+		return
+	}
+
+	input := &sqs.ListQueuesInput{}
+	for {
+		output, err := client.ListQueues(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "SQS Queues")
+			return
+		}
+
+		for _, queueURL := range output.QueueUrls {
+			// TagQueue takes the URL, whose last path segment is the queue name
+			name := queueURL[strings.LastIndex(queueURL, "/")+1:]
+			t.applyAndRecord(TagResult{
+				Service:      "SQS",
+				ResourceType: SQSQueue.Type,
+				ResourceID:   name,
+				ARN:          t.buildARN(SQSQueue, name),
+			}, func() error {
+				_, err := client.TagQueue(t.ctx, &sqs.TagQueueInput{
+					QueueUrl: aws.String(queueURL),
+					Tags:     t.tags,
+				})
+				return err
+			})
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
@@ -0,0 +1,118 @@
+package tagger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSQSClient is a mock implementation of SQSAPI
+type MockSQSClient struct {
+	mock.Mock
+}
+
+func (m *MockSQSClient) ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sqs.ListQueuesOutput), args.Error(1)
+}
+
+func (m *MockSQSClient) TagQueue(ctx context.Context, params *sqs.TagQueueInput, optFns ...func(*sqs.Options)) (*sqs.TagQueueOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sqs.TagQueueOutput), args.Error(1)
+}
+
+func sqsQueueURL(name string) string {
+	return "https://sqs.us-west-2.amazonaws.com/123456789012/" + name
+}
+
+func TestTagSQSResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		tags           map[string]string
+		setupMock      func(*MockSQSClient)
+		expectedStatus []string
+	}{
+		{
+			name: "paginates and continues after a failing queue",
+			tags: map[string]string{"map-migrated": "mig12345"},
+			setupMock: func(m *MockSQSClient) {
+				m.On("ListQueues", mock.Anything, &sqs.ListQueuesInput{}).
+					Return(&sqs.ListQueuesOutput{
+						QueueUrls: []string{sqsQueueURL("ingest"), sqsQueueURL("locked")},
+						NextToken: aws.String("page-2"),
+					}, nil).Once()
+				m.On("ListQueues", mock.Anything, &sqs.ListQueuesInput{NextToken: aws.String("page-2")}).
+					Return(&sqs.ListQueuesOutput{
+						QueueUrls: []string{sqsQueueURL("ingest-dlq")},
+					}, nil).Once()
+				for _, name := range []string{"ingest", "ingest-dlq"} {
+					m.On("TagQueue", mock.Anything, &sqs.TagQueueInput{
+						QueueUrl: aws.String(sqsQueueURL(name)),
+						Tags:     map[string]string{"map-migrated": "mig12345"},
+					}).Return(&sqs.TagQueueOutput{}, nil).Once()
+				}
+				m.On("TagQueue", mock.Anything, &sqs.TagQueueInput{
+					QueueUrl: aws.String(sqsQueueURL("locked")),
+					Tags:     map[string]string{"map-migrated": "mig12345"},
+				}).Return(nil, errors.New("AccessDenied")).Once()
+			},
+			expectedStatus: []string{StatusTagged, StatusFailed, StatusTagged},
+		},
+		{
+			name:           "skips when no tags are configured",
+			tags:           map[string]string{},
+			setupMock:      func(m *MockSQSClient) {},
+			expectedStatus: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockSQSClient)
+			tt.setupMock(mockClient)
+
+			tagger := createTestTagger()
+			tagger.tags = tt.tags
+			tagger.results = NewResultCollector()
+			tagger.tagSQSResourcesWithClient(mockClient)
+
+			mockClient.AssertExpectations(t)
+			if len(tt.tags) == 0 {
+				mockClient.AssertNotCalled(t, "ListQueues", mock.Anything, mock.Anything)
+			}
+
+			var statuses []string
+			for _, result := range tagger.Results() {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.expectedStatus, statuses)
+		})
+	}
+}
+
+func TestTagSQSResourcesRecordsQueueARN(t *testing.T) {
+	mockClient := new(MockSQSClient)
+	mockClient.On("ListQueues", mock.Anything, &sqs.ListQueuesInput{}).
+		Return(&sqs.ListQueuesOutput{QueueUrls: []string{sqsQueueURL("orders.fifo")}}, nil).Once()
+	mockClient.On("TagQueue", mock.Anything, mock.Anything).Return(&sqs.TagQueueOutput{}, nil).Once()
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagSQSResourcesWithClient(mockClient)
+
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "orders.fifo", results[0].ResourceID)
+	assert.Equal(t, "arn:aws:sqs:us-west-2:123456789012:orders.fifo", results[0].ARN)
+}
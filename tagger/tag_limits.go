@@ -0,0 +1,103 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// defaultMaxTagKeys is the per-resource tag limit of most AWS services
+const defaultMaxTagKeys = 50
+
+// serviceMaxTagKeys holds per-resource tag limits for services guarded before writing
+var serviceMaxTagKeys = map[string]int{
+	"EC2": 50,
+	"RDS": 50,
+}
+
+// serviceMaxTagsPerCall holds how many tags a single tag call accepts for
+// services whose limit is below the per-resource limit
+var serviceMaxTagsPerCall = map[string]int{
+	"Kinesis": 10,
+}
+
+// tagChunks splits tags into maps of at most the service's per-call limit, in
+// key order, so services that tag incrementally can apply them in several calls
+func tagChunks(service string, tags map[string]string) []map[string]string {
+	size, ok := serviceMaxTagsPerCall[service]
+	if !ok || len(tags) <= size {
+		return []map[string]string{tags}
+	}
+
+	var chunks []map[string]string
+	for _, key := range sortedTagKeys(tags) {
+		if len(chunks) == 0 || len(chunks[len(chunks)-1]) == size {
+			chunks = append(chunks, make(map[string]string, size))
+		}
+		chunks[len(chunks)-1][key] = tags[key]
+	}
+	return chunks
+}
+
+// WithMaxTagKeys caps the tag keys a resource may end up with; zero uses the service limit
+func WithMaxTagKeys(n int) Option {
+	return func(t *AWSResourceTagger) {
+		t.maxTagKeys = n
+	}
+}
+
+// tagKeyLimit returns the number of tag keys a resource of service may carry
+func (t *AWSResourceTagger) tagKeyLimit(service string) int {
+	if t.maxTagKeys > 0 {
+		return t.maxTagKeys
+	}
+	if limit, ok := serviceMaxTagKeys[service]; ok {
+		return limit
+	}
+	return defaultMaxTagKeys
+}
+
+// skipOverTagLimit records a skipped result when merging the desired keys into
+// the existing ones would exceed the resource's tag limit
+func (t *AWSResourceTagger) skipOverTagLimit(target TagResult, existing, desired []string) bool {
+	keys := make(map[string]bool, len(existing)+len(desired))
+	for _, k := range existing {
+		keys[k] = true
+	}
+	for _, k := range desired {
+		keys[k] = true
+	}
+
+	limit := t.tagKeyLimit(target.Service)
+	if len(keys) <= limit {
+		return false
+	}
+
+	reason := fmt.Sprintf("merged tags would have %d keys, over the %d-key limit", len(keys), limit)
+	log.Printf("Error: skipping %s %s %s: %s", target.Service, target.ResourceType, target.ResourceID, reason)
+	target.Status = StatusSkipped
+	target.Error = reason
+	t.recordResult(target)
+	return true
+}
+
+// ec2TagKeys returns the keys of EC2 tags
+func ec2TagKeys(tags []types.Tag) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, aws.ToString(tag.Key))
+	}
+	return keys
+}
+
+// rdsTagKeys returns the keys of RDS tags
+func rdsTagKeys(tags []rdstypes.Tag) []string {
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, aws.ToString(tag.Key))
+	}
+	return keys
+}
@@ -0,0 +1,98 @@
+package tagger
+
+import (
+	"log"
+	"sort"
+)
+
+// Taggability records whether a resource type accepts tags and any constraint
+// that limits which resources of that type are tagged
+type Taggability struct {
+	Service      string
+	ResourceType string
+	Taggable     bool
+	Constraint   string
+}
+
+// resourceTaggability is the registry of known resource types, keyed by service/type
+var resourceTaggability = map[string]Taggability{}
+
+func init() {
+	for _, entry := range []Taggability{
+		{Service: GlueDatabase.Service, ResourceType: GlueDatabase.Type, Taggable: true},
+		{Service: GlueConnection.Service, ResourceType: GlueConnection.Type, Taggable: true},
+		{Service: GlueCrawler.Service, ResourceType: GlueCrawler.Type, Taggable: true},
+		{Service: GlueJob.Service, ResourceType: GlueJob.Type, Taggable: true},
+		{Service: GlueTrigger.Service, ResourceType: GlueTrigger.Type, Taggable: true},
+		{Service: GlueWorkflow.Service, ResourceType: GlueWorkflow.Type, Taggable: true},
+		{Service: "glue", ResourceType: "table", Taggable: true},
+		{Service: "glue", ResourceType: "partition", Taggable: false,
+			Constraint: "Glue partitions do not support tags; tag the owning table instead"},
+		{Service: "glue", ResourceType: "column-statistics-task", Taggable: false,
+			Constraint: "Glue column statistics task schedules do not support tags"},
+		{Service: AthenaWorkgroup.Service, ResourceType: AthenaWorkgroup.Type, Taggable: true,
+			Constraint: "the primary workgroup is skipped unless --skip-defaults=false"},
+		{Service: AthenaCatalog.Service, ResourceType: AthenaCatalog.Type, Taggable: true},
+		{Service: "globalaccelerator", ResourceType: "accelerator", Taggable: true},
+		{Service: "globalaccelerator", ResourceType: "listener", Taggable: false,
+			Constraint: "Global Accelerator listeners do not support tags; tag the accelerator instead"},
+		{Service: "kms", ResourceType: "aws-managed-key", Taggable: false,
+			Constraint: "AWS managed KMS keys cannot be tagged by the account"},
+		{Service: "kms", ResourceType: "key", Taggable: true},
+		{Service: "ec2", ResourceType: "security-group", Taggable: true,
+			Constraint: "the default security group is skipped unless --skip-defaults=false"},
+	} {
+		resourceTaggability[taggabilityKey(entry.Service, entry.ResourceType)] = entry
+	}
+}
+
+// taggabilityKey builds the registry key for a service and resource type
+func taggabilityKey(service, resourceType string) string {
+	return service + "/" + resourceType
+}
+
+// ResourceTaggability returns the registry entries sorted by service and resource type
+func ResourceTaggability() []Taggability {
+	entries := make([]Taggability, 0, len(resourceTaggability))
+	for _, entry := range resourceTaggability {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return taggabilityKey(entries[i].Service, entries[i].ResourceType) <
+			taggabilityKey(entries[j].Service, entries[j].ResourceType)
+	})
+	return entries
+}
+
+// lookupTaggability returns the registry entry for a resource type
+func lookupTaggability(service, resourceType string) (Taggability, bool) {
+	entry, ok := resourceTaggability[taggabilityKey(service, resourceType)]
+	return entry, ok
+}
+
+// isTaggable reports whether a resource type accepts tags. Types missing from
+// the registry are assumed taggable so new services are not silently skipped.
+func isTaggable(service, resourceType string) bool {
+	entry, ok := lookupTaggability(service, resourceType)
+	return !ok || entry.Taggable
+}
+
+// skipUntaggable records a skipped result for resources whose type cannot be
+// tagged. The per-service taggers never list such types, so it guards the
+// ARN-based paths: --arns-file, --service/--resource-type and S3 buckets.
+func (t *AWSResourceTagger) skipUntaggable(service, resourceType, resourceID, arn string) bool {
+	if isTaggable(service, resourceType) {
+		return false
+	}
+	entry, _ := lookupTaggability(service, resourceType)
+	log.Printf("Skipping untaggable %s %s %s: %s", service, resourceType, resourceID, entry.Constraint)
+	t.recordResult(TagResult{
+		Service:      service,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		ARN:          arn,
+		Status:       StatusSkipped,
+		Error:        entry.Constraint,
+	})
+	return true
+}
@@ -0,0 +1,91 @@
+package tagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourceTaggabilityRegistry(t *testing.T) {
+	entries := ResourceTaggability()
+	assert.NotEmpty(t, entries)
+
+	seen := make(map[string]bool)
+	for i, entry := range entries {
+		key := taggabilityKey(entry.Service, entry.ResourceType)
+		assert.False(t, seen[key], "duplicate registry entry %s", key)
+		seen[key] = true
+		if !entry.Taggable {
+			assert.NotEmpty(t, entry.Constraint, "untaggable entry %s must explain why", key)
+		}
+		if i > 0 {
+			prev := entries[i-1]
+			assert.Less(t, taggabilityKey(prev.Service, prev.ResourceType), key, "registry must be sorted")
+		}
+	}
+
+	tests := []struct {
+		service      string
+		resourceType string
+		taggable     bool
+	}{
+		{"glue", "table", true},
+		{"glue", "partition", false},
+		{"glue", "database", true},
+		{"glue", "workflow", true},
+		{"kms", "aws-managed-key", false},
+		{"kms", "key", true},
+		{"athena", "workgroup", true},
+	}
+	for _, tt := range tests {
+		entry, ok := lookupTaggability(tt.service, tt.resourceType)
+		assert.True(t, ok, "missing registry entry %s/%s", tt.service, tt.resourceType)
+		assert.Equal(t, tt.taggable, entry.Taggable, "%s/%s", tt.service, tt.resourceType)
+	}
+
+	workgroup, _ := lookupTaggability("athena", "workgroup")
+	assert.Contains(t, workgroup.Constraint, "primary")
+}
+
+func TestIsTaggableUnknownType(t *testing.T) {
+	assert.True(t, isTaggable("lambda", "function"))
+}
+
+func TestSkipUntaggable(t *testing.T) {
+	tagger := &AWSResourceTagger{results: NewResultCollector()}
+
+	assert.False(t, tagger.skipUntaggable("glue", "table", "orders", "arn:aws:glue:us-east-1:123456789012:table/sales/orders"))
+	assert.True(t, tagger.skipUntaggable("glue", "partition", "orders/2024", ""))
+
+	results := tagger.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Equal(t, "partition", results[0].ResourceType)
+	assert.Contains(t, results[0].Error, "owning table")
+}
+
+func TestUntaggableARNsAreLeftOutOfTagResourcesBatches(t *testing.T) {
+	table := "arn:aws:glue:us-west-2:123456789012:table/sales/orders"
+	partition := "arn:aws:glue:us-west-2:123456789012:partition/sales/orders/2024"
+	mockClient := new(MockResourceGroupsTaggingClient)
+	mockClient.On("TagResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.TagResourcesInput) bool {
+		return assert.ObjectsAreEqual([]string{table}, input.ResourceARNList)
+	})).Return(&resourcegroupstaggingapi.TagResourcesOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"env": "prod"},
+		results: NewResultCollector(),
+	}
+	tagger.tagARNsWithClient(mockClient, []string{table, partition})
+
+	mockClient.AssertExpectations(t)
+	statuses := map[string]string{}
+	for _, r := range tagger.Results() {
+		statuses[r.ARN] = r.Status
+	}
+	assert.Equal(t, map[string]string{table: StatusTagged, partition: StatusSkipped}, statuses)
+}
@@ -4,15 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 // AWSResourceTagger handles AWS resource tagging operations
@@ -23,60 +33,414 @@ type AWSResourceTagger struct {
 	awsTags   []types.Tag
 	accountID string
 	region    string
+	results   *ResultCollector
+	metrics   *MetricsCollector
+
+	// onlyServices and excludeServices filter TagAllResources by lower-cased service name
+	onlyServices    map[string]bool
+	excludeServices map[string]bool
+
+	// regions lists every region a multi-region run covers; empty means region only
+	regions []string
+	// allRegions replaces regions with every region enabled for the account
+	allRegions bool
+	// regionSummary breaks the report summary down per region
+	regionSummary bool
+	// useFIPS resolves FIPS endpoints for every service
+	useFIPS bool
+	// resourceTypeSummary breaks the run summary down per service and resource type
+	resourceTypeSummary bool
+
+	// discovery caches resource listings across phases when enabled
+	discovery *discoveryCache
+
+	// dryRun discovers resources but never calls a tag API
+	dryRun      bool
+	dryRunCount int64
+	// verifyMismatches counts resources whose tags did not read back as written
+	verifyMismatches int64
+
+	// deterministic sorts tag keys and reported results for reproducible output
+	deterministic bool
+
+	// errorOnEmpty fails the run when no resource was found in any service
+	errorOnEmpty bool
+
+	// strictValidation restricts tags to the character set accepted by AWS
+	strictValidation bool
+
+	// serviceDelay is the pause after each service tagger completes
+	serviceDelay time.Duration
+	sleep        func(time.Duration)
+	// serviceTimeout cancels a service tagger that runs longer; zero disables it
+	serviceTimeout time.Duration
+
+	// reportFile receives a JSON report when the run ends, even if interrupted
+	reportFile string
+	// outputFormat set to json prints the report to out, stdout by default, without a report file
+	outputFormat string
+	out          io.Writer
+	// diffAgainst is a previous report the run is compared with
+	diffAgainst string
+	// allowCrossAccountARNs tags --arns-file entries owned by other accounts
+	allowCrossAccountARNs bool
+	// failedARNsFile receives the ARNs of failed resources for a retry run
+	failedARNsFile string
+	// outputDir receives all run artifacts in a timestamped subfolder
+	outputDir string
+	// checkpointFile receives tagged resources as they succeed; resume skips those already listed
+	checkpointFile string
+	resume         bool
+	checkpoint     *checkpoint
+
+	// roleARN is assumed for every call, with externalID when set
+	roleARN    string
+	externalID string
+
+	// glueCatalogIDs lists the Glue catalogs to discover; empty means the default catalog
+	glueCatalogIDs []string
+	// glueRoleARN is assumed for Glue calls, e.g. a delegated-admin role for shared catalogs
+	glueRoleARN string
+	// verify reads Glue tags back after writing them
+	verify bool
+	// ensureKeys are added with their default value only where the key is absent
+	ensureKeys map[string]string
+
+	// athenaSkipWorkgroups lists workgroups never tagged, in addition to primary
+	athenaSkipWorkgroups []string
+
+	// ecsTypes limits ECS tagging to lower-cased resource kinds; empty means all
+	ecsTypes map[string]bool
+
+	// glueResources limits Glue tagging to lower-cased sub-resource kinds; empty means all
+	glueResources map[string]bool
+
+	// eksFilter limits EKS tagging to clusters with a given status or version
+	eksFilter EKSFilter
+
+	// useTaggingAPI skips resources the Tagging API reports as already tagged
+	useTaggingAPI bool
+	taggedARNs    map[string]bool
+	taggedMu      sync.Mutex
+
+	// skipTagged reads existing tags and skips resources that carry every desired tag
+	skipTagged bool
+
+	// maxTagKeys overrides the per-service tag key limit when positive
+	maxTagKeys int
+
+	// concurrency caps in-flight API calls; adaptiveConcurrency tunes the cap to throttling
+	concurrency         int
+	adaptiveConcurrency bool
+	limiter             *concurrencyLimiter
+	// openSearchWorkerCount is how many OpenSearch domains are tagged at once
+	openSearchWorkerCount int
+
+	// ownedOnly skips resources owned by another account
+	ownedOnly bool
+	// logGroupPrefix and logGroupMinBytes narrow the CloudWatch log groups tagged
+	logGroupPrefix   string
+	logGroupMinBytes int64
+	// createdAfter skips resources created at or before it; zero disables the filter
+	createdAfter time.Time
+
+	// tagDefaults tags default resources such as the Athena primary workgroup
+	tagDefaults bool
+
+	// maxAPIErrors aborts the run once exceeded; zero disables the check
+	maxAPIErrors int
+	cancel       context.CancelFunc
+	aborted      int32
+
+	// retryAttempts bounds the tries of a throttled tag write; retryBaseDelay is the first backoff
+	retryAttempts  int
+	retryBaseDelay time.Duration
+}
+
+// Option configures optional AWSResourceTagger behaviour
+type Option func(*AWSResourceTagger)
+
+// WithMaxAPIErrors aborts the run once more than n non-throttling API errors occur
+func WithMaxAPIErrors(n int) Option {
+	return func(t *AWSResourceTagger) {
+		t.maxAPIErrors = n
+	}
+}
+
+// WithStrictValidation rejects tags containing characters AWS does not accept
+func WithStrictValidation(strict bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.strictValidation = strict
+	}
+}
+
+// WithSkipDefaults controls whether registered default resources are left untagged
+func WithSkipDefaults(skip bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.tagDefaults = !skip
+	}
+}
+
+// WithGlueCatalogIDs discovers Glue databases and connections in each listed catalog
+func WithGlueCatalogIDs(ids []string) Option {
+	return func(t *AWSResourceTagger) {
+		t.glueCatalogIDs = ids
+	}
+}
+
+// WithAthenaSkipWorkgroups leaves the named Athena workgroups untagged
+func WithAthenaSkipWorkgroups(names []string) Option {
+	return func(t *AWSResourceTagger) {
+		t.athenaSkipWorkgroups = names
+	}
+}
+
+// WithServiceTimeout cancels the API calls of any service tagger still running after d; zero disables it
+func WithServiceTimeout(d time.Duration) Option {
+	return func(t *AWSResourceTagger) {
+		t.serviceTimeout = d
+	}
+}
+
+// WithServiceDelay sets the pause after each service tagger; zero disables it
+func WithServiceDelay(d time.Duration) Option {
+	return func(t *AWSResourceTagger) {
+		t.serviceDelay = d
+	}
 }
 
+// WithRetryAttempts sets how many times a throttled tag write is tried; zero uses the default
+func WithRetryAttempts(n int) Option {
+	return func(t *AWSResourceTagger) {
+		t.retryAttempts = n
+	}
+}
+
+// apiThrottleSleepDuration is the default pause after each service tagger
 const apiThrottleSleepDuration = time.Second
 
+// throttlingErrorCodes are API error codes returned when a call is rate limited
+var throttlingErrorCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestLimitExceeded":                   true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"SlowDown":                               true,
+}
+
+// Throttled and transiently failing tag writes are retried with jittered exponential backoff
+const (
+	defaultRetryAttempts  = 5
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 20 * time.Second
+)
+
+// retryWithBackoff calls fn until it succeeds, fails with an error that is
+// neither throttling nor transient or runs out of attempts, returning the last
+// error. It is the only retry policy for the calls it wraps: skipSDKRetries
+// turns the SDK retryer off for them so attempts do not multiply.
+func (t *AWSResourceTagger) retryWithBackoff(fn func() error) error {
+	attempts := t.retryAttempts
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	delay := t.retryBaseDelay
+	if delay <= 0 {
+		delay = defaultRetryBaseDelay
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || !isRetryableError(err) || attempt == attempts {
+			return err
+		}
+		reason := "Transient error"
+		if isThrottlingError(err) {
+			t.metrics.RecordThrottle()
+			reason = "Throttled"
+		}
+		// Sleep between half and all of the current delay so retries spread out
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		log.Printf("%s (attempt %d/%d), retrying in %v: %v", reason, attempt, attempts, wait, err)
+		if !t.waitOrCancel(wait) {
+			return err
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// isRetryableError reports whether a call is worth repeating: throttling, or
+// an error the SDK's standard retryer treats as transient such as a 5xx response
+func isRetryableError(err error) bool {
+	return isThrottlingError(err) ||
+		retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err).Bool() ||
+		isV1RetryableError(err)
+}
+
+// retriedByTagger reports whether calls of an operation are wrapped in
+// retryWithBackoff: every tag write, plus the Glue tag reads
+func retriedByTagger(operation string) bool {
+	return isTagWriteOperation(operation) || operation == "GetTags"
+}
+
+// skipSDKRetries returns an SDK API option that makes the operations wrapped
+// in retryWithBackoff try once per call, leaving retries to the tagger
+func skipSDKRetries(stack *middleware.Stack) error {
+	if !retriedByTagger(stack.ID()) {
+		return nil
+	}
+	_, err := stack.Finalize.Swap("Retry", retry.NewAttemptMiddleware(aws.NopRetryer{}, smithyhttp.RequestCloner))
+	return err
+}
+
+// waitOrCancel pauses for d, returning false early if the run is cancelled
+func (t *AWSResourceTagger) waitOrCancel(d time.Duration) bool {
+	if t.ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-t.ctx.Done():
+		return false
+	}
+}
+
 // TagAllResources concurrently tags all supported resources
-func (t *AWSResourceTagger) TagAllResources() {
+func (t *AWSResourceTagger) TagAllResources() error {
 	log.Println("Starting MAP 2.0 resource tagging process...")
 
+	if err := t.validateTags(); err != nil {
+		return fmt.Errorf("invalid tags configuration: %w", err)
+	}
 	if err := t.validateSSOSession(); err != nil {
-		log.Fatalf("SSO session validation failed: %v", err)
-		return
+		return fmt.Errorf("SSO session validation failed: %w", err)
 	}
+	defer t.flush()
 
-	var wg sync.WaitGroup
-	resourceTaggers := map[string]func(){
-		"EC2":         t.tagEC2Resources,
-		"CloudWatch":  t.tagCloudWatchResources,
-		"Glue":        t.tagGlueResources,
-		"Athena":      t.tagAthenaResources,
-		"S3Buckets":   t.tagS3Buckets,
-		"OpenSearch":  t.tagOpenSearchResources,
-		"RDS":         t.tagRDSResources,
-		"ElastiCache": t.tagElastiCacheResources,
-		"ELB":         t.tagELBResources,
-		"VPC":         t.tagVPCResources,
-	}
-	errorsChannel := make(chan error, len(resourceTaggers))
+	resourceTaggers := t.filterResourceTaggers(t.resourceTaggers())
 
-	for key, tagger := range resourceTaggers {
-		wg.Add(1)
-		go t.executeWithThrottleConcurrent(tagger, &wg, errorsChannel, key)
+	if err := t.runAcrossRegions(resourceTaggers); err != nil {
+		return err
 	}
+	if err := t.checkResourcesFound(); err != nil {
+		return err
+	}
+	log.Println("Completed MAP 2.0 resource tagging process")
+	return nil
+}
 
-	wg.Wait()
+// runResourceTaggers runs every service tagger concurrently and reports whether the run was aborted
+func (t *AWSResourceTagger) runResourceTaggers(resourceTaggers map[string]func() error) error {
+	errorsChannel := make(chan error, len(resourceTaggers))
+	t.runBatch(resourceTaggers, errorsChannel)
 	close(errorsChannel)
+	var serviceErrors []error
 	for err := range errorsChannel {
 		if err != nil {
 			log.Printf("Error in tagging process: %v", err)
+			serviceErrors = append(serviceErrors, err)
 		}
 	}
-	log.Println("Completed MAP 2.0 resource tagging process")
+
+	if atomic.LoadInt32(&t.aborted) == 1 {
+		return fmt.Errorf("tagging aborted after %d API errors (--max-api-errors=%d)",
+			t.metrics.APIErrors(), t.maxAPIErrors)
+	}
+	if t.ctx != nil && t.ctx.Err() != nil {
+		return fmt.Errorf("tagging interrupted: %w", t.ctx.Err())
+	}
+	return errors.Join(serviceErrors...)
+}
+
+// stopped reports whether the run was aborted or interrupted
+func (t *AWSResourceTagger) stopped() bool {
+	return atomic.LoadInt32(&t.aborted) == 1 || (t.ctx != nil && t.ctx.Err() != nil)
+}
+
+// runBatch starts every tagger at once and waits for all of them to return.
+// With a service timeout the batch runs under a context with that deadline:
+// the taggers all start together, so it bounds each of them, and a service
+// past it has its API calls cancelled rather than left running in the
+// background while the run moves on, e.g. to the next region.
+func (t *AWSResourceTagger) runBatch(resourceTaggers map[string]func() error, errorsChannel chan<- error) {
+	if t.serviceTimeout > 0 {
+		parent := t.ctx
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, t.serviceTimeout)
+		t.ctx = ctx
+		defer func() {
+			cancel()
+			t.ctx = parent
+		}()
+	}
+
+	var wg sync.WaitGroup
+	for key, tagger := range resourceTaggers {
+		wg.Add(1)
+		go t.executeWithThrottleConcurrent(tagger, &wg, errorsChannel, key)
+	}
+	wg.Wait()
 }
 
 // executeWithThrottleConcurrent runs a function in a goroutine and then sleeps to prevent API throttling
-func (t *AWSResourceTagger) executeWithThrottleConcurrent(f func(), wg *sync.WaitGroup, errorsChannel chan<- error, resourceType string) {
+func (t *AWSResourceTagger) executeWithThrottleConcurrent(f func() error, wg *sync.WaitGroup, errorsChannel chan<- error, resourceType string) {
 	defer wg.Done()
 	log.Printf("Starting tagging for resource type: %s", resourceType)
-	f()
+	err := t.runWithServiceTimeout(t.ctx, f)
+	if errors.Is(err, errServiceTimeout) {
+		log.Printf("Stopped tagging for resource type %s: %v", resourceType, err)
+		errorsChannel <- fmt.Errorf("%s: %w", resourceType, err)
+		return
+	}
+	if err != nil {
+		errorsChannel <- fmt.Errorf("%s: %w", resourceType, err)
+	}
 	log.Printf("Completed tagging for resource type: %s", resourceType)
-	time.Sleep(apiThrottleSleepDuration)
+	t.pauseAfterService()
+}
+
+// errServiceTimeout marks a service tagger stopped by the service timeout
+var errServiceTimeout = errors.New("timed out")
+
+// runWithServiceTimeout runs f and reports errServiceTimeout when it returned
+// because ctx, the batch context built by runBatch, passed its deadline
+func (t *AWSResourceTagger) runWithServiceTimeout(ctx context.Context, f func() error) error {
+	err := f()
+	if t.serviceTimeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %v", errServiceTimeout, t.serviceTimeout)
+	}
+	return err
+}
+
+// pauseAfterService sleeps for the configured service delay to prevent API throttling
+func (t *AWSResourceTagger) pauseAfterService() {
+	if t.serviceDelay <= 0 {
+		return
+	}
+	sleep := t.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	sleep(t.serviceDelay)
 }
 
-// validateSSOSession validates the SSO session by making a simple AWS API call
+// validateSSOSession validates the SSO session by making a simple AWS API call.
+// Dry runs only check that credentials are present.
 func (t *AWSResourceTagger) validateSSOSession() error {
+	if t.dryRun {
+		t.checkCredentials()
+		return nil
+	}
 	stsClient := sts.NewFromConfig(t.cfg)
 	_, err := stsClient.GetCallerIdentity(t.ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
@@ -95,24 +459,24 @@ func getAccountID(ctx context.Context, cfg aws.Config) (string, error) {
 	return *result.Account, nil
 }
 
-// NewAWSResourceTagger creates a new tagger instance
-func NewAWSResourceTagger(ctx context.Context, profile, region string, tags map[string]string) (*AWSResourceTagger, error) {
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion(region),
-		config.WithSharedConfigProfile(profile),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+// ResolveAccountID loads the AWS config for profile and region and returns the
+// caller's account ID, confirming the credentials work
+func ResolveAccountID(ctx context.Context, profile, region string, opts ...Option) (string, error) {
+	t := &AWSResourceTagger{region: region}
+	for _, opt := range opts {
+		opt(t)
 	}
-
-	// Get AWS Account ID
-	accountID, err := getAccountID(ctx, cfg)
+	cfg, err := config.LoadDefaultConfig(ctx, t.configLoadOptions(profile)...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get AWS account ID: %v", err)
+		return "", fmt.Errorf("unable to load SDK config: %v", err)
 	}
-	log.Printf("Using AWS Account ID: %s", accountID)
+	t.cfg = cfg
+	t.assumeRole()
+	return getAccountID(ctx, t.cfg)
+}
 
+// NewAWSResourceTagger creates a new tagger instance
+func NewAWSResourceTagger(ctx context.Context, profile, region string, tags map[string]string, opts ...Option) (*AWSResourceTagger, error) {
 	// Convert tags to AWS format
 	awsTags := make([]types.Tag, 0, len(tags))
 	for k, v := range tags {
@@ -122,19 +486,98 @@ func NewAWSResourceTagger(ctx context.Context, profile, region string, tags map[
 		})
 	}
 
-	return &AWSResourceTagger{
-		ctx:       ctx,
-		cfg:       cfg,
-		tags:      tags,
-		awsTags:   awsTags,
-		accountID: accountID,
-		region:    region,
-	}, nil
+	ctx, cancel := context.WithCancel(ctx)
+	t := &AWSResourceTagger{
+		ctx:          ctx,
+		tags:         tags,
+		awsTags:      awsTags,
+		region:       region,
+		results:      NewResultCollector(),
+		metrics:      NewMetricsCollector(),
+		cancel:       cancel,
+		serviceDelay: apiThrottleSleepDuration,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	// Load AWS configuration once the options that shape it are applied
+	cfg, err := config.LoadDefaultConfig(ctx, t.configLoadOptions(profile)...)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+	}
+	t.cfg = cfg
+
+	t.assumeRole()
+
+	// Get AWS Account ID; a dry run carries on offline without one
+	t.accountID, err = getAccountID(ctx, t.cfg)
+	if err != nil {
+		if !t.dryRun {
+			cancel()
+			return nil, fmt.Errorf("unable to get AWS account ID: %v", err)
+		}
+		log.Printf("Warning: unable to get AWS account ID, ARNs will use %q: %v", dryRunAccountID, err)
+		t.accountID = dryRunAccountID
+	}
+	log.Printf("Using AWS Account ID: %s", t.accountID)
+
+	if t.allRegions {
+		regions, err := t.enabledRegions(ec2.NewFromConfig(t.cfg))
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("unable to list enabled regions: %w", err)
+		}
+		log.Printf("Tagging %d enabled regions: %s", len(regions), strings.Join(regions, ","))
+		WithRegions(regions)(t)
+	}
+
+	if t.deterministic {
+		sort.Slice(t.awsTags, func(i, j int) bool {
+			return aws.ToString(t.awsTags[i].Key) < aws.ToString(t.awsTags[j].Key)
+		})
+	}
+	if t.checkpointFile != "" {
+		if t.checkpoint, err = openCheckpoint(t.checkpointFile, t.resume); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	t.cfg.APIOptions = append(t.cfg.APIOptions, countTagWrites(t.metrics), skipSDKRetries)
+	if t.concurrency > 0 || t.adaptiveConcurrency {
+		ceiling := t.concurrency
+		if ceiling <= 0 {
+			ceiling = defaultAutoConcurrency
+		}
+		t.limiter = newConcurrencyLimiter(ceiling, t.adaptiveConcurrency)
+		t.cfg.APIOptions = append(t.cfg.APIOptions, limitConcurrency(t.limiter))
+	}
+	return t, nil
 }
 
 // handleError handles AWS API errors
 func (t *AWSResourceTagger) handleError(err error, resourceID, service string) {
+	if t.ctx != nil && t.ctx.Err() != nil {
+		// The run was cancelled, so follow-on failures are not worth counting
+		log.Printf("Skipping %s resource %s: %v", service, resourceID, t.ctx.Err())
+		return
+	}
+
+	if isServiceUnavailableInRegion(err) {
+		// Not a tagging failure: the service simply has no endpoint here
+		t.metrics.RecordUnavailable()
+		log.Printf("%s not available in %s, skipping", service, t.region)
+		return
+	}
+
 	var ae smithy.APIError
+	if isThrottlingError(err) {
+		t.metrics.RecordThrottle()
+	} else {
+		t.recordAPIError()
+	}
+
 	if errors.As(err, &ae) {
 		switch ae.ErrorCode() {
 		case "AccessDenied":
@@ -148,3 +591,30 @@ func (t *AWSResourceTagger) handleError(err error, resourceID, service string) {
 		log.Printf("Error tagging %s resource %s: %v", service, resourceID, err)
 	}
 }
+
+// isServiceUnavailableInRegion reports whether err means the service has no
+// endpoint in the configured region rather than a failed API call
+func isServiceUnavailableInRegion(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "failed to resolve service endpoint") ||
+		strings.Contains(msg, "ResolveEndpointV2")
+}
+
+// recordAPIError counts a non-throttling failure and cancels the run once
+// the --max-api-errors threshold is exceeded
+func (t *AWSResourceTagger) recordAPIError() {
+	total := t.metrics.RecordAPIError()
+	if t.maxAPIErrors <= 0 || total <= int64(t.maxAPIErrors) {
+		return
+	}
+	if atomic.CompareAndSwapInt32(&t.aborted, 0, 1) {
+		log.Printf("Aborting: %d API errors exceeded --max-api-errors=%d", total, t.maxAPIErrors)
+		if t.cancel != nil {
+			t.cancel()
+		}
+	}
+}
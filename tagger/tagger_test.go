@@ -1,13 +1,20 @@
 package tagger
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"net"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -205,3 +212,336 @@ func TestTagAllResources_ConcurrentExecution(t *testing.T) {
 
 	mockTagger.stsClient.AssertExpectations(t)
 }
+
+func TestRunResourceTaggers_MaxAPIErrorsAbortsRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tagger := &AWSResourceTagger{
+		ctx:          ctx,
+		cancel:       cancel,
+		metrics:      NewMetricsCollector(),
+		maxAPIErrors: 3,
+	}
+
+	var attempts int
+	resourceTaggers := map[string]func() error{
+		"EC2": func() error {
+			// Simulate a service failing on every resource until the run is cancelled
+			for i := 0; i < 100; i++ {
+				if tagger.ctx.Err() != nil {
+					return nil
+				}
+				attempts++
+				tagger.handleError(&mockAPIError{code: "AccessDenied", message: "denied"}, "i-123", "EC2")
+			}
+			return nil
+		},
+	}
+
+	err := tagger.runResourceTaggers(resourceTaggers)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-api-errors=3")
+	assert.Equal(t, 4, attempts, "run should stop right after the threshold is exceeded")
+	assert.Equal(t, int64(4), tagger.metrics.APIErrors())
+	assert.Error(t, ctx.Err(), "context should be cancelled")
+}
+
+func TestHandleError_ThrottlingDoesNotCountTowardsMaxAPIErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tagger := &AWSResourceTagger{
+		ctx:          ctx,
+		cancel:       cancel,
+		metrics:      NewMetricsCollector(),
+		maxAPIErrors: 1,
+	}
+
+	for i := 0; i < 5; i++ {
+		tagger.handleError(&mockAPIError{code: "ThrottlingException", message: "slow down"}, "i-123", "EC2")
+	}
+
+	assert.Equal(t, int64(0), tagger.metrics.APIErrors())
+	assert.Equal(t, int64(5), tagger.metrics.Throttles())
+	assert.NoError(t, ctx.Err())
+}
+
+func TestExecuteWithThrottleConcurrent_ServiceDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		delay          time.Duration
+		expectedSleeps []time.Duration
+	}{
+		{
+			name:           "Zero delay skips the sleep",
+			delay:          0,
+			expectedSleeps: nil,
+		},
+		{
+			name:           "Configured delay is respected",
+			delay:          5 * time.Second,
+			expectedSleeps: []time.Duration{5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sleeps []time.Duration
+			tagger := &AWSResourceTagger{
+				sleep: func(d time.Duration) { sleeps = append(sleeps, d) },
+			}
+			WithServiceDelay(tt.delay)(tagger)
+
+			var wg sync.WaitGroup
+			errorsChannel := make(chan error, 1)
+			wg.Add(1)
+			tagger.executeWithThrottleConcurrent(func() error { return nil }, &wg, errorsChannel, "EC2")
+			wg.Wait()
+
+			assert.Equal(t, tt.expectedSleeps, sleeps)
+		})
+	}
+}
+
+func TestHandleError_ServiceUnavailableInRegion(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "Endpoint resolution error",
+			err:  fmt.Errorf("operation error EKS: ListClusters, failed to resolve service endpoint, %w", errors.New("endpoint rule error")),
+		},
+		{
+			name: "Endpoint host does not exist",
+			err: fmt.Errorf("operation error EKS: ListClusters, %w", &net.DNSError{
+				Err: "no such host", Name: "eks.ap-east-9.amazonaws.com", IsNotFound: true,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logBuffer bytes.Buffer
+			log.SetOutput(&logBuffer)
+			defer log.SetOutput(os.Stderr)
+
+			tagger := &AWSResourceTagger{
+				ctx:          context.Background(),
+				region:       "ap-east-9",
+				metrics:      NewMetricsCollector(),
+				maxAPIErrors: 1,
+			}
+			tagger.handleError(tt.err, "all", "EKS Clusters")
+
+			assert.Equal(t, int64(1), tagger.metrics.Unavailable())
+			assert.Equal(t, int64(0), tagger.metrics.APIErrors(), "unavailable services are not tagging failures")
+			assert.Contains(t, logBuffer.String(), "EKS Clusters not available in ap-east-9, skipping")
+			assert.NotContains(t, logBuffer.String(), "Error tagging")
+		})
+	}
+
+	// Ordinary failures are still counted as API errors
+	tagger := &AWSResourceTagger{ctx: context.Background(), metrics: NewMetricsCollector()}
+	tagger.handleError(errors.New("connection reset"), "i-123", "EC2")
+	assert.Equal(t, int64(0), tagger.metrics.Unavailable())
+	assert.Equal(t, int64(1), tagger.metrics.APIErrors())
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	throttled := &mockAPIError{code: "ThrottlingException", message: "Rate exceeded"}
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "Succeeds after two throttles",
+			errs:          []error{throttled, throttled, nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "Other errors are not retried",
+			errs:          []error{&mockAPIError{code: "AccessDenied", message: "denied"}},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:          "Gives up after the last attempt",
+			errs:          []error{throttled, throttled, throttled, throttled},
+			expectedCalls: 3,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagger := &AWSResourceTagger{
+				ctx:            context.Background(),
+				metrics:        NewMetricsCollector(),
+				retryAttempts:  3,
+				retryBaseDelay: time.Millisecond,
+			}
+
+			calls := 0
+			err := tagger.retryWithBackoff(func() error {
+				calls++
+				return tt.errs[calls-1]
+			})
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRetryWithBackoffStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tagger := &AWSResourceTagger{ctx: ctx, retryBaseDelay: time.Hour}
+
+	calls := 0
+	err := tagger.retryWithBackoff(func() error {
+		calls++
+		return &mockAPIError{code: "RequestLimitExceeded", message: "Request limit exceeded"}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestThrottledCreateTagsIsRetried(t *testing.T) {
+	mockClient := new(MockEC2Client)
+	mockClient.On("DescribeInstances", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{InstanceId: aws.String("i-busy")}}}},
+		}, nil).Once()
+	mockClient.On("DescribeVolumes", mock.Anything, mock.Anything).Return(&ec2.DescribeVolumesOutput{}, nil).Once()
+	expectNoSnapshotsOrImages(mockClient)
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).
+		Return(nil, &mockAPIError{code: "RequestLimitExceeded", message: "Request limit exceeded"}).Twice()
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:            context.Background(),
+		awsTags:        []ec2types.Tag{{Key: aws.String("Environment"), Value: aws.String("Test")}},
+		metrics:        NewMetricsCollector(),
+		retryBaseDelay: time.Millisecond,
+	}
+	tagger.tagEC2ResourcesWithClient(mockClient)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "CreateTags", 3)
+	assert.Equal(t, int64(2), tagger.metrics.Throttles())
+	assert.Equal(t, int64(0), tagger.metrics.APIErrors())
+}
+
+func TestThrottledGlueTagResourceIsRetried(t *testing.T) {
+	mockClient := new(MockGlueClient)
+	tagger := createTestTagger()
+	tagger.metrics = NewMetricsCollector()
+	tagger.retryBaseDelay = time.Millisecond
+	metrics := &GlueMetrics{}
+
+	mockClient.On("ListWorkflows", mock.Anything, mock.Anything).
+		Return(&glue.ListWorkflowsOutput{Workflows: []string{"nightly-etl"}}, nil)
+	mockClient.On("TagResource", mock.Anything, mock.Anything).
+		Return(nil, &mockAPIError{code: "ThrottlingException", message: "Rate exceeded"}).Twice()
+	mockClient.On("TagResource", mock.Anything, mock.Anything).Return(&glue.TagResourceOutput{}, nil).Once()
+
+	tagger.tagGlueWorkflows(mockClient, metrics)
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TagResource", 3)
+	assert.Equal(t, int64(2), tagger.metrics.Throttles())
+	verifyResourceMetrics(t, metrics, &GlueMetrics{WorkflowsFound: 1, WorkflowsTagged: 1})
+}
+
+func TestServiceTimeoutStopsSlowService(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	var mu sync.Mutex
+	finished := map[string]bool{}
+	finish := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		finished[name] = true
+	}
+
+	tagger := &AWSResourceTagger{ctx: context.Background()}
+	WithServiceTimeout(20 * time.Millisecond)(tagger)
+
+	start := time.Now()
+	err := tagger.runResourceTaggers(map[string]func() error{
+		"Stuck": func() error {
+			// A slow API call returns once its context is done
+			<-tagger.ctx.Done()
+			finish("Stuck")
+			return nil
+		},
+		"EC2": func() error { finish("EC2"); return nil },
+		"S3":  func() error { finish("S3"); return nil },
+	})
+
+	assert.ErrorIs(t, err, errServiceTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+	mu.Lock()
+	assert.Equal(t, map[string]bool{"Stuck": true, "EC2": true, "S3": true}, finished, "the timed out service returns before the run moves on")
+	mu.Unlock()
+	assert.Contains(t, logBuffer.String(), "Stopped tagging for resource type Stuck: timed out after 20ms")
+	assert.NoError(t, tagger.ctx.Err(), "the run context is restored after the batch")
+}
+
+func TestServiceTimeoutThenRegionSwitch(t *testing.T) {
+	tagger := &AWSResourceTagger{ctx: context.Background(), region: "us-east-1"}
+	WithRegions([]string{"eu-west-1", "ap-southeast-2"})(tagger)
+	WithServiceTimeout(20 * time.Millisecond)(tagger)
+
+	var mu sync.Mutex
+	seen := map[string][]string{}
+	err := tagger.runAcrossRegions(map[string]func() error{
+		"Stuck": func() error {
+			start := tagger.region
+			<-tagger.ctx.Done()
+			// Work done after the deadline must still see this service's region
+			mu.Lock()
+			defer mu.Unlock()
+			seen[start] = append(seen[start], tagger.region)
+			return nil
+		},
+	})
+
+	assert.ErrorIs(t, err, errServiceTimeout)
+	assert.Equal(t, map[string][]string{
+		"eu-west-1":      {"eu-west-1"},
+		"ap-southeast-2": {"ap-southeast-2"},
+	}, seen)
+	assert.Equal(t, "us-east-1", tagger.region)
+}
+
+func TestTagAllResourcesRejectsInvalidTags(t *testing.T) {
+	var logBuffer bytes.Buffer
+	log.SetOutput(&logBuffer)
+	defer log.SetOutput(os.Stderr)
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"aws:createdBy": "me"},
+		metrics: NewMetricsCollector(),
+	}
+
+	err := tagger.TagAllResources()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tag key cannot start with 'aws:'")
+	assert.NotContains(t, logBuffer.String(), "Starting tagging for resource type")
+}
@@ -0,0 +1,106 @@
+package tagger
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+// WithTaggingAPIDiscovery looks up already-tagged resources through the Resource
+// Groups Tagging API so they can be skipped without a tag write
+func WithTaggingAPIDiscovery(enabled bool) Option {
+	return func(t *AWSResourceTagger) {
+		t.useTaggingAPI = enabled
+	}
+}
+
+// tagFilters builds one filter per desired tag; the API ANDs them, so only
+// resources carrying every key with the desired value match
+func (t *AWSResourceTagger) tagFilters() []rgtypes.TagFilter {
+	keys := sortedTagKeys(t.tags)
+	filters := make([]rgtypes.TagFilter, 0, len(keys))
+	for _, k := range keys {
+		filters = append(filters, rgtypes.TagFilter{
+			Key:    aws.String(k),
+			Values: []string{t.tags[k]},
+		})
+	}
+	return filters
+}
+
+// loadTaggedARNs records the resources of the given type filters that already
+// carry all desired tags. The Tagging API never returns resources that have
+// not been tagged before, so it only narrows the set of writes and the
+// service's own list calls remain the source of discovered resources.
+func (t *AWSResourceTagger) loadTaggedARNs(client ResourceGroupsTaggingAPI, typeFilters []string) error {
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: typeFilters,
+		TagFilters:          t.tagFilters(),
+	}
+
+	tagged := make(map[string]bool)
+	for {
+		output, err := client.GetResources(t.ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to list tagged resources: %w", err)
+		}
+		for _, mapping := range output.ResourceTagMappingList {
+			tagged[aws.ToString(mapping.ResourceARN)] = true
+		}
+		if aws.ToString(output.PaginationToken) == "" {
+			break
+		}
+		input.PaginationToken = output.PaginationToken
+	}
+
+	t.taggedMu.Lock()
+	defer t.taggedMu.Unlock()
+	if t.taggedARNs == nil {
+		t.taggedARNs = make(map[string]bool)
+	}
+	for arn := range tagged {
+		t.taggedARNs[arn] = true
+	}
+	log.Printf("Found %d %v resources that already carry all tags", len(tagged), typeFilters)
+	return nil
+}
+
+// markTagged records arn as already carrying all desired tags
+func (t *AWSResourceTagger) markTagged(arn string) {
+	t.taggedMu.Lock()
+	defer t.taggedMu.Unlock()
+	if t.taggedARNs == nil {
+		t.taggedARNs = make(map[string]bool)
+	}
+	t.taggedARNs[arn] = true
+}
+
+// hasAllTags reports whether arn was found by loadTaggedARNs or marked by markTagged
+func (t *AWSResourceTagger) hasAllTags(arn string) bool {
+	t.taggedMu.Lock()
+	defer t.taggedMu.Unlock()
+	return t.taggedARNs[arn]
+}
+
+// alreadyTagged reports whether arn was found by loadTaggedARNs and records it as skipped
+func (t *AWSResourceTagger) alreadyTagged(arn string) bool {
+	if !t.hasAllTags(arn) {
+		return false
+	}
+
+	service, resourceType := arnServiceAndType(arn)
+	log.Printf("Skipping %s: already has all tags", arn)
+	t.metrics.RecordAlreadyTagged()
+	t.recordResult(TagResult{
+		Service:      service,
+		ResourceType: resourceType,
+		ResourceID:   arn,
+		ARN:          arn,
+		Status:       StatusSkipped,
+		Error:        "already has all tags",
+	})
+	return true
+}
@@ -0,0 +1,74 @@
+package tagger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/glue"
+	gluetypes "github.com/aws/aws-sdk-go-v2/service/glue/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoadGlueTaggedARNsAppliesTagFilters(t *testing.T) {
+	tagger := createTestTagger()
+	taggedARN := "arn:aws:glue:us-west-2:123456789012:database/tagged"
+
+	rgtClient := new(MockResourceGroupsTaggingClient)
+	rgtClient.On("GetResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return assert.ObjectsAreEqual([]rgtypes.TagFilter{
+			{Key: aws.String("Environment"), Values: []string{"Test"}},
+			{Key: aws.String("Project"), Values: []string{"UnitTest"}},
+		}, input.TagFilters) &&
+			assert.ObjectsAreEqual([]string{"glue:database", "glue:connection", "glue:crawler", "glue:job", "glue:trigger", "glue:usageProfile", "glue:workflow"}, input.ResourceTypeFilters)
+	})).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []rgtypes.ResourceTagMapping{{ResourceARN: aws.String(taggedARN)}},
+	}, nil)
+
+	tagger.loadGlueTaggedARNs(rgtClient)
+	rgtClient.AssertExpectations(t)
+
+	// Only the untagged database is written
+	glueClient := new(MockGlueClient)
+	glueClient.On("GetDatabases", mock.Anything, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []gluetypes.Database{{Name: aws.String("tagged")}, {Name: aws.String("untagged")}},
+	}, nil)
+	glueClient.On("TagResource", mock.Anything, &glue.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:glue:us-west-2:123456789012:database/untagged"),
+		TagsToAdd:   tagger.convertToGlueTags(),
+	}).Return(&glue.TagResourceOutput{}, nil).Once()
+
+	tagger.tagGlueDatabases(glueClient, &GlueMetrics{})
+
+	glueClient.AssertExpectations(t)
+	glueClient.AssertNumberOfCalls(t, "TagResource", 1)
+}
+
+func TestLoadTaggedARNsPaginationAndError(t *testing.T) {
+	tagger := createTestTagger()
+
+	client := new(MockResourceGroupsTaggingClient)
+	client.On("GetResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return input.PaginationToken == nil
+	})).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []rgtypes.ResourceTagMapping{{ResourceARN: aws.String("arn:aws:glue:us-west-2:123456789012:job/a")}},
+		PaginationToken:        aws.String("next"),
+	}, nil).Once()
+	client.On("GetResources", mock.Anything, mock.MatchedBy(func(input *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return aws.ToString(input.PaginationToken) == "next"
+	})).Return(&resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []rgtypes.ResourceTagMapping{{ResourceARN: aws.String("arn:aws:glue:us-west-2:123456789012:job/b")}},
+	}, nil).Once()
+
+	assert.NoError(t, tagger.loadTaggedARNs(client, []string{"glue:job"}))
+	assert.True(t, tagger.alreadyTagged("arn:aws:glue:us-west-2:123456789012:job/a"))
+	assert.True(t, tagger.alreadyTagged("arn:aws:glue:us-west-2:123456789012:job/b"))
+	assert.False(t, tagger.alreadyTagged("arn:aws:glue:us-west-2:123456789012:job/c"))
+
+	failing := new(MockResourceGroupsTaggingClient)
+	failing.On("GetResources", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDenied"))
+	assert.Error(t, createTestTagger().loadTaggedARNs(failing, []string{"glue:job"}))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -16,6 +17,13 @@ type VPCEC2API interface {
 	DescribeTransitGateways(ctx context.Context, params *ec2.DescribeTransitGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewaysOutput, error)
 	DescribeTransitGatewayAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error)
 	DescribeTransitGatewayPeeringAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayPeeringAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error)
+	DescribeTransitGatewayConnectPeers(ctx context.Context, params *ec2.DescribeTransitGatewayConnectPeersInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayConnectPeersOutput, error)
+	DescribeVpcPeeringConnections(ctx context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
+	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeNatGateways(ctx context.Context, params *ec2.DescribeNatGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNatGatewaysOutput, error)
+	DescribeInternetGateways(ctx context.Context, params *ec2.DescribeInternetGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInternetGatewaysOutput, error)
 	CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
 }
 
@@ -23,14 +31,21 @@ type VPCEC2API interface {
 type VPCLatticeAPI interface {
 	ListServiceNetworks(ctx context.Context, params *vpclattice.ListServiceNetworksInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListServiceNetworksOutput, error)
 	ListServices(ctx context.Context, params *vpclattice.ListServicesInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListServicesOutput, error)
+	ListTargetGroups(ctx context.Context, params *vpclattice.ListTargetGroupsInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListTargetGroupsOutput, error)
+	ListListeners(ctx context.Context, params *vpclattice.ListListenersInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListListenersOutput, error)
 	TagResource(ctx context.Context, params *vpclattice.TagResourceInput, optFns ...func(*vpclattice.Options)) (*vpclattice.TagResourceOutput, error)
 }
 
+func init() {
+	registerService("VPC", (*AWSResourceTagger).tagVPCResources)
+}
+
 // tagVPCResources is the main entry point that creates and uses the clients
-func (t *AWSResourceTagger) tagVPCResources() {
+func (t *AWSResourceTagger) tagVPCResources() error {
 	ec2Client := ec2.NewFromConfig(t.cfg)
 	latticeClient := vpclattice.NewFromConfig(t.cfg)
 	t.tagVPCResourcesWithClients(ec2Client, latticeClient)
+	return nil
 }
 
 // tagVPCResourcesWithClients handles the actual tagging logic with provided clients
@@ -41,6 +56,16 @@ func (t *AWSResourceTagger) tagVPCResourcesWithClients(ec2Client VPCEC2API, latt
 	// Tag Transit Gateway and its attachments
 	t.tagTransitGatewayResourcesWithClient(ec2Client)
 
+	// Tag VPC peering connections
+	t.tagVPCPeeringConnectionsWithClient(ec2Client)
+
+	// Tag core networking resources
+	t.tagSubnetsWithClient(ec2Client)
+	t.tagSecurityGroupsWithClient(ec2Client)
+	t.tagRouteTablesWithClient(ec2Client)
+	t.tagInternetGatewaysWithClient(ec2Client)
+	t.tagNATGatewaysWithClient(ec2Client)
+
 	// Tag VPC Lattice resources
 	t.tagVPCLatticeResourcesWithClient(latticeClient)
 
@@ -58,16 +83,11 @@ func (t *AWSResourceTagger) tagTransitGatewayResourcesWithClient(client VPCEC2AP
 	}
 
 	for _, tgw := range tgws.TransitGateways {
-		// Tag the Transit Gateway itself
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{aws.ToString(tgw.TransitGatewayId)},
-			Tags:      t.convertToEC2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(tgw.TransitGatewayId), "Transit Gateway")
+		// Tag the Transit Gateway itself; attachments of a shared one are still ours
+		target := TagResult{Service: "VPC", ResourceType: "transit-gateway", ResourceID: aws.ToString(tgw.TransitGatewayId)}
+		if !t.skipNotOwned(target, tgw.OwnerId) && t.tagEC2NetworkResource(client, target.ResourceID, target.ResourceType) != nil {
 			continue
 		}
-		log.Printf("Successfully tagged Transit Gateway: %s", aws.ToString(tgw.TransitGatewayId))
 
 		// Tag VPN attachments
 		t.tagTransitGatewayVPNAttachments(client, aws.ToString(tgw.TransitGatewayId))
@@ -80,9 +100,186 @@ func (t *AWSResourceTagger) tagTransitGatewayResourcesWithClient(client VPCEC2AP
 
 		// Tag Direct Connect attachments
 		t.tagTransitGatewayDirectConnectAttachments(client, aws.ToString(tgw.TransitGatewayId))
+
+		// Tag Connect attachments and their Connect peers
+		t.tagTransitGatewayConnectAttachments(client, aws.ToString(tgw.TransitGatewayId))
+	}
+}
+
+// tagVPCPeeringConnectionsWithClient tags active and pending-acceptance VPC peering connections
+func (t *AWSResourceTagger) tagVPCPeeringConnectionsWithClient(client VPCEC2API) {
+	log.Println("Tagging VPC peering connections...")
+
+	input := &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []types.Filter{
+			{
+				Name: aws.String("status-code"),
+				Values: []string{
+					string(types.VpcPeeringConnectionStateReasonCodeActive),
+					string(types.VpcPeeringConnectionStateReasonCodePendingAcceptance),
+				},
+			},
+		},
+	}
+	for {
+		connections, err := client.DescribeVpcPeeringConnections(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "VPC Peering Connections")
+			return
+		}
+
+		for _, connection := range connections.VpcPeeringConnections {
+			t.tagEC2NetworkResource(client, aws.ToString(connection.VpcPeeringConnectionId), "peering-connection")
+		}
+
+		if connections.NextToken == nil {
+			break
+		}
+		input.NextToken = connections.NextToken
 	}
 }
 
+// tagSubnetsWithClient tags every subnet
+func (t *AWSResourceTagger) tagSubnetsWithClient(client VPCEC2API) {
+	log.Println("Tagging subnets...")
+
+	input := &ec2.DescribeSubnetsInput{}
+	for {
+		output, err := client.DescribeSubnets(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Subnets")
+			return
+		}
+
+		for _, subnet := range output.Subnets {
+			t.tagEC2NetworkResource(client, aws.ToString(subnet.SubnetId), "subnet")
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagSecurityGroupsWithClient tags security groups other than registered defaults
+func (t *AWSResourceTagger) tagSecurityGroupsWithClient(client VPCEC2API) {
+	log.Println("Tagging security groups...")
+
+	input := &ec2.DescribeSecurityGroupsInput{}
+	for {
+		output, err := client.DescribeSecurityGroups(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Security Groups")
+			return
+		}
+
+		for _, group := range output.SecurityGroups {
+			target := TagResult{Service: "VPC", ResourceType: "security-group", ResourceID: aws.ToString(group.GroupId)}
+			if t.skipDefaultResource(target, "ec2", "security-group", aws.ToString(group.GroupName)) {
+				continue
+			}
+			t.tagEC2NetworkResource(client, aws.ToString(group.GroupId), "security-group")
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagRouteTablesWithClient tags every route table
+func (t *AWSResourceTagger) tagRouteTablesWithClient(client VPCEC2API) {
+	log.Println("Tagging route tables...")
+
+	input := &ec2.DescribeRouteTablesInput{}
+	for {
+		output, err := client.DescribeRouteTables(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Route Tables")
+			return
+		}
+
+		for _, table := range output.RouteTables {
+			t.tagEC2NetworkResource(client, aws.ToString(table.RouteTableId), "route-table")
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagInternetGatewaysWithClient tags every internet gateway
+func (t *AWSResourceTagger) tagInternetGatewaysWithClient(client VPCEC2API) {
+	log.Println("Tagging internet gateways...")
+
+	input := &ec2.DescribeInternetGatewaysInput{}
+	for {
+		output, err := client.DescribeInternetGateways(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "Internet Gateways")
+			return
+		}
+
+		for _, gateway := range output.InternetGateways {
+			t.tagEC2NetworkResource(client, aws.ToString(gateway.InternetGatewayId), "internet-gateway")
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagNATGatewaysWithClient tags pending and available NAT gateways
+func (t *AWSResourceTagger) tagNATGatewaysWithClient(client VPCEC2API) {
+	log.Println("Tagging NAT gateways...")
+
+	// Deleted NAT gateways stay visible for a while but cannot be tagged
+	input := &ec2.DescribeNatGatewaysInput{
+		Filter: []types.Filter{
+			{
+				Name: aws.String("state"),
+				Values: []string{
+					string(types.NatGatewayStatePending),
+					string(types.NatGatewayStateAvailable),
+				},
+			},
+		},
+	}
+	for {
+		output, err := client.DescribeNatGateways(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "NAT Gateways")
+			return
+		}
+
+		for _, gateway := range output.NatGateways {
+			t.tagEC2NetworkResource(client, aws.ToString(gateway.NatGatewayId), "nat-gateway")
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// tagEC2NetworkResource tags a single networking resource by ID and records the outcome
+func (t *AWSResourceTagger) tagEC2NetworkResource(client VPCEC2API, resourceID, resourceType string) error {
+	return t.applyAndRecord(TagResult{Service: "VPC", ResourceType: resourceType, ResourceID: resourceID}, func() error {
+		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
+			Resources: []string{resourceID},
+			Tags:      t.convertToEC2Tags(),
+		})
+		return err
+	})
+}
+
 // tagVPCLatticeResourcesWithClient tags VPC Lattice resources with provided client
 func (t *AWSResourceTagger) tagVPCLatticeResourcesWithClient(client VPCLatticeAPI) {
 	log.Println("Tagging VPC Lattice resources...")
@@ -95,15 +292,7 @@ func (t *AWSResourceTagger) tagVPCLatticeResourcesWithClient(client VPCLatticeAP
 	}
 
 	for _, network := range networks.Items {
-		_, err := client.TagResource(t.ctx, &vpclattice.TagResourceInput{
-			ResourceArn: network.Arn,
-			Tags:        t.tags,
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(network.Name), "VPC Lattice Service Network")
-			continue
-		}
-		log.Printf("Successfully tagged VPC Lattice service network: %s", aws.ToString(network.Name))
+		t.tagVPCLatticeResource(client, network.Arn, "service-network")
 	}
 
 	// Tag Services
@@ -114,18 +303,72 @@ func (t *AWSResourceTagger) tagVPCLatticeResourcesWithClient(client VPCLatticeAP
 	}
 
 	for _, service := range services.Items {
-		_, err := client.TagResource(t.ctx, &vpclattice.TagResourceInput{
-			ResourceArn: service.Arn,
-			Tags:        t.tags,
-		})
+		t.tagVPCLatticeResource(client, service.Arn, "service")
+		t.tagVPCLatticeListeners(client, aws.ToString(service.Arn))
+	}
+
+	t.tagVPCLatticeTargetGroups(client)
+}
+
+// tagVPCLatticeListeners tags the listeners of one VPC Lattice service
+func (t *AWSResourceTagger) tagVPCLatticeListeners(client VPCLatticeAPI, serviceArn string) {
+	input := &vpclattice.ListListenersInput{ServiceIdentifier: aws.String(serviceArn)}
+	for {
+		listeners, err := client.ListListeners(t.ctx, input)
 		if err != nil {
-			t.handleError(err, aws.ToString(service.Name), "VPC Lattice Service")
-			continue
+			t.handleError(err, serviceArn, "VPC Lattice Listeners")
+			return
+		}
+
+		for _, listener := range listeners.Items {
+			t.tagVPCLatticeResource(client, listener.Arn, "listener")
+		}
+
+		if listeners.NextToken == nil {
+			return
+		}
+		input.NextToken = listeners.NextToken
+	}
+}
+
+// tagVPCLatticeTargetGroups tags every VPC Lattice target group
+func (t *AWSResourceTagger) tagVPCLatticeTargetGroups(client VPCLatticeAPI) {
+	input := &vpclattice.ListTargetGroupsInput{}
+	for {
+		targetGroups, err := client.ListTargetGroups(t.ctx, input)
+		if err != nil {
+			t.handleError(err, "all", "VPC Lattice Target Groups")
+			return
+		}
+
+		for _, targetGroup := range targetGroups.Items {
+			t.tagVPCLatticeResource(client, targetGroup.Arn, "target-group")
+		}
+
+		if targetGroups.NextToken == nil {
+			return
 		}
-		log.Printf("Successfully tagged VPC Lattice service: %s", aws.ToString(service.Name))
+		input.NextToken = targetGroups.NextToken
 	}
 }
 
+// tagVPCLatticeResource tags a single VPC Lattice resource by ARN and records the outcome
+func (t *AWSResourceTagger) tagVPCLatticeResource(client VPCLatticeAPI, arn *string, resourceType string) {
+	resourceARN := aws.ToString(arn)
+	t.applyAndRecord(TagResult{
+		Service:      "VPC Lattice",
+		ResourceType: resourceType,
+		ResourceID:   resourceARN[strings.LastIndex(resourceARN, "/")+1:],
+		ARN:          resourceARN,
+	}, func() error {
+		_, err := client.TagResource(t.ctx, &vpclattice.TagResourceInput{
+			ResourceArn: arn,
+			Tags:        t.tags,
+		})
+		return err
+	})
+}
+
 // tagTransitGatewayVPNAttachments tags Transit Gateway VPN attachments
 func (t *AWSResourceTagger) tagTransitGatewayVPNAttachments(client VPCEC2API, tgwID string) {
 	attachments, err := client.DescribeTransitGatewayAttachments(t.ctx, &ec2.DescribeTransitGatewayAttachmentsInput{
@@ -146,15 +389,7 @@ func (t *AWSResourceTagger) tagTransitGatewayVPNAttachments(client VPCEC2API, tg
 	}
 
 	for _, attachment := range attachments.TransitGatewayAttachments {
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{aws.ToString(attachment.TransitGatewayAttachmentId)},
-			Tags:      t.convertToEC2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(attachment.TransitGatewayAttachmentId), "Transit Gateway VPN Attachment")
-			continue
-		}
-		log.Printf("Successfully tagged Transit Gateway VPN attachment: %s", aws.ToString(attachment.TransitGatewayAttachmentId))
+		t.tagEC2NetworkResource(client, aws.ToString(attachment.TransitGatewayAttachmentId), "transit-gateway-vpn-attachment")
 	}
 }
 
@@ -178,15 +413,7 @@ func (t *AWSResourceTagger) tagTransitGatewayVPCAttachments(client VPCEC2API, tg
 	}
 
 	for _, attachment := range attachments.TransitGatewayAttachments {
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{aws.ToString(attachment.TransitGatewayAttachmentId)},
-			Tags:      t.convertToEC2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(attachment.TransitGatewayAttachmentId), "Transit Gateway VPC Attachment")
-			continue
-		}
-		log.Printf("Successfully tagged Transit Gateway VPC attachment: %s", aws.ToString(attachment.TransitGatewayAttachmentId))
+		t.tagEC2NetworkResource(client, aws.ToString(attachment.TransitGatewayAttachmentId), "transit-gateway-vpc-attachment")
 	}
 }
 
@@ -206,15 +433,7 @@ func (t *AWSResourceTagger) tagTransitGatewayPeeringAttachments(client VPCEC2API
 	}
 
 	for _, attachment := range attachments.TransitGatewayPeeringAttachments {
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{aws.ToString(attachment.TransitGatewayAttachmentId)},
-			Tags:      t.convertToEC2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(attachment.TransitGatewayAttachmentId), "Transit Gateway Peering Attachment")
-			continue
-		}
-		log.Printf("Successfully tagged Transit Gateway peering attachment: %s", aws.ToString(attachment.TransitGatewayAttachmentId))
+		t.tagEC2NetworkResource(client, aws.ToString(attachment.TransitGatewayAttachmentId), "transit-gateway-peering-attachment")
 	}
 }
 
@@ -238,66 +457,68 @@ func (t *AWSResourceTagger) tagTransitGatewayDirectConnectAttachments(client VPC
 	}
 
 	for _, attachment := range attachments.TransitGatewayAttachments {
-		_, err := client.CreateTags(t.ctx, &ec2.CreateTagsInput{
-			Resources: []string{aws.ToString(attachment.TransitGatewayAttachmentId)},
-			Tags:      t.convertToEC2Tags(),
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(attachment.TransitGatewayAttachmentId), "Transit Gateway Direct Connect Attachment")
-			continue
-		}
-		log.Printf("Successfully tagged Transit Gateway Direct Connect attachment: %s", aws.ToString(attachment.TransitGatewayAttachmentId))
+		t.tagEC2NetworkResource(client, aws.ToString(attachment.TransitGatewayAttachmentId), "transit-gateway-direct-connect-attachment")
 	}
 }
 
-// tagVPCLatticeResources tags VPC Lattice resources (for plans after 10-May-2024)
-func (t *AWSResourceTagger) tagVPCLatticeResources() {
-	log.Println("Tagging VPC Lattice resources...")
-	client := vpclattice.NewFromConfig(t.cfg)
-
-	// Tag Service Networks
-	networks, err := client.ListServiceNetworks(t.ctx, &vpclattice.ListServiceNetworksInput{})
+// tagTransitGatewayConnectAttachments tags Transit Gateway Connect attachments and their Connect peers
+func (t *AWSResourceTagger) tagTransitGatewayConnectAttachments(client VPCEC2API, tgwID string) {
+	attachments, err := client.DescribeTransitGatewayAttachments(t.ctx, &ec2.DescribeTransitGatewayAttachmentsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("transit-gateway-id"),
+				Values: []string{tgwID},
+			},
+			{
+				Name:   aws.String("resource-type"),
+				Values: []string{"connect"},
+			},
+		},
+	})
 	if err != nil {
-		t.handleError(err, "all", "VPC Lattice Service Networks")
+		t.handleError(err, tgwID, "Transit Gateway Connect Attachments")
 		return
 	}
 
-	for _, network := range networks.Items {
-		_, err := client.TagResource(t.ctx, &vpclattice.TagResourceInput{
-			ResourceArn: network.Arn,
-			Tags:        t.tags, // Using the map[string]string directly
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(network.Name), "VPC Lattice Service Network")
-			continue
-		}
-		log.Printf("Successfully tagged VPC Lattice service network: %s", aws.ToString(network.Name))
+	for _, attachment := range attachments.TransitGatewayAttachments {
+		attachmentID := aws.ToString(attachment.TransitGatewayAttachmentId)
+		t.tagEC2NetworkResource(client, attachmentID, "transit-gateway-connect-attachment")
+
+		// Connect peers are tagged independently of their attachment
+		t.tagTransitGatewayConnectPeers(client, attachmentID)
 	}
+}
 
-	// Tag Services
-	services, err := client.ListServices(t.ctx, &vpclattice.ListServicesInput{})
+// tagTransitGatewayConnectPeers tags the Connect peers of a Transit Gateway Connect attachment
+func (t *AWSResourceTagger) tagTransitGatewayConnectPeers(client VPCEC2API, attachmentID string) {
+	peers, err := client.DescribeTransitGatewayConnectPeers(t.ctx, &ec2.DescribeTransitGatewayConnectPeersInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("transit-gateway-attachment-id"),
+				Values: []string{attachmentID},
+			},
+		},
+	})
 	if err != nil {
-		t.handleError(err, "all", "VPC Lattice Services")
+		t.handleError(err, attachmentID, "Transit Gateway Connect Peers")
 		return
 	}
 
-	for _, service := range services.Items {
-		_, err := client.TagResource(t.ctx, &vpclattice.TagResourceInput{
-			ResourceArn: service.Arn,
-			Tags:        t.tags, // Using the map[string]string directly
-		})
-		if err != nil {
-			t.handleError(err, aws.ToString(service.Name), "VPC Lattice Service")
-			continue
-		}
-		log.Printf("Successfully tagged VPC Lattice service: %s", aws.ToString(service.Name))
+	for _, peer := range peers.TransitGatewayConnectPeers {
+		t.tagEC2NetworkResource(client, aws.ToString(peer.TransitGatewayConnectPeerId), "transit-gateway-connect-peer")
 	}
 }
 
+// tagVPCLatticeResources tags VPC Lattice resources (for plans after 10-May-2024)
+func (t *AWSResourceTagger) tagVPCLatticeResources() {
+	t.tagVPCLatticeResourcesWithClient(vpclattice.NewFromConfig(t.cfg))
+}
+
 // convertToEC2Tags converts the common tags map to EC2-specific tags
 func (t *AWSResourceTagger) convertToEC2Tags() []types.Tag {
 	ec2Tags := make([]types.Tag, 0, len(t.tags))
-	for k, v := range t.tags {
+	for _, k := range t.orderedTagKeys() {
+		v := t.tags[k]
 		ec2Tags = append(ec2Tags, types.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
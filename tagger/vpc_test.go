@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
 	vpclatticeTypes "github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -42,6 +43,71 @@ func (m *MockVPCClient) DescribeTransitGatewayPeeringAttachments(ctx context.Con
 	return args.Get(0).(*ec2.DescribeTransitGatewayPeeringAttachmentsOutput), args.Error(1)
 }
 
+func (m *MockVPCClient) DescribeTransitGatewayConnectPeers(ctx context.Context, params *ec2.DescribeTransitGatewayConnectPeersInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayConnectPeersOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeTransitGatewayConnectPeersOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeVpcPeeringConnections(ctx context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeVpcPeeringConnectionsOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeSubnetsOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeSecurityGroupsOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeRouteTablesOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeNatGateways(ctx context.Context, params *ec2.DescribeNatGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNatGatewaysOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeNatGatewaysOutput), args.Error(1)
+}
+
+func (m *MockVPCClient) DescribeInternetGateways(ctx context.Context, params *ec2.DescribeInternetGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInternetGatewaysOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ec2.DescribeInternetGatewaysOutput), args.Error(1)
+}
+
+// expectNoCoreNetworkResources mocks empty subnet, security group, route table and gateway listings
+func expectNoCoreNetworkResources(m *MockVPCClient) {
+	m.On("DescribeSubnets", mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{}, nil)
+	m.On("DescribeSecurityGroups", mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+	m.On("DescribeRouteTables", mock.Anything, mock.Anything).Return(&ec2.DescribeRouteTablesOutput{}, nil)
+	m.On("DescribeNatGateways", mock.Anything, mock.Anything).Return(&ec2.DescribeNatGatewaysOutput{}, nil)
+	m.On("DescribeInternetGateways", mock.Anything, mock.Anything).Return(&ec2.DescribeInternetGatewaysOutput{}, nil)
+}
+
 func (m *MockVPCClient) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -71,6 +137,22 @@ func (m *MockVPCLatticeClient) ListServices(ctx context.Context, params *vpclatt
 	return args.Get(0).(*vpclattice.ListServicesOutput), args.Error(1)
 }
 
+func (m *MockVPCLatticeClient) ListTargetGroups(ctx context.Context, params *vpclattice.ListTargetGroupsInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListTargetGroupsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vpclattice.ListTargetGroupsOutput), args.Error(1)
+}
+
+func (m *MockVPCLatticeClient) ListListeners(ctx context.Context, params *vpclattice.ListListenersInput, optFns ...func(*vpclattice.Options)) (*vpclattice.ListListenersOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vpclattice.ListListenersOutput), args.Error(1)
+}
+
 func (m *MockVPCLatticeClient) TagResource(ctx context.Context, params *vpclattice.TagResourceInput, optFns ...func(*vpclattice.Options)) (*vpclattice.TagResourceOutput, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -165,6 +247,11 @@ func TestTagVPCResourcesWithClients(t *testing.T) {
 					},
 				}, nil)
 
+				// No Connect attachments on this gateway
+				m.On("DescribeTransitGatewayAttachments", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeTransitGatewayAttachmentsInput) bool {
+					return len(input.Filters) == 2 && input.Filters[1].Values[0] == "connect"
+				})).Return(&ec2.DescribeTransitGatewayAttachmentsOutput{}, nil)
+
 				// Setup Peering Attachments
 				m.On("DescribeTransitGatewayPeeringAttachments", mock.Anything, mock.Anything).
 					Return(&ec2.DescribeTransitGatewayPeeringAttachmentsOutput{
@@ -176,6 +263,14 @@ func TestTagVPCResourcesWithClients(t *testing.T) {
 						},
 					}, nil)
 
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+						VpcPeeringConnections: []types.VpcPeeringConnection{
+							{VpcPeeringConnectionId: aws.String("pcx-123")},
+						},
+					}, nil)
+				expectNoCoreNetworkResources(m)
+
 				// Setup CreateTags for all resources
 				m.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
 			},
@@ -199,6 +294,10 @@ func TestTagVPCResourcesWithClients(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.On("ListListeners", mock.Anything, mock.Anything).
+					Return(&vpclattice.ListListenersOutput{}, nil)
+				m.On("ListTargetGroups", mock.Anything, mock.Anything).
+					Return(&vpclattice.ListTargetGroupsOutput{}, nil)
 
 				m.On("TagResource", mock.Anything, mock.Anything).Return(&vpclattice.TagResourceOutput{}, nil)
 			},
@@ -209,12 +308,17 @@ func TestTagVPCResourcesWithClients(t *testing.T) {
 			setupEC2Mocks: func(m *MockVPCClient) {
 				m.On("DescribeTransitGateways", mock.Anything, mock.Anything).
 					Return(nil, errors.New("API error"))
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeVpcPeeringConnectionsOutput{}, nil)
+				expectNoCoreNetworkResources(m)
 			},
 			setupLatticeMocks: func(m *MockVPCLatticeClient) {
 				m.On("ListServiceNetworks", mock.Anything, mock.Anything).
 					Return(&vpclattice.ListServiceNetworksOutput{}, nil)
 				m.On("ListServices", mock.Anything, mock.Anything).
 					Return(&vpclattice.ListServicesOutput{}, nil)
+				m.On("ListTargetGroups", mock.Anything, mock.Anything).
+					Return(&vpclattice.ListTargetGroupsOutput{}, nil)
 			},
 			expectError: true,
 		},
@@ -344,6 +448,11 @@ func TestTagTransitGatewayResources(t *testing.T) {
 					},
 				}, nil)
 
+				// No Connect attachments on this gateway
+				m.On("DescribeTransitGatewayAttachments", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeTransitGatewayAttachmentsInput) bool {
+					return len(input.Filters) == 2 && input.Filters[1].Values[0] == "connect"
+				})).Return(&ec2.DescribeTransitGatewayAttachmentsOutput{}, nil)
+
 				// Setup DescribeTransitGatewayPeeringAttachments
 				m.On("DescribeTransitGatewayPeeringAttachments", mock.Anything, mock.Anything).
 					Return(&ec2.DescribeTransitGatewayPeeringAttachmentsOutput{
@@ -413,6 +522,11 @@ func TestTagVPCLatticeResources(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.On("ListListeners", mock.Anything, &vpclattice.ListListenersInput{
+					ServiceIdentifier: aws.String("arn:aws:vpclattice:region:account:service/service1"),
+				}).Return(&vpclattice.ListListenersOutput{}, nil)
+				m.On("ListTargetGroups", mock.Anything, &vpclattice.ListTargetGroupsInput{}).
+					Return(&vpclattice.ListTargetGroupsOutput{}, nil)
 
 				m.On("TagResource", mock.Anything, mock.Anything).
 					Return(&vpclattice.TagResourceOutput{}, nil)
@@ -447,3 +561,439 @@ func TestTagVPCLatticeResources(t *testing.T) {
 		})
 	}
 }
+
+func TestTagVPCLatticeListeners(t *testing.T) {
+	serviceArn := "arn:aws:vpclattice:us-west-2:123456789012:service/svc-1"
+	mockClient := new(MockVPCLatticeClient)
+	mockClient.On("ListListeners", mock.Anything, &vpclattice.ListListenersInput{
+		ServiceIdentifier: aws.String(serviceArn),
+	}).Return(&vpclattice.ListListenersOutput{
+		Items: []vpclatticeTypes.ListenerSummary{
+			{Arn: aws.String(serviceArn + "/listener/listener-1")},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil).Once()
+	mockClient.On("ListListeners", mock.Anything, &vpclattice.ListListenersInput{
+		ServiceIdentifier: aws.String(serviceArn),
+		NextToken:         aws.String("page-2"),
+	}).Return(&vpclattice.ListListenersOutput{
+		Items: []vpclatticeTypes.ListenerSummary{
+			{Arn: aws.String(serviceArn + "/listener/listener-2")},
+		},
+	}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &vpclattice.TagResourceInput{
+		ResourceArn: aws.String(serviceArn + "/listener/listener-1"),
+		Tags:        map[string]string{"Environment": "Test"},
+	}).Return(&vpclattice.TagResourceOutput{}, nil).Once()
+	mockClient.On("TagResource", mock.Anything, &vpclattice.TagResourceInput{
+		ResourceArn: aws.String(serviceArn + "/listener/listener-2"),
+		Tags:        map[string]string{"Environment": "Test"},
+	}).Return(&vpclattice.TagResourceOutput{}, nil).Once()
+
+	tagger := &AWSResourceTagger{
+		ctx:     context.Background(),
+		tags:    map[string]string{"Environment": "Test"},
+		results: NewResultCollector(),
+	}
+	tagger.tagVPCLatticeListeners(mockClient, serviceArn)
+
+	mockClient.AssertExpectations(t)
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, TagResult{
+		Service:      "VPC Lattice",
+		ResourceType: "listener",
+		ResourceID:   "listener-1",
+		ARN:          serviceArn + "/listener/listener-1",
+		Status:       StatusTagged,
+		Tags:         tagger.tags,
+	}, results[0])
+}
+
+func TestTagVPCLatticeTargetGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(*MockVPCLatticeClient)
+	}{
+		{
+			name: "Tag target groups across pages",
+			setupMocks: func(m *MockVPCLatticeClient) {
+				m.On("ListTargetGroups", mock.Anything, &vpclattice.ListTargetGroupsInput{}).
+					Return(&vpclattice.ListTargetGroupsOutput{
+						Items: []vpclatticeTypes.TargetGroupSummary{
+							{Arn: aws.String("arn:aws:vpclattice:us-west-2:123456789012:targetgroup/tg-1")},
+						},
+						NextToken: aws.String("page-2"),
+					}, nil).Once()
+				m.On("ListTargetGroups", mock.Anything, &vpclattice.ListTargetGroupsInput{NextToken: aws.String("page-2")}).
+					Return(&vpclattice.ListTargetGroupsOutput{
+						Items: []vpclatticeTypes.TargetGroupSummary{
+							{Arn: aws.String("arn:aws:vpclattice:us-west-2:123456789012:targetgroup/tg-2")},
+						},
+					}, nil).Once()
+				for _, id := range []string{"tg-1", "tg-2"} {
+					m.On("TagResource", mock.Anything, &vpclattice.TagResourceInput{
+						ResourceArn: aws.String("arn:aws:vpclattice:us-west-2:123456789012:targetgroup/" + id),
+						Tags:        map[string]string{"Environment": "Test"},
+					}).Return(&vpclattice.TagResourceOutput{}, nil).Once()
+				}
+			},
+		},
+		{
+			name: "Handle ListTargetGroups error",
+			setupMocks: func(m *MockVPCLatticeClient) {
+				m.On("ListTargetGroups", mock.Anything, &vpclattice.ListTargetGroupsInput{}).
+					Return(nil, errors.New("API error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockVPCLatticeClient)
+			tt.setupMocks(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"Environment": "Test"},
+			}
+			tagger.tagVPCLatticeTargetGroups(mockClient)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagTransitGatewayConnectAttachments(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(*MockVPCClient)
+		expectTagged []string
+	}{
+		{
+			name: "Successfully tag Connect attachments and peers",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeTransitGatewayAttachments", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeTransitGatewayAttachmentsInput) bool {
+					return len(input.Filters) == 2 &&
+						input.Filters[0].Values[0] == "tgw-123" &&
+						*input.Filters[1].Name == "resource-type" && input.Filters[1].Values[0] == "connect"
+				})).Return(&ec2.DescribeTransitGatewayAttachmentsOutput{
+					TransitGatewayAttachments: []types.TransitGatewayAttachment{
+						{
+							TransitGatewayAttachmentId: aws.String("tgw-attach-connect-1"),
+							ResourceType:               types.TransitGatewayAttachmentResourceTypeConnect,
+						},
+					},
+				}, nil)
+
+				m.On("DescribeTransitGatewayConnectPeers", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeTransitGatewayConnectPeersInput) bool {
+					return len(input.Filters) == 1 &&
+						*input.Filters[0].Name == "transit-gateway-attachment-id" &&
+						input.Filters[0].Values[0] == "tgw-attach-connect-1"
+				})).Return(&ec2.DescribeTransitGatewayConnectPeersOutput{
+					TransitGatewayConnectPeers: []types.TransitGatewayConnectPeer{
+						{TransitGatewayConnectPeerId: aws.String("tgw-connect-peer-1")},
+						{TransitGatewayConnectPeerId: aws.String("tgw-connect-peer-2")},
+					},
+				}, nil)
+
+				m.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectTagged: []string{"tgw-attach-connect-1", "tgw-connect-peer-1", "tgw-connect-peer-2"},
+		},
+		{
+			name: "Continue tagging peers when attachment and peer tagging fail",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeTransitGatewayAttachments", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeTransitGatewayAttachmentsOutput{
+						TransitGatewayAttachments: []types.TransitGatewayAttachment{
+							{TransitGatewayAttachmentId: aws.String("tgw-attach-connect-1")},
+						},
+					}, nil)
+
+				m.On("DescribeTransitGatewayConnectPeers", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeTransitGatewayConnectPeersOutput{
+						TransitGatewayConnectPeers: []types.TransitGatewayConnectPeer{
+							{TransitGatewayConnectPeerId: aws.String("tgw-connect-peer-1")},
+							{TransitGatewayConnectPeerId: aws.String("tgw-connect-peer-2")},
+						},
+					}, nil)
+
+				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == "tgw-attach-connect-1" || input.Resources[0] == "tgw-connect-peer-1"
+				})).Return(nil, errors.New("API error"))
+				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == "tgw-connect-peer-2"
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectTagged: []string{"tgw-attach-connect-1", "tgw-connect-peer-1", "tgw-connect-peer-2"},
+		},
+		{
+			name: "Handle DescribeTransitGatewayConnectPeers error",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeTransitGatewayAttachments", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeTransitGatewayAttachmentsOutput{
+						TransitGatewayAttachments: []types.TransitGatewayAttachment{
+							{TransitGatewayAttachmentId: aws.String("tgw-attach-connect-1")},
+						},
+					}, nil)
+				m.On("DescribeTransitGatewayConnectPeers", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
+				m.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectTagged: []string{"tgw-attach-connect-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockVPCClient)
+			tt.setupMocks(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"Environment": "Test"},
+			}
+
+			tagger.tagTransitGatewayConnectAttachments(mockClient, "tgw-123")
+
+			mockClient.AssertExpectations(t)
+			mockClient.AssertNumberOfCalls(t, "CreateTags", len(tt.expectTagged))
+			for _, id := range tt.expectTagged {
+				mockClient.AssertCalled(t, "CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == id
+				}))
+			}
+		})
+	}
+}
+
+func TestTagVPCPeeringConnections(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(*MockVPCClient)
+		expectTagged []string
+	}{
+		{
+			name: "Tag active and pending peering connections across pages",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeVpcPeeringConnectionsInput) bool {
+					return input.NextToken == nil &&
+						len(input.Filters) == 1 &&
+						*input.Filters[0].Name == "status-code" &&
+						len(input.Filters[0].Values) == 2 &&
+						input.Filters[0].Values[0] == "active" && input.Filters[0].Values[1] == "pending-acceptance"
+				})).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+					VpcPeeringConnections: []types.VpcPeeringConnection{
+						{VpcPeeringConnectionId: aws.String("pcx-1")},
+					},
+					NextToken: aws.String("page-2"),
+				}, nil)
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeVpcPeeringConnectionsInput) bool {
+					return aws.ToString(input.NextToken) == "page-2"
+				})).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+					VpcPeeringConnections: []types.VpcPeeringConnection{
+						{VpcPeeringConnectionId: aws.String("pcx-2")},
+					},
+				}, nil)
+
+				m.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectTagged: []string{"pcx-1", "pcx-2"},
+		},
+		{
+			name: "Continue after CreateTags error",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+						VpcPeeringConnections: []types.VpcPeeringConnection{
+							{VpcPeeringConnectionId: aws.String("pcx-1")},
+							{VpcPeeringConnectionId: aws.String("pcx-2")},
+						},
+					}, nil)
+
+				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == "pcx-1"
+				})).Return(nil, errors.New("API error"))
+				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == "pcx-2"
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectTagged: []string{"pcx-1", "pcx-2"},
+		},
+		{
+			name: "Handle DescribeVpcPeeringConnections error",
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeVpcPeeringConnections", mock.Anything, mock.Anything).
+					Return(nil, errors.New("API error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockVPCClient)
+			tt.setupMocks(mockClient)
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"Environment": "Test"},
+			}
+
+			tagger.tagVPCPeeringConnectionsWithClient(mockClient)
+
+			mockClient.AssertExpectations(t)
+			mockClient.AssertNumberOfCalls(t, "CreateTags", len(tt.expectTagged))
+			for _, id := range tt.expectTagged {
+				mockClient.AssertCalled(t, "CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == id
+				}))
+			}
+		})
+	}
+}
+
+func TestTagVPCCoreNetworkResources(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagFunc      func(*AWSResourceTagger, VPCEC2API)
+		setupMocks   func(*MockVPCClient)
+		expectTagged []string
+	}{
+		{
+			name:    "Tag subnets across pages",
+			tagFunc: (*AWSResourceTagger).tagSubnetsWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets:   []types.Subnet{{SubnetId: aws.String("subnet-1")}},
+						NextToken: aws.String("page-2"),
+					}, nil)
+				m.On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{NextToken: aws.String("page-2")}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []types.Subnet{{SubnetId: aws.String("subnet-2")}},
+					}, nil)
+			},
+			expectTagged: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name:    "Skip the default security group",
+			tagFunc: (*AWSResourceTagger).tagSecurityGroupsWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeSecurityGroups", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []types.SecurityGroup{
+							{GroupId: aws.String("sg-default"), GroupName: aws.String("default")},
+							{GroupId: aws.String("sg-web"), GroupName: aws.String("web")},
+						},
+					}, nil)
+			},
+			expectTagged: []string{"sg-web"},
+		},
+		{
+			name:    "Tag route tables",
+			tagFunc: (*AWSResourceTagger).tagRouteTablesWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeRouteTables", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []types.RouteTable{{RouteTableId: aws.String("rtb-1")}},
+					}, nil)
+			},
+			expectTagged: []string{"rtb-1"},
+		},
+		{
+			name:    "Tag internet gateways",
+			tagFunc: (*AWSResourceTagger).tagInternetGatewaysWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeInternetGateways", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeInternetGatewaysOutput{
+						InternetGateways: []types.InternetGateway{{InternetGatewayId: aws.String("igw-1")}},
+					}, nil)
+			},
+			expectTagged: []string{"igw-1"},
+		},
+		{
+			name:    "Tag pending and available NAT gateways",
+			tagFunc: (*AWSResourceTagger).tagNATGatewaysWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeNatGateways", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeNatGatewaysInput) bool {
+					return len(input.Filter) == 1 && *input.Filter[0].Name == "state" &&
+						len(input.Filter[0].Values) == 2
+				})).Return(&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []types.NatGateway{{NatGatewayId: aws.String("nat-1")}},
+				}, nil)
+			},
+			expectTagged: []string{"nat-1"},
+		},
+		{
+			name:    "Handle empty result sets",
+			tagFunc: (*AWSResourceTagger).tagSubnetsWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeSubnets", mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{}, nil)
+			},
+		},
+		{
+			name:    "Handle describe errors",
+			tagFunc: (*AWSResourceTagger).tagRouteTablesWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeRouteTables", mock.Anything, mock.Anything).Return(nil, errors.New("API error"))
+			},
+		},
+		{
+			name:    "Continue after CreateTags error",
+			tagFunc: (*AWSResourceTagger).tagSubnetsWithClient,
+			setupMocks: func(m *MockVPCClient) {
+				m.On("DescribeSubnets", mock.Anything, mock.Anything).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []types.Subnet{{SubnetId: aws.String("subnet-1")}, {SubnetId: aws.String("subnet-2")}},
+					}, nil)
+				m.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == "subnet-1"
+				})).Return(nil, errors.New("API error"))
+			},
+			expectTagged: []string{"subnet-1", "subnet-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockVPCClient)
+			tt.setupMocks(mockClient)
+			mockClient.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+
+			tagger := &AWSResourceTagger{
+				ctx:  context.Background(),
+				tags: map[string]string{"Environment": "Test"},
+			}
+
+			tt.tagFunc(tagger, mockClient)
+
+			mockClient.AssertNumberOfCalls(t, "CreateTags", len(tt.expectTagged))
+			for _, id := range tt.expectTagged {
+				mockClient.AssertCalled(t, "CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+					return input.Resources[0] == id
+				}))
+			}
+		})
+	}
+}
+
+func TestVPCCoreNetworkResourcesAreRecorded(t *testing.T) {
+	mockClient := new(MockVPCClient)
+	mockClient.On("DescribeSubnets", mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []types.Subnet{{SubnetId: aws.String("subnet-1")}, {SubnetId: aws.String("subnet-2")}},
+	}, nil)
+	mockClient.On("CreateTags", mock.Anything, mock.MatchedBy(func(input *ec2.CreateTagsInput) bool {
+		return input.Resources[0] == "subnet-1"
+	})).Return(nil, errors.New("API error"))
+	mockClient.On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+
+	tagger := createTestTagger()
+	tagger.results = NewResultCollector()
+	tagger.tagSubnetsWithClient(mockClient)
+
+	results := tagger.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, TagResult{Service: "VPC", ResourceType: "subnet", ResourceID: "subnet-1", Status: StatusFailed, Error: "API error"}, results[0])
+	assert.Equal(t, TagResult{Service: "VPC", ResourceType: "subnet", ResourceID: "subnet-2", Status: StatusTagged, Tags: tagger.tags}, results[1])
+}